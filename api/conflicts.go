@@ -0,0 +1,34 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/containous/traefik/log"
+)
+
+// FrontendConflict describes two frontends of an entry point whose rules can match the same requests.
+// Frontends are listed in the order they are evaluated.
+type FrontendConflict struct {
+	EntryPoint  string   `json:"entryPoint"`
+	Kind        string   `json:"kind"`
+	Frontends   []string `json:"frontends"`
+	Priorities  []int    `json:"priorities"`
+	Description string   `json:"description"`
+}
+
+func (p Handler) getConflictsHandler(response http.ResponseWriter, request *http.Request) {
+	if p.FrontendConflicts == nil {
+		http.NotFound(response, request)
+		return
+	}
+
+	conflicts, _ := p.FrontendConflicts.Get().([]FrontendConflict)
+	if conflicts == nil {
+		conflicts = []FrontendConflict{}
+	}
+
+	err := templatesRenderer.JSON(response, http.StatusOK, conflicts)
+	if err != nil {
+		log.Error(err)
+	}
+}
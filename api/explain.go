@@ -0,0 +1,113 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/containous/traefik/log"
+)
+
+// RouteExplainer explains how an entry point routes a request.
+type RouteExplainer interface {
+	ExplainRoute(entryPointName string, req *http.Request) (*RouteExplanation, error)
+}
+
+// ExplainRequest describes the synthetic request to route.
+type ExplainRequest struct {
+	EntryPoint string            `json:"entryPoint"`
+	Method     string            `json:"method,omitempty"`
+	URL        string            `json:"url"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	RemoteAddr string            `json:"remoteAddr,omitempty"`
+}
+
+// RouteExplanation describes the frontend selected for a request,
+// along with every frontend of the entry point in the order they are evaluated.
+type RouteExplanation struct {
+	EntryPoint string                `json:"entryPoint"`
+	Frontend   string                `json:"frontend,omitempty"`
+	Backend    string                `json:"backend,omitempty"`
+	Frontends  []FrontendExplanation `json:"frontends"`
+}
+
+// FrontendExplanation describes how a frontend evaluated a request.
+type FrontendExplanation struct {
+	Name        string                           `json:"name"`
+	Backend     string                           `json:"backend,omitempty"`
+	Priority    int                              `json:"priority"`
+	Matched     bool                             `json:"matched"`
+	Routes      map[string]RouteRulesExplanation `json:"routes,omitempty"`
+	Middlewares []string                         `json:"middlewares,omitempty"`
+}
+
+// RouteRulesExplanation describes the evaluation of the rules of a frontend route.
+type RouteRulesExplanation struct {
+	Rule  string           `json:"rule"`
+	Rules []RuleEvaluation `json:"rules,omitempty"`
+	Error string           `json:"error,omitempty"`
+}
+
+// RuleEvaluation is the result of matching a single rule of an expression.
+// The result of a negated rule is reported before applying the negation.
+type RuleEvaluation struct {
+	Rule     string `json:"rule"`
+	Matched  bool   `json:"matched"`
+	Negated  bool   `json:"negated,omitempty"`
+	Modifier bool   `json:"modifier,omitempty"`
+}
+
+func (p Handler) explainRouteHandler(response http.ResponseWriter, request *http.Request) {
+	if p.RouteExplainer == nil {
+		http.NotFound(response, request)
+		return
+	}
+
+	explainRequest := &ExplainRequest{}
+	if err := json.NewDecoder(request.Body).Decode(explainRequest); err != nil {
+		http.Error(response, fmt.Sprintf("invalid explain request: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	req, err := explainRequest.newRequest()
+	if err != nil {
+		http.Error(response, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	explanation, err := p.RouteExplainer.ExplainRoute(explainRequest.EntryPoint, req)
+	if err != nil {
+		http.Error(response, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	err = templatesRenderer.JSON(response, http.StatusOK, explanation)
+	if err != nil {
+		log.Error(err)
+	}
+}
+
+func (e *ExplainRequest) newRequest() (*http.Request, error) {
+	if e.EntryPoint == "" {
+		return nil, errors.New("missing entry point")
+	}
+
+	method := e.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	req, err := http.NewRequest(method, e.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL %q: %v", e.URL, err)
+	}
+	for name, value := range e.Headers {
+		req.Header.Set(name, value)
+	}
+	if host := req.Header.Get("Host"); host != "" {
+		req.Host = host
+	}
+	req.RemoteAddr = e.RemoteAddr
+	return req, nil
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/containous/mux"
+	"github.com/containous/traefik/healthcheck"
 	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/middlewares"
 	"github.com/containous/traefik/safe"
@@ -22,6 +23,9 @@ type Handler struct {
 	Statistics            *types.Statistics          `description:"Enable more detailed statistics" export:"true"`
 	Stats                 *thoas_stats.Stats         `json:"-"`
 	StatsRecorder         *middlewares.StatsRecorder `json:"-"`
+	RouteExplainer        RouteExplainer             `json:"-"`
+	FrontendConflicts     *safe.Safe                 `json:"-"`
+	HealthCheck           *healthcheck.HealthCheck   `json:"-"`
 }
 
 var (
@@ -47,6 +51,10 @@ func (p Handler) AddRoutes(router *mux.Router) {
 	router.Methods(http.MethodGet).Path("/api/providers/{provider}/frontends/{frontend}").HandlerFunc(p.getFrontendHandler)
 	router.Methods(http.MethodGet).Path("/api/providers/{provider}/frontends/{frontend}/routes").HandlerFunc(p.getRoutesHandler)
 	router.Methods(http.MethodGet).Path("/api/providers/{provider}/frontends/{frontend}/routes/{route}").HandlerFunc(p.getRouteHandler)
+	router.Methods(http.MethodPost).Path("/api/explain").HandlerFunc(p.explainRouteHandler)
+	router.Methods(http.MethodGet).Path("/api/conflicts").HandlerFunc(p.getConflictsHandler)
+	router.Methods(http.MethodGet).Path("/api/healthcheck").HandlerFunc(p.getHealthCheckHandler)
+	router.Methods(http.MethodGet).Path("/api/healthcheck/{backend}").HandlerFunc(p.getBackendHealthCheckHandler)
 
 	// health route
 	router.Methods(http.MethodGet).Path("/health").HandlerFunc(p.getHealthHandler)
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/containous/mux"
+	"github.com/containous/traefik/log"
+)
+
+func (p Handler) getHealthCheckHandler(response http.ResponseWriter, request *http.Request) {
+	if p.HealthCheck == nil {
+		http.NotFound(response, request)
+		return
+	}
+
+	err := templatesRenderer.JSON(response, http.StatusOK, p.HealthCheck.Status())
+	if err != nil {
+		log.Error(err)
+	}
+}
+
+func (p Handler) getBackendHealthCheckHandler(response http.ResponseWriter, request *http.Request) {
+	if p.HealthCheck == nil {
+		http.NotFound(response, request)
+		return
+	}
+
+	backendID := mux.Vars(request)["backend"]
+	if statuses, ok := p.HealthCheck.Status()[backendID]; ok {
+		err := templatesRenderer.JSON(response, http.StatusOK, statuses)
+		if err != nil {
+			log.Error(err)
+		}
+		return
+	}
+	http.NotFound(response, request)
+}
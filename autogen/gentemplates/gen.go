@@ -72,6 +72,20 @@ var _templatesConsul_catalogTmpl = []byte(`[backends]
     {{if $loadBalancer.Stickiness }}
     [backends."backend-{{ $backendName }}".loadBalancer.stickiness]
       cookieName = "{{ $loadBalancer.Stickiness.CookieName }}"
+      secret = "{{ $loadBalancer.Stickiness.Secret }}"
+      secure = {{ $loadBalancer.Stickiness.Secure }}
+      httpOnly = {{ $loadBalancer.Stickiness.HTTPOnly }}
+      sameSite = "{{ $loadBalancer.Stickiness.SameSite }}"
+      maxAge = {{ $loadBalancer.Stickiness.MaxAge }}
+      domain = "{{ $loadBalancer.Stickiness.Domain }}"
+      path = "{{ $loadBalancer.Stickiness.Path }}"
+    {{end}}
+    {{if $loadBalancer.Hash }}
+    [backends."backend-{{ $backendName }}".loadBalancer.hash]
+      key = "{{ $loadBalancer.Hash.Key }}"
+      {{if $loadBalancer.Hash.LoadFactor }}
+      loadFactor = {{ printf "%f" $loadBalancer.Hash.LoadFactor }}
+      {{end}}
     {{end}}
   {{end}}
 
@@ -85,9 +99,29 @@ var _templatesConsul_catalogTmpl = []byte(`[backends]
   {{ $healthCheck := getHealthCheck $service.Attributes }}
   {{if $healthCheck }}
   [backends.backend-{{ $backendName }}.healthCheck]
+    type = "{{ $healthCheck.Type }}"
     path = "{{ $healthCheck.Path }}"
     port = {{ $healthCheck.Port }}
     interval = "{{ $healthCheck.Interval }}"
+    timeout = "{{ $healthCheck.Timeout }}"
+    scheme = "{{ $healthCheck.Scheme }}"
+    method = "{{ $healthCheck.Method }}"
+    hostname = "{{ $healthCheck.Hostname }}"
+    {{if $healthCheck.StatusCodes }}
+    statusCodes = [{{range $healthCheck.StatusCodes }}
+      "{{.}}",
+      {{end}}]
+    {{end}}
+    body = '''{{ $healthCheck.Body }}'''
+    rise = {{ $healthCheck.Rise }}
+    fall = {{ $healthCheck.Fall }}
+    grpcService = "{{ $healthCheck.GRPCService }}"
+    {{if $healthCheck.Headers }}
+    [backends.backend-{{ $backendName }}.healthCheck.headers]
+      {{range $k, $v := $healthCheck.Headers }}
+      {{$k}} = "{{$v}}"
+      {{end}}
+    {{end}}
   {{end}}
 
   {{ $buffering := getBuffering $service.Attributes }}
@@ -100,6 +134,48 @@ var _templatesConsul_catalogTmpl = []byte(`[backends]
     retryExpression = "{{ $buffering.RetryExpression }}"
   {{end}}
 
+  {{ $transport := getTransport $service.Attributes }}
+  {{if $transport }}
+  [backends.backend-{{ $backendName }}.transport]
+    dialTimeout = "{{ $transport.DialTimeout }}"
+    responseHeaderTimeout = "{{ $transport.ResponseHeaderTimeout }}"
+    idleConnTimeout = "{{ $transport.IdleConnTimeout }}"
+    maxIdleConnsPerHost = {{ $transport.MaxIdleConnsPerHost }}
+    disableKeepAlives = {{ $transport.DisableKeepAlives }}
+    disableHTTP2 = {{ $transport.DisableHTTP2 }}
+    {{if $transport.TLS }}
+    [backends.backend-{{ $backendName }}.transport.tls]
+      ca = '''{{ $transport.TLS.CA }}'''
+      serverName = "{{ $transport.TLS.ServerName }}"
+      cert = '''{{ $transport.TLS.Cert }}'''
+      key = '''{{ $transport.TLS.Key }}'''
+      insecureSkipVerify = {{ $transport.TLS.InsecureSkipVerify }}
+    {{end}}
+  {{end}}
+
+  {{ $retry := getRetry $service.Attributes }}
+  {{if $retry }}
+  [backends.backend-{{ $backendName }}.retry]
+    attempts = {{ $retry.Attempts }}
+    {{if $retry.StatusCodes }}
+    statusCodes = [{{range $retry.StatusCodes }}
+      "{{.}}",
+      {{end}}]
+    {{end}}
+    idempotentOnly = {{ $retry.IdempotentOnly }}
+    perTryTimeout = "{{ $retry.PerTryTimeout }}"
+    backoff = "{{ $retry.Backoff }}"
+    maxBackoff = "{{ $retry.MaxBackoff }}"
+    budgetPercent = {{ $retry.BudgetPercent }}
+  {{end}}
+
+  {{ $hedging := getHedging $service.Attributes }}
+  {{if $hedging }}
+  [backends.backend-{{ $backendName }}.hedging]
+    delay = "{{ $hedging.Delay }}"
+    percentile = {{ $hedging.Percentile }}
+  {{end}}
+
 {{end}}
 {{range $index, $node := .Nodes}}
 
@@ -247,6 +323,12 @@ var _templatesDockerTmpl = []byte(`{{$backendServers := .Servers}}
 [backends]
 {{range $backendName, $backend := .Backends}}
 
+  {{ $backendProtocol := getBackendProtocol $backend }}
+  {{if $backendProtocol }}
+  [backends."backend-{{ $backendName }}"]
+    protocol = "{{ $backendProtocol }}"
+  {{end}}
+
   {{ $circuitBreaker := getCircuitBreaker $backend }}
   {{if $circuitBreaker }}
   [backends."backend-{{ $backendName }}".circuitBreaker]
@@ -261,6 +343,20 @@ var _templatesDockerTmpl = []byte(`{{$backendServers := .Servers}}
       {{if $loadBalancer.Stickiness }}
       [backends."backend-{{ $backendName }}".loadBalancer.stickiness]
         cookieName = "{{ $loadBalancer.Stickiness.CookieName }}"
+        secret = "{{ $loadBalancer.Stickiness.Secret }}"
+        secure = {{ $loadBalancer.Stickiness.Secure }}
+        httpOnly = {{ $loadBalancer.Stickiness.HTTPOnly }}
+        sameSite = "{{ $loadBalancer.Stickiness.SameSite }}"
+        maxAge = {{ $loadBalancer.Stickiness.MaxAge }}
+        domain = "{{ $loadBalancer.Stickiness.Domain }}"
+        path = "{{ $loadBalancer.Stickiness.Path }}"
+      {{end}}
+      {{if $loadBalancer.Hash }}
+      [backends."backend-{{ $backendName }}".loadBalancer.hash]
+        key = "{{ $loadBalancer.Hash.Key }}"
+        {{if $loadBalancer.Hash.LoadFactor }}
+        loadFactor = {{ printf "%f" $loadBalancer.Hash.LoadFactor }}
+        {{end}}
       {{end}}
   {{end}}
 
@@ -274,9 +370,29 @@ var _templatesDockerTmpl = []byte(`{{$backendServers := .Servers}}
   {{ $healthCheck := getHealthCheck $backend }}
   {{if $healthCheck }}
   [backends.backend-{{ $backendName }}.healthCheck]
+    type = "{{ $healthCheck.Type }}"
     path = "{{ $healthCheck.Path }}"
     port = {{ $healthCheck.Port }}
     interval = "{{ $healthCheck.Interval }}"
+    timeout = "{{ $healthCheck.Timeout }}"
+    scheme = "{{ $healthCheck.Scheme }}"
+    method = "{{ $healthCheck.Method }}"
+    hostname = "{{ $healthCheck.Hostname }}"
+    {{if $healthCheck.StatusCodes }}
+    statusCodes = [{{range $healthCheck.StatusCodes }}
+      "{{.}}",
+      {{end}}]
+    {{end}}
+    body = '''{{ $healthCheck.Body }}'''
+    rise = {{ $healthCheck.Rise }}
+    fall = {{ $healthCheck.Fall }}
+    grpcService = "{{ $healthCheck.GRPCService }}"
+    {{if $healthCheck.Headers }}
+    [backends.backend-{{ $backendName }}.healthCheck.headers]
+      {{range $k, $v := $healthCheck.Headers }}
+      {{$k}} = "{{$v}}"
+      {{end}}
+    {{end}}
   {{end}}
 
   {{ $buffering := getBuffering $backend }}
@@ -289,6 +405,48 @@ var _templatesDockerTmpl = []byte(`{{$backendServers := .Servers}}
     retryExpression = "{{ $buffering.RetryExpression }}"
   {{end}}
 
+  {{ $transport := getTransport $backend }}
+  {{if $transport }}
+  [backends.backend-{{ $backendName }}.transport]
+    dialTimeout = "{{ $transport.DialTimeout }}"
+    responseHeaderTimeout = "{{ $transport.ResponseHeaderTimeout }}"
+    idleConnTimeout = "{{ $transport.IdleConnTimeout }}"
+    maxIdleConnsPerHost = {{ $transport.MaxIdleConnsPerHost }}
+    disableKeepAlives = {{ $transport.DisableKeepAlives }}
+    disableHTTP2 = {{ $transport.DisableHTTP2 }}
+    {{if $transport.TLS }}
+    [backends.backend-{{ $backendName }}.transport.tls]
+      ca = '''{{ $transport.TLS.CA }}'''
+      serverName = "{{ $transport.TLS.ServerName }}"
+      cert = '''{{ $transport.TLS.Cert }}'''
+      key = '''{{ $transport.TLS.Key }}'''
+      insecureSkipVerify = {{ $transport.TLS.InsecureSkipVerify }}
+    {{end}}
+  {{end}}
+
+  {{ $retry := getRetry $backend }}
+  {{if $retry }}
+  [backends.backend-{{ $backendName }}.retry]
+    attempts = {{ $retry.Attempts }}
+    {{if $retry.StatusCodes }}
+    statusCodes = [{{range $retry.StatusCodes }}
+      "{{.}}",
+      {{end}}]
+    {{end}}
+    idempotentOnly = {{ $retry.IdempotentOnly }}
+    perTryTimeout = "{{ $retry.PerTryTimeout }}"
+    backoff = "{{ $retry.Backoff }}"
+    maxBackoff = "{{ $retry.MaxBackoff }}"
+    budgetPercent = {{ $retry.BudgetPercent }}
+  {{end}}
+
+  {{ $hedging := getHedging $backend }}
+  {{if $hedging }}
+  [backends.backend-{{ $backendName }}.hedging]
+    delay = "{{ $hedging.Delay }}"
+    percentile = {{ $hedging.Percentile }}
+  {{end}}
+
   {{ $servers := index $backendServers $backendName }}
   {{range $serverName, $server := $servers }}
     {{if hasServices $server }}
@@ -476,6 +634,27 @@ var _templatesDockerTmpl = []byte(`{{$backendServers := .Servers}}
       {{end}}
     {{end}}
 
+    {{ $split := getSplit $container }}
+    {{if $split }}
+    [frontends."frontend-{{ $frontendName }}".split]
+      {{range $split.Backends }}
+      [[frontends."frontend-{{ $frontendName }}".split.backends]]
+        backend = "backend-{{ .Backend }}"
+        weight = {{ .Weight }}
+      {{end}}
+      {{if $split.Stickiness }}
+      [frontends."frontend-{{ $frontendName }}".split.stickiness]
+        cookieName = "{{ $split.Stickiness.CookieName }}"
+        secret = "{{ $split.Stickiness.Secret }}"
+        secure = {{ $split.Stickiness.Secure }}
+        httpOnly = {{ $split.Stickiness.HTTPOnly }}
+        sameSite = "{{ $split.Stickiness.SameSite }}"
+        maxAge = {{ $split.Stickiness.MaxAge }}
+        domain = "{{ $split.Stickiness.Domain }}"
+        path = "{{ $split.Stickiness.Path }}"
+      {{end}}
+    {{end}}
+
     {{ $rateLimit := getRateLimit $container }}
     {{if $rateLimit }}
     [frontends."frontend-{{ $frontendName }}".rateLimit]
@@ -583,6 +762,20 @@ var _templatesEcsTmpl = []byte(`[backends]
     {{if $loadBalancer.Stickiness }}
     [backends."backend-{{ $serviceName }}".loadBalancer.stickiness]
       cookieName = "{{ $loadBalancer.Stickiness.CookieName }}"
+      secret = "{{ $loadBalancer.Stickiness.Secret }}"
+      secure = {{ $loadBalancer.Stickiness.Secure }}
+      httpOnly = {{ $loadBalancer.Stickiness.HTTPOnly }}
+      sameSite = "{{ $loadBalancer.Stickiness.SameSite }}"
+      maxAge = {{ $loadBalancer.Stickiness.MaxAge }}
+      domain = "{{ $loadBalancer.Stickiness.Domain }}"
+      path = "{{ $loadBalancer.Stickiness.Path }}"
+    {{end}}
+    {{if $loadBalancer.Hash }}
+    [backends."backend-{{ $serviceName }}".loadBalancer.hash]
+      key = "{{ $loadBalancer.Hash.Key }}"
+      {{if $loadBalancer.Hash.LoadFactor }}
+      loadFactor = {{ printf "%f" $loadBalancer.Hash.LoadFactor }}
+      {{end}}
     {{end}}
   {{end}}
 
@@ -596,9 +789,29 @@ var _templatesEcsTmpl = []byte(`[backends]
   {{ $healthCheck := getHealthCheck $firstInstance }}
   {{if $healthCheck }}
   [backends.backend-{{ $serviceName }}.healthCheck]
+    type = "{{ $healthCheck.Type }}"
     path = "{{ $healthCheck.Path }}"
     port = {{ $healthCheck.Port }}
     interval = "{{ $healthCheck.Interval }}"
+    timeout = "{{ $healthCheck.Timeout }}"
+    scheme = "{{ $healthCheck.Scheme }}"
+    method = "{{ $healthCheck.Method }}"
+    hostname = "{{ $healthCheck.Hostname }}"
+    {{if $healthCheck.StatusCodes }}
+    statusCodes = [{{range $healthCheck.StatusCodes }}
+      "{{.}}",
+      {{end}}]
+    {{end}}
+    body = '''{{ $healthCheck.Body }}'''
+    rise = {{ $healthCheck.Rise }}
+    fall = {{ $healthCheck.Fall }}
+    grpcService = "{{ $healthCheck.GRPCService }}"
+    {{if $healthCheck.Headers }}
+    [backends.backend-{{ $serviceName }}.healthCheck.headers]
+      {{range $k, $v := $healthCheck.Headers }}
+      {{$k}} = "{{$v}}"
+      {{end}}
+    {{end}}
   {{end}}
 
   {{ $buffering := getBuffering $firstInstance }}
@@ -611,10 +824,55 @@ var _templatesEcsTmpl = []byte(`[backends]
     retryExpression = "{{ $buffering.RetryExpression }}"
   {{end}}
 
+  {{ $transport := getTransport $firstInstance }}
+  {{if $transport }}
+  [backends.backend-{{ $serviceName }}.transport]
+    dialTimeout = "{{ $transport.DialTimeout }}"
+    responseHeaderTimeout = "{{ $transport.ResponseHeaderTimeout }}"
+    idleConnTimeout = "{{ $transport.IdleConnTimeout }}"
+    maxIdleConnsPerHost = {{ $transport.MaxIdleConnsPerHost }}
+    disableKeepAlives = {{ $transport.DisableKeepAlives }}
+    disableHTTP2 = {{ $transport.DisableHTTP2 }}
+    {{if $transport.TLS }}
+    [backends.backend-{{ $serviceName }}.transport.tls]
+      ca = '''{{ $transport.TLS.CA }}'''
+      serverName = "{{ $transport.TLS.ServerName }}"
+      cert = '''{{ $transport.TLS.Cert }}'''
+      key = '''{{ $transport.TLS.Key }}'''
+      insecureSkipVerify = {{ $transport.TLS.InsecureSkipVerify }}
+    {{end}}
+  {{end}}
+
+  {{ $retry := getRetry $firstInstance }}
+  {{if $retry }}
+  [backends.backend-{{ $serviceName }}.retry]
+    attempts = {{ $retry.Attempts }}
+    {{if $retry.StatusCodes }}
+    statusCodes = [{{range $retry.StatusCodes }}
+      "{{.}}",
+      {{end}}]
+    {{end}}
+    idempotentOnly = {{ $retry.IdempotentOnly }}
+    perTryTimeout = "{{ $retry.PerTryTimeout }}"
+    backoff = "{{ $retry.Backoff }}"
+    maxBackoff = "{{ $retry.MaxBackoff }}"
+    budgetPercent = {{ $retry.BudgetPercent }}
+  {{end}}
+
+  {{ $hedging := getHedging $firstInstance }}
+  {{if $hedging }}
+  [backends.backend-{{ $serviceName }}.hedging]
+    delay = "{{ $hedging.Delay }}"
+    percentile = {{ $hedging.Percentile }}
+  {{end}}
+
   {{range $serverName, $server := getServers $instances }}
   [backends.backend-{{ $serviceName }}.servers.{{ $serverName }}]
     url = "{{ $server.URL }}"
     weight = {{ $server.Weight }}
+    {{if $server.Zone }}
+    zone = "{{ $server.Zone }}"
+    {{end}}
   {{end}}
 
 {{end}}
@@ -790,6 +1048,9 @@ var _templatesKubernetesTmpl = []byte(`[backends]
 {{range $backendName, $backend := .Backends }}
 
   [backends."{{ $backendName }}"]
+    {{if $backend.Protocol }}
+    protocol = "{{ $backend.Protocol }}"
+    {{end}}
 
     {{if $backend.CircuitBreaker }}
     [backends."{{ $backendName }} ".circuitBreaker]
@@ -802,6 +1063,20 @@ var _templatesKubernetesTmpl = []byte(`[backends]
       {{if $backend.LoadBalancer.Stickiness }}
       [backends."{{ $backendName }}".loadBalancer.stickiness]
         cookieName = "{{ $backend.LoadBalancer.Stickiness.CookieName }}"
+        secret = "{{ $backend.LoadBalancer.Stickiness.Secret }}"
+        secure = {{ $backend.LoadBalancer.Stickiness.Secure }}
+        httpOnly = {{ $backend.LoadBalancer.Stickiness.HTTPOnly }}
+        sameSite = "{{ $backend.LoadBalancer.Stickiness.SameSite }}"
+        maxAge = {{ $backend.LoadBalancer.Stickiness.MaxAge }}
+        domain = "{{ $backend.LoadBalancer.Stickiness.Domain }}"
+        path = "{{ $backend.LoadBalancer.Stickiness.Path }}"
+      {{end}}
+      {{if $backend.LoadBalancer.Hash }}
+      [backends."{{ $backendName }}".loadBalancer.hash]
+        key = "{{ $backend.LoadBalancer.Hash.Key }}"
+        {{if $backend.LoadBalancer.Hash.LoadFactor }}
+        loadFactor = {{ printf "%f" $backend.LoadBalancer.Hash.LoadFactor }}
+        {{end}}
       {{end}}
 
     {{if $backend.MaxConn }}
@@ -819,10 +1094,79 @@ var _templatesKubernetesTmpl = []byte(`[backends]
       retryExpression = "{{ $backend.Buffering.RetryExpression }}"
     {{end}}
 
+    {{if $backend.HealthCheck }}
+    [backends."{{ $backendName }}".healthCheck]
+      type = "{{ $backend.HealthCheck.Type }}"
+      path = "{{ $backend.HealthCheck.Path }}"
+      port = {{ $backend.HealthCheck.Port }}
+      interval = "{{ $backend.HealthCheck.Interval }}"
+      timeout = "{{ $backend.HealthCheck.Timeout }}"
+      scheme = "{{ $backend.HealthCheck.Scheme }}"
+      method = "{{ $backend.HealthCheck.Method }}"
+      hostname = "{{ $backend.HealthCheck.Hostname }}"
+      {{if $backend.HealthCheck.StatusCodes }}
+      statusCodes = [{{range $backend.HealthCheck.StatusCodes }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      body = '''{{ $backend.HealthCheck.Body }}'''
+      rise = {{ $backend.HealthCheck.Rise }}
+      fall = {{ $backend.HealthCheck.Fall }}
+      grpcService = "{{ $backend.HealthCheck.GRPCService }}"
+      {{if $backend.HealthCheck.Headers }}
+      [backends."{{ $backendName }}".healthCheck.headers]
+        {{range $k, $v := $backend.HealthCheck.Headers }}
+        {{$k}} = "{{$v}}"
+        {{end}}
+      {{end}}
+    {{end}}
+
+    {{if $backend.Transport }}
+    [backends."{{ $backendName }}".transport]
+      dialTimeout = "{{ $backend.Transport.DialTimeout }}"
+      responseHeaderTimeout = "{{ $backend.Transport.ResponseHeaderTimeout }}"
+      idleConnTimeout = "{{ $backend.Transport.IdleConnTimeout }}"
+      maxIdleConnsPerHost = {{ $backend.Transport.MaxIdleConnsPerHost }}
+      disableKeepAlives = {{ $backend.Transport.DisableKeepAlives }}
+      disableHTTP2 = {{ $backend.Transport.DisableHTTP2 }}
+      {{if $backend.Transport.TLS }}
+      [backends."{{ $backendName }}".transport.tls]
+        ca = '''{{ $backend.Transport.TLS.CA }}'''
+        serverName = "{{ $backend.Transport.TLS.ServerName }}"
+        cert = '''{{ $backend.Transport.TLS.Cert }}'''
+        key = '''{{ $backend.Transport.TLS.Key }}'''
+        insecureSkipVerify = {{ $backend.Transport.TLS.InsecureSkipVerify }}
+      {{end}}
+    {{end}}
+
+    {{if $backend.Retry }}
+    [backends."{{ $backendName }}".retry]
+      attempts = {{ $backend.Retry.Attempts }}
+      {{if $backend.Retry.StatusCodes }}
+      statusCodes = [{{range $backend.Retry.StatusCodes }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      idempotentOnly = {{ $backend.Retry.IdempotentOnly }}
+      perTryTimeout = "{{ $backend.Retry.PerTryTimeout }}"
+      backoff = "{{ $backend.Retry.Backoff }}"
+      maxBackoff = "{{ $backend.Retry.MaxBackoff }}"
+      budgetPercent = {{ $backend.Retry.BudgetPercent }}
+    {{end}}
+
+    {{if $backend.Hedging }}
+    [backends."{{ $backendName }}".hedging]
+      delay = "{{ $backend.Hedging.Delay }}"
+      percentile = {{ $backend.Hedging.Percentile }}
+    {{end}}
+
     {{range $serverName, $server := $backend.Servers }}
     [backends."{{ $backendName }}".servers."{{ $serverName }}"]
       url = "{{ $server.URL }}"
       weight = {{ $server.Weight }}
+      {{if $server.Zone }}
+      zone = "{{ $server.Zone }}"
+      {{end}}
     {{end}}
 
 {{end}}
@@ -848,6 +1192,26 @@ var _templatesKubernetesTmpl = []byte(`[backends]
       "{{.}}",
       {{end}}]
 
+    {{if $frontend.Split }}
+    [frontends."{{ $frontendName }}".split]
+      {{range $frontend.Split.Backends }}
+      [[frontends."{{ $frontendName }}".split.backends]]
+        backend = "{{ .Backend }}"
+        weight = {{ .Weight }}
+      {{end}}
+      {{if $frontend.Split.Stickiness }}
+      [frontends."{{ $frontendName }}".split.stickiness]
+        cookieName = "{{ $frontend.Split.Stickiness.CookieName }}"
+        secret = "{{ $frontend.Split.Stickiness.Secret }}"
+        secure = {{ $frontend.Split.Stickiness.Secure }}
+        httpOnly = {{ $frontend.Split.Stickiness.HTTPOnly }}
+        sameSite = "{{ $frontend.Split.Stickiness.SameSite }}"
+        maxAge = {{ $frontend.Split.Stickiness.MaxAge }}
+        domain = "{{ $frontend.Split.Stickiness.Domain }}"
+        path = "{{ $frontend.Split.Stickiness.Path }}"
+      {{end}}
+    {{end}}
+
     {{if $frontend.Redirect }}
     [frontends."{{ $frontendName }}".redirect]
       entryPoint = "{{ $frontend.Redirect.EntryPoint }}"
@@ -963,6 +1327,12 @@ var _templatesKvTmpl = []byte(`[backends]
 {{range $backend := List .Prefix "/backends/" }}
   {{ $backendName := Last $backend }}
 
+  {{ $backendProtocol := getBackendProtocol $backend }}
+  {{if $backendProtocol }}
+  [backends."{{ $backendName }}"]
+    protocol = "{{ $backendProtocol }}"
+  {{end}}
+
   {{ $circuitBreaker := getCircuitBreaker $backend }}
   {{if $circuitBreaker }}
   [backends."{{ $backendName }}".circuitBreaker]
@@ -977,6 +1347,20 @@ var _templatesKvTmpl = []byte(`[backends]
       {{if $loadBalancer.Stickiness }}
       [backends."{{ $backendName }}".loadBalancer.stickiness]
         cookieName = "{{ $loadBalancer.Stickiness.CookieName }}"
+        secret = "{{ $loadBalancer.Stickiness.Secret }}"
+        secure = {{ $loadBalancer.Stickiness.Secure }}
+        httpOnly = {{ $loadBalancer.Stickiness.HTTPOnly }}
+        sameSite = "{{ $loadBalancer.Stickiness.SameSite }}"
+        maxAge = {{ $loadBalancer.Stickiness.MaxAge }}
+        domain = "{{ $loadBalancer.Stickiness.Domain }}"
+        path = "{{ $loadBalancer.Stickiness.Path }}"
+      {{end}}
+      {{if $loadBalancer.Hash }}
+      [backends."{{ $backendName }}".loadBalancer.hash]
+        key = "{{ $loadBalancer.Hash.Key }}"
+        {{if $loadBalancer.Hash.LoadFactor }}
+        loadFactor = {{ printf "%f" $loadBalancer.Hash.LoadFactor }}
+        {{end}}
       {{end}}
   {{end}}
 
@@ -990,9 +1374,29 @@ var _templatesKvTmpl = []byte(`[backends]
   {{ $healthCheck := getHealthCheck $backend }}
   {{if $healthCheck }}
   [backends.{{ $backendName }}.healthCheck]
+    type = "{{ $healthCheck.Type }}"
     path = "{{ $healthCheck.Path }}"
     port = {{ $healthCheck.Port }}
     interval = "{{ $healthCheck.Interval }}"
+    timeout = "{{ $healthCheck.Timeout }}"
+    scheme = "{{ $healthCheck.Scheme }}"
+    method = "{{ $healthCheck.Method }}"
+    hostname = "{{ $healthCheck.Hostname }}"
+    {{if $healthCheck.StatusCodes }}
+    statusCodes = [{{range $healthCheck.StatusCodes }}
+      "{{.}}",
+      {{end}}]
+    {{end}}
+    body = '''{{ $healthCheck.Body }}'''
+    rise = {{ $healthCheck.Rise }}
+    fall = {{ $healthCheck.Fall }}
+    grpcService = "{{ $healthCheck.GRPCService }}"
+    {{if $healthCheck.Headers }}
+    [backends.{{ $backendName }}.healthCheck.headers]
+      {{range $k, $v := $healthCheck.Headers }}
+      {{$k}} = "{{$v}}"
+      {{end}}
+    {{end}}
   {{end}}
 
   {{ $buffering := getBuffering $backend }}
@@ -1005,6 +1409,48 @@ var _templatesKvTmpl = []byte(`[backends]
     retryExpression = "{{ $buffering.RetryExpression }}"
   {{end}}
 
+  {{ $transport := getTransport $backend }}
+  {{if $transport }}
+  [backends.{{ $backendName }}.transport]
+    dialTimeout = "{{ $transport.DialTimeout }}"
+    responseHeaderTimeout = "{{ $transport.ResponseHeaderTimeout }}"
+    idleConnTimeout = "{{ $transport.IdleConnTimeout }}"
+    maxIdleConnsPerHost = {{ $transport.MaxIdleConnsPerHost }}
+    disableKeepAlives = {{ $transport.DisableKeepAlives }}
+    disableHTTP2 = {{ $transport.DisableHTTP2 }}
+    {{if $transport.TLS }}
+    [backends.{{ $backendName }}.transport.tls]
+      ca = '''{{ $transport.TLS.CA }}'''
+      serverName = "{{ $transport.TLS.ServerName }}"
+      cert = '''{{ $transport.TLS.Cert }}'''
+      key = '''{{ $transport.TLS.Key }}'''
+      insecureSkipVerify = {{ $transport.TLS.InsecureSkipVerify }}
+    {{end}}
+  {{end}}
+
+  {{ $retry := getRetry $backend }}
+  {{if $retry }}
+  [backends.{{ $backendName }}.retry]
+    attempts = {{ $retry.Attempts }}
+    {{if $retry.StatusCodes }}
+    statusCodes = [{{range $retry.StatusCodes }}
+      "{{.}}",
+      {{end}}]
+    {{end}}
+    idempotentOnly = {{ $retry.IdempotentOnly }}
+    perTryTimeout = "{{ $retry.PerTryTimeout }}"
+    backoff = "{{ $retry.Backoff }}"
+    maxBackoff = "{{ $retry.MaxBackoff }}"
+    budgetPercent = {{ $retry.BudgetPercent }}
+  {{end}}
+
+  {{ $hedging := getHedging $backend }}
+  {{if $hedging }}
+  [backends.{{ $backendName }}.hedging]
+    delay = "{{ $hedging.Delay }}"
+    percentile = {{ $hedging.Percentile }}
+  {{end}}
+
   {{range $serverName, $server := getServers $backend}}
   [backends."{{ $backendName }}".servers."{{ $serverName }}"]
     url = "{{ $server.URL }}"
@@ -1060,6 +1506,27 @@ var _templatesKvTmpl = []byte(`[backends]
       {{end}}
     {{end}}
 
+    {{ $split := getSplit $frontend }}
+    {{if $split }}
+    [frontends."{{ $frontendName }}".split]
+      {{range $split.Backends }}
+      [[frontends."{{ $frontendName }}".split.backends]]
+        backend = "{{ .Backend }}"
+        weight = {{ .Weight }}
+      {{end}}
+      {{if $split.Stickiness }}
+      [frontends."{{ $frontendName }}".split.stickiness]
+        cookieName = "{{ $split.Stickiness.CookieName }}"
+        secret = "{{ $split.Stickiness.Secret }}"
+        secure = {{ $split.Stickiness.Secure }}
+        httpOnly = {{ $split.Stickiness.HTTPOnly }}
+        sameSite = "{{ $split.Stickiness.SameSite }}"
+        maxAge = {{ $split.Stickiness.MaxAge }}
+        domain = "{{ $split.Stickiness.Domain }}"
+        path = "{{ $split.Stickiness.Path }}"
+      {{end}}
+    {{end}}
+
     {{ $rateLimit := getRateLimit $frontend }}
     {{if $rateLimit }}
     [frontends."{{ $frontendName }}".rateLimit]
@@ -1185,6 +1652,20 @@ var _templatesMarathonTmpl = []byte(`{{ $apps := .Applications }}
       {{if $loadBalancer.Stickiness }}
       [backends."{{ $backendName }}".loadBalancer.stickiness]
         cookieName = "{{ $loadBalancer.Stickiness.CookieName }}"
+        secret = "{{ $loadBalancer.Stickiness.Secret }}"
+        secure = {{ $loadBalancer.Stickiness.Secure }}
+        httpOnly = {{ $loadBalancer.Stickiness.HTTPOnly }}
+        sameSite = "{{ $loadBalancer.Stickiness.SameSite }}"
+        maxAge = {{ $loadBalancer.Stickiness.MaxAge }}
+        domain = "{{ $loadBalancer.Stickiness.Domain }}"
+        path = "{{ $loadBalancer.Stickiness.Path }}"
+      {{end}}
+      {{if $loadBalancer.Hash }}
+      [backends."{{ $backendName }}".loadBalancer.hash]
+        key = "{{ $loadBalancer.Hash.Key }}"
+        {{if $loadBalancer.Hash.LoadFactor }}
+        loadFactor = {{ printf "%f" $loadBalancer.Hash.LoadFactor }}
+        {{end}}
       {{end}}
     {{end}}
 
@@ -1198,9 +1679,29 @@ var _templatesMarathonTmpl = []byte(`{{ $apps := .Applications }}
     {{ $healthCheck := getHealthCheck $app }}
     {{if $healthCheck }}
     [backends."{{ $backendName }}".healthCheck]
-      path = "{{ $healthCheck.Path }}"
+      type = "{{ $healthCheck.Type }}"
+    path = "{{ $healthCheck.Path }}"
       port = {{ $healthCheck.Port }}
       interval = "{{ $healthCheck.Interval }}"
+      timeout = "{{ $healthCheck.Timeout }}"
+      scheme = "{{ $healthCheck.Scheme }}"
+      method = "{{ $healthCheck.Method }}"
+      hostname = "{{ $healthCheck.Hostname }}"
+      {{if $healthCheck.StatusCodes }}
+      statusCodes = [{{range $healthCheck.StatusCodes }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      body = '''{{ $healthCheck.Body }}'''
+      rise = {{ $healthCheck.Rise }}
+      fall = {{ $healthCheck.Fall }}
+    grpcService = "{{ $healthCheck.GRPCService }}"
+      {{if $healthCheck.Headers }}
+      [backends."{{ $backendName }}".healthCheck.headers]
+        {{range $k, $v := $healthCheck.Headers }}
+        {{$k}} = "{{$v}}"
+        {{end}}
+      {{end}}
     {{end}}
 
     {{ $buffering := getBuffering $app }}
@@ -1213,6 +1714,48 @@ var _templatesMarathonTmpl = []byte(`{{ $apps := .Applications }}
       retryExpression = "{{ $buffering.RetryExpression }}"
     {{end}}
 
+    {{ $transport := getTransport $app }}
+    {{if $transport }}
+    [backends."{{ $backendName }}".transport]
+      dialTimeout = "{{ $transport.DialTimeout }}"
+      responseHeaderTimeout = "{{ $transport.ResponseHeaderTimeout }}"
+      idleConnTimeout = "{{ $transport.IdleConnTimeout }}"
+      maxIdleConnsPerHost = {{ $transport.MaxIdleConnsPerHost }}
+      disableKeepAlives = {{ $transport.DisableKeepAlives }}
+      disableHTTP2 = {{ $transport.DisableHTTP2 }}
+      {{if $transport.TLS }}
+      [backends."{{ $backendName }}".transport.tls]
+        ca = '''{{ $transport.TLS.CA }}'''
+        serverName = "{{ $transport.TLS.ServerName }}"
+        cert = '''{{ $transport.TLS.Cert }}'''
+        key = '''{{ $transport.TLS.Key }}'''
+        insecureSkipVerify = {{ $transport.TLS.InsecureSkipVerify }}
+      {{end}}
+    {{end}}
+
+    {{ $retry := getRetry $app }}
+    {{if $retry }}
+    [backends."{{ $backendName }}".retry]
+      attempts = {{ $retry.Attempts }}
+      {{if $retry.StatusCodes }}
+      statusCodes = [{{range $retry.StatusCodes }}
+        "{{.}}",
+        {{end}}]
+      {{end}}
+      idempotentOnly = {{ $retry.IdempotentOnly }}
+      perTryTimeout = "{{ $retry.PerTryTimeout }}"
+      backoff = "{{ $retry.Backoff }}"
+      maxBackoff = "{{ $retry.MaxBackoff }}"
+      budgetPercent = {{ $retry.BudgetPercent }}
+    {{end}}
+
+    {{ $hedging := getHedging $app }}
+    {{if $hedging }}
+    [backends."{{ $backendName }}".hedging]
+      delay = "{{ $hedging.Delay }}"
+      percentile = {{ $hedging.Percentile }}
+    {{end}}
+
     {{range $serverName, $server := getServers $app $serviceName }}
     [backends."{{ $backendName }}".servers."{{ $serverName }}"]
       url = "{{ $server.URL }}"
@@ -1379,6 +1922,20 @@ var _templatesMesosTmpl = []byte(`[backends]
       {{if $loadBalancer.Stickiness }}
       [backends."backend-{{ $backendName }}".loadBalancer.stickiness]
         cookieName = "{{ $loadBalancer.Stickiness.CookieName }}"
+        secret = "{{ $loadBalancer.Stickiness.Secret }}"
+        secure = {{ $loadBalancer.Stickiness.Secure }}
+        httpOnly = {{ $loadBalancer.Stickiness.HTTPOnly }}
+        sameSite = "{{ $loadBalancer.Stickiness.SameSite }}"
+        maxAge = {{ $loadBalancer.Stickiness.MaxAge }}
+        domain = "{{ $loadBalancer.Stickiness.Domain }}"
+        path = "{{ $loadBalancer.Stickiness.Path }}"
+      {{end}}
+      {{if $loadBalancer.Hash }}
+      [backends."backend-{{ $backendName }}".loadBalancer.hash]
+        key = "{{ $loadBalancer.Hash.Key }}"
+        {{if $loadBalancer.Hash.LoadFactor }}
+        loadFactor = {{ printf "%f" $loadBalancer.Hash.LoadFactor }}
+        {{end}}
       {{end}}
   {{end}}
 
@@ -1392,9 +1949,29 @@ var _templatesMesosTmpl = []byte(`[backends]
   {{ $healthCheck := getHealthCheck $app }}
   {{if $healthCheck }}
   [backends.backend-{{ $backendName }}.healthCheck]
+    type = "{{ $healthCheck.Type }}"
     path = "{{ $healthCheck.Path }}"
     port = {{ $healthCheck.Port }}
     interval = "{{ $healthCheck.Interval }}"
+    timeout = "{{ $healthCheck.Timeout }}"
+    scheme = "{{ $healthCheck.Scheme }}"
+    method = "{{ $healthCheck.Method }}"
+    hostname = "{{ $healthCheck.Hostname }}"
+    {{if $healthCheck.StatusCodes }}
+    statusCodes = [{{range $healthCheck.StatusCodes }}
+      "{{.}}",
+      {{end}}]
+    {{end}}
+    body = '''{{ $healthCheck.Body }}'''
+    rise = {{ $healthCheck.Rise }}
+    fall = {{ $healthCheck.Fall }}
+    grpcService = "{{ $healthCheck.GRPCService }}"
+    {{if $healthCheck.Headers }}
+    [backends.backend-{{ $backendName }}.healthCheck.headers]
+      {{range $k, $v := $healthCheck.Headers }}
+      {{$k}} = "{{$v}}"
+      {{end}}
+    {{end}}
   {{end}}
 
   {{ $buffering := getBuffering $app }}
@@ -1407,6 +1984,48 @@ var _templatesMesosTmpl = []byte(`[backends]
     retryExpression = "{{ $buffering.RetryExpression }}"
   {{end}}
 
+  {{ $transport := getTransport $app }}
+  {{if $transport }}
+  [backends.backend-{{ $backendName }}.transport]
+    dialTimeout = "{{ $transport.DialTimeout }}"
+    responseHeaderTimeout = "{{ $transport.ResponseHeaderTimeout }}"
+    idleConnTimeout = "{{ $transport.IdleConnTimeout }}"
+    maxIdleConnsPerHost = {{ $transport.MaxIdleConnsPerHost }}
+    disableKeepAlives = {{ $transport.DisableKeepAlives }}
+    disableHTTP2 = {{ $transport.DisableHTTP2 }}
+    {{if $transport.TLS }}
+    [backends.backend-{{ $backendName }}.transport.tls]
+      ca = '''{{ $transport.TLS.CA }}'''
+      serverName = "{{ $transport.TLS.ServerName }}"
+      cert = '''{{ $transport.TLS.Cert }}'''
+      key = '''{{ $transport.TLS.Key }}'''
+      insecureSkipVerify = {{ $transport.TLS.InsecureSkipVerify }}
+    {{end}}
+  {{end}}
+
+  {{ $retry := getRetry $app }}
+  {{if $retry }}
+  [backends.backend-{{ $backendName }}.retry]
+    attempts = {{ $retry.Attempts }}
+    {{if $retry.StatusCodes }}
+    statusCodes = [{{range $retry.StatusCodes }}
+      "{{.}}",
+      {{end}}]
+    {{end}}
+    idempotentOnly = {{ $retry.IdempotentOnly }}
+    perTryTimeout = "{{ $retry.PerTryTimeout }}"
+    backoff = "{{ $retry.Backoff }}"
+    maxBackoff = "{{ $retry.MaxBackoff }}"
+    budgetPercent = {{ $retry.BudgetPercent }}
+  {{end}}
+
+  {{ $hedging := getHedging $app }}
+  {{if $hedging }}
+  [backends.backend-{{ $backendName }}.hedging]
+    delay = "{{ $hedging.Delay }}"
+    percentile = {{ $hedging.Percentile }}
+  {{end}}
+
   {{range $serverName, $server := getServers $tasks }}
   [backends.backend-{{ $backendName }}.servers.{{ $serverName }}]
     url = "{{ $server.URL }}"
@@ -1593,6 +2212,20 @@ var _templatesRancherTmpl = []byte(`{{ $backendServers := .Backends }}
       {{if $loadBalancer.Stickiness }}
       [backends."backend-{{ $backendName }}".loadBalancer.stickiness]
         cookieName = "{{ $loadBalancer.Stickiness.CookieName }}"
+        secret = "{{ $loadBalancer.Stickiness.Secret }}"
+        secure = {{ $loadBalancer.Stickiness.Secure }}
+        httpOnly = {{ $loadBalancer.Stickiness.HTTPOnly }}
+        sameSite = "{{ $loadBalancer.Stickiness.SameSite }}"
+        maxAge = {{ $loadBalancer.Stickiness.MaxAge }}
+        domain = "{{ $loadBalancer.Stickiness.Domain }}"
+        path = "{{ $loadBalancer.Stickiness.Path }}"
+      {{end}}
+      {{if $loadBalancer.Hash }}
+      [backends."backend-{{ $backendName }}".loadBalancer.hash]
+        key = "{{ $loadBalancer.Hash.Key }}"
+        {{if $loadBalancer.Hash.LoadFactor }}
+        loadFactor = {{ printf "%f" $loadBalancer.Hash.LoadFactor }}
+        {{end}}
       {{end}}
   {{end}}
 
@@ -1606,9 +2239,29 @@ var _templatesRancherTmpl = []byte(`{{ $backendServers := .Backends }}
   {{ $healthCheck := getHealthCheck $backend }}
   {{if $healthCheck }}
   [backends.backend-{{ $backendName }}.healthCheck]
+    type = "{{ $healthCheck.Type }}"
     path = "{{ $healthCheck.Path }}"
     port = {{ $healthCheck.Port }}
     interval = "{{ $healthCheck.Interval }}"
+    timeout = "{{ $healthCheck.Timeout }}"
+    scheme = "{{ $healthCheck.Scheme }}"
+    method = "{{ $healthCheck.Method }}"
+    hostname = "{{ $healthCheck.Hostname }}"
+    {{if $healthCheck.StatusCodes }}
+    statusCodes = [{{range $healthCheck.StatusCodes }}
+      "{{.}}",
+      {{end}}]
+    {{end}}
+    body = '''{{ $healthCheck.Body }}'''
+    rise = {{ $healthCheck.Rise }}
+    fall = {{ $healthCheck.Fall }}
+    grpcService = "{{ $healthCheck.GRPCService }}"
+    {{if $healthCheck.Headers }}
+    [backends.backend-{{ $backendName }}.healthCheck.headers]
+      {{range $k, $v := $healthCheck.Headers }}
+      {{$k}} = "{{$v}}"
+      {{end}}
+    {{end}}
   {{end}}
 
   {{ $buffering := getBuffering $backend }}
@@ -1621,6 +2274,48 @@ var _templatesRancherTmpl = []byte(`{{ $backendServers := .Backends }}
     retryExpression = "{{ $buffering.RetryExpression }}"
   {{end}}
 
+  {{ $transport := getTransport $backend }}
+  {{if $transport }}
+  [backends."backend-{{ $backendName }}".transport]
+    dialTimeout = "{{ $transport.DialTimeout }}"
+    responseHeaderTimeout = "{{ $transport.ResponseHeaderTimeout }}"
+    idleConnTimeout = "{{ $transport.IdleConnTimeout }}"
+    maxIdleConnsPerHost = {{ $transport.MaxIdleConnsPerHost }}
+    disableKeepAlives = {{ $transport.DisableKeepAlives }}
+    disableHTTP2 = {{ $transport.DisableHTTP2 }}
+    {{if $transport.TLS }}
+    [backends."backend-{{ $backendName }}".transport.tls]
+      ca = '''{{ $transport.TLS.CA }}'''
+      serverName = "{{ $transport.TLS.ServerName }}"
+      cert = '''{{ $transport.TLS.Cert }}'''
+      key = '''{{ $transport.TLS.Key }}'''
+      insecureSkipVerify = {{ $transport.TLS.InsecureSkipVerify }}
+    {{end}}
+  {{end}}
+
+  {{ $retry := getRetry $backend }}
+  {{if $retry }}
+  [backends."backend-{{ $backendName }}".retry]
+    attempts = {{ $retry.Attempts }}
+    {{if $retry.StatusCodes }}
+    statusCodes = [{{range $retry.StatusCodes }}
+      "{{.}}",
+      {{end}}]
+    {{end}}
+    idempotentOnly = {{ $retry.IdempotentOnly }}
+    perTryTimeout = "{{ $retry.PerTryTimeout }}"
+    backoff = "{{ $retry.Backoff }}"
+    maxBackoff = "{{ $retry.MaxBackoff }}"
+    budgetPercent = {{ $retry.BudgetPercent }}
+  {{end}}
+
+  {{ $hedging := getHedging $backend }}
+  {{if $hedging }}
+  [backends."backend-{{ $backendName }}".hedging]
+    delay = "{{ $hedging.Delay }}"
+    percentile = {{ $hedging.Percentile }}
+  {{end}}
+
   {{range $serverName, $server := getServers $backend}}
   [backends.backend-{{ $backendName }}.servers.{{ $serverName }}]
     url = "{{ $server.URL }}"
@@ -44,6 +44,9 @@ const (
 	// DefaultIdleTimeout before closing an idle connection.
 	DefaultIdleTimeout = 180 * time.Second
 
+	// DefaultUDPIdleTimeout before closing an idle UDP session.
+	DefaultUDPIdleTimeout = 30 * time.Second
+
 	// DefaultGraceTimeout controls how long Traefik serves pending requests
 	// prior to shutting down.
 	DefaultGraceTimeout = 10 * time.Second
@@ -75,6 +78,7 @@ type GlobalConfiguration struct {
 	RootCAs                   tls.RootCAs             `description:"Add cert file for self-signed certificate"`
 	Retry                     *Retry                  `description:"Enable retry sending request if network error" export:"true"`
 	HealthCheck               *HealthCheckConfig      `description:"Health check parameters" export:"true"`
+	Zone                      string                  `description:"Zone of the Traefik instance: the servers of the backends in this zone are preferred" export:"true"`
 	RespondingTimeouts        *RespondingTimeouts     `description:"Timeouts for incoming requests to the Traefik instance" export:"true"`
 	ForwardingTimeouts        *ForwardingTimeouts     `description:"Timeouts for requests forwarded to the backend servers" export:"true"`
 	Web                       *WebCompatibility       `description:"(Deprecated) Enable Web backend with default settings" export:"true"` // Deprecated
@@ -390,11 +394,21 @@ func (ep *EntryPoints) Set(value string) error {
 		forwardedHeaders.TrustedIPs = strings.Split(fhTrustedIPs, ",")
 	}
 
+	var udp *UDP
+	if len(result["udp_idletimeout"]) > 0 {
+		idleTimeout, err := time.ParseDuration(result["udp_idletimeout"])
+		if err != nil {
+			return fmt.Errorf("invalid UDP idle timeout %q: %v", result["udp_idletimeout"], err)
+		}
+		udp = &UDP{IdleTimeout: flaeg.Duration(idleTimeout)}
+	}
+
 	if proxyProtocol != nil && proxyProtocol.Insecure {
 		log.Warn("ProxyProtocol.Insecure:true is dangerous. Please use 'ProxyProtocol.TrustedIPs:IPs' and remove 'ProxyProtocol.Insecure:true'")
 	}
 
 	(*ep)[result["name"]] = &EntryPoint{
+		Network:              result["network"],
 		Address:              result["address"],
 		TLS:                  configTLS,
 		Redirect:             redirect,
@@ -402,6 +416,7 @@ func (ep *EntryPoints) Set(value string) error {
 		WhitelistSourceRange: whiteListSourceRange,
 		ProxyProtocol:        proxyProtocol,
 		ForwardedHeaders:     forwardedHeaders,
+		UDP:                  udp,
 	}
 
 	return nil
@@ -462,6 +477,31 @@ type EntryPoint struct {
 	Compress             bool              `export:"true"`
 	ProxyProtocol        *ProxyProtocol    `export:"true"`
 	ForwardedHeaders     *ForwardedHeaders `export:"true"`
+	UDP                  *UDP              `export:"true"`
+}
+
+// Networks of entry points not serving HTTP.
+const (
+	NetworkTCP = "tcp"
+	NetworkUDP = "udp"
+)
+
+// IsTCP tells whether the entry point routes TCP connections instead of HTTP requests.
+func (ep *EntryPoint) IsTCP() bool {
+	return strings.EqualFold(ep.Network, NetworkTCP)
+}
+
+// IsUDP tells whether the entry point forwards UDP datagrams instead of HTTP requests.
+func (ep *EntryPoint) IsUDP() bool {
+	return strings.EqualFold(ep.Network, NetworkUDP)
+}
+
+// GetUDPIdleTimeout returns the duration after which idle UDP sessions of the entry point are closed.
+func (ep *EntryPoint) GetUDPIdleTimeout() time.Duration {
+	if ep.UDP == nil || ep.UDP.IdleTimeout <= 0 {
+		return DefaultUDPIdleTimeout
+	}
+	return time.Duration(ep.UDP.IdleTimeout)
 }
 
 // Retry contains request retry config
@@ -493,6 +533,11 @@ type ProxyProtocol struct {
 	TrustedIPs []string
 }
 
+// UDP contains the configuration of UDP entry points
+type UDP struct {
+	IdleTimeout flaeg.Duration `description:"Duration after which an idle UDP session is closed. Defaults to 30 seconds" export:"true"`
+}
+
 // ForwardedHeaders Trust client forwarding headers
 type ForwardedHeaders struct {
 	Insecure   bool
@@ -289,6 +289,29 @@ func TestEntryPoints_Set(t *testing.T) {
 				ForwardedHeaders:     &ForwardedHeaders{Insecure: true},
 			},
 		},
+		{
+			name:                   "tcp network",
+			expression:             "Name:foo Network:tcp Address::5432",
+			expectedEntryPointName: "foo",
+			expectedEntryPoint: &EntryPoint{
+				Network:              "tcp",
+				Address:              ":5432",
+				WhitelistSourceRange: []string{},
+				ForwardedHeaders:     &ForwardedHeaders{Insecure: true},
+			},
+		},
+		{
+			name:                   "udp network",
+			expression:             "Name:foo Network:udp Address::53 UDP.IdleTimeout:10s",
+			expectedEntryPointName: "foo",
+			expectedEntryPoint: &EntryPoint{
+				Network:              "udp",
+				Address:              ":53",
+				WhitelistSourceRange: []string{},
+				ForwardedHeaders:     &ForwardedHeaders{Insecure: true},
+				UDP:                  &UDP{IdleTimeout: flaeg.Duration(10 * time.Second)},
+			},
+		},
 	}
 
 	for _, test := range testCases {
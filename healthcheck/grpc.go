@@ -0,0 +1,91 @@
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+)
+
+// grpcHealthCheckMethod is the method of the gRPC health checking protocol,
+// see https://github.com/grpc/grpc/blob/master/doc/health-checking.md
+const grpcHealthCheckMethod = "/grpc.health.v1.Health/Check"
+
+// Serving statuses of the gRPC health checking protocol.
+const (
+	grpcStatusUnknown        int32 = 0
+	grpcStatusServing        int32 = 1
+	grpcStatusNotServing     int32 = 2
+	grpcStatusServiceUnknown int32 = 3
+)
+
+var grpcStatusNames = map[int32]string{
+	grpcStatusUnknown:        "UNKNOWN",
+	grpcStatusServing:        "SERVING",
+	grpcStatusNotServing:     "NOT_SERVING",
+	grpcStatusServiceUnknown: "SERVICE_UNKNOWN",
+}
+
+// grpcHealthCheckRequest is the grpc.health.v1.HealthCheckRequest message.
+type grpcHealthCheckRequest struct {
+	Service string `protobuf:"bytes,1,opt,name=service" json:"service,omitempty"`
+}
+
+func (m *grpcHealthCheckRequest) Reset()         { *m = grpcHealthCheckRequest{} }
+func (m *grpcHealthCheckRequest) String() string { return proto.CompactTextString(m) }
+func (*grpcHealthCheckRequest) ProtoMessage()    {}
+
+// grpcHealthCheckResponse is the grpc.health.v1.HealthCheckResponse message.
+type grpcHealthCheckResponse struct {
+	Status int32 `protobuf:"varint,1,opt,name=status" json:"status,omitempty"`
+}
+
+func (m *grpcHealthCheckResponse) Reset()         { *m = grpcHealthCheckResponse{} }
+func (m *grpcHealthCheckResponse) String() string { return proto.CompactTextString(m) }
+func (*grpcHealthCheckResponse) ProtoMessage()    {}
+
+// checkGRPCHealth checks that the server reports the service of the health check as serving,
+// with the gRPC health checking protocol.
+func checkGRPCHealth(serverURL *url.URL, backend *BackendHealthCheck) error {
+	ctx, cancel := context.WithTimeout(context.Background(), backend.requestTimeout)
+	defer cancel()
+
+	var dialOptions []grpc.DialOption
+	if backend.useTLS(serverURL) {
+		dialOptions = append(dialOptions, grpc.WithTransportCredentials(credentials.NewTLS(backend.tlsConfig())))
+	} else {
+		dialOptions = append(dialOptions, grpc.WithInsecure())
+	}
+	if len(backend.Hostname) > 0 {
+		dialOptions = append(dialOptions, grpc.WithAuthority(backend.Hostname))
+	}
+
+	conn, err := grpc.DialContext(ctx, backend.address(serverURL), dialOptions...)
+	if err != nil {
+		return fmt.Errorf("gRPC connection failed: %s", err)
+	}
+	defer conn.Close()
+
+	resp := &grpcHealthCheckResponse{}
+	err = grpc.Invoke(ctx, grpcHealthCheckMethod, &grpcHealthCheckRequest{Service: backend.GRPCService}, resp, conn)
+	switch {
+	case grpc.Code(err) == codes.Unimplemented:
+		return fmt.Errorf("gRPC health checking protocol not implemented: %s", err)
+	case err != nil:
+		return fmt.Errorf("gRPC request failed: %s", err)
+	case resp.Status != grpcStatusServing:
+		return fmt.Errorf("received gRPC serving status %s", grpcStatusName(resp.Status))
+	}
+	return nil
+}
+
+func grpcStatusName(status int32) string {
+	if name, ok := grpcStatusNames[status]; ok {
+		return name
+	}
+	return fmt.Sprint(status)
+}
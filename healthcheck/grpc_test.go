@@ -0,0 +1,107 @@
+package healthcheck
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// serveGRPCHealth starts a gRPC server answering the health checks with the serving status of each service.
+func serveGRPCHealth(t *testing.T, statuses map[string]int32) (*url.URL, func()) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := grpc.NewServer()
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "grpc.health.v1.Health",
+		HandlerType: (*interface{})(nil),
+		Methods: []grpc.MethodDesc{{
+			MethodName: "Check",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				req := &grpcHealthCheckRequest{}
+				if err := dec(req); err != nil {
+					return nil, err
+				}
+				status, ok := statuses[req.Service]
+				if !ok {
+					status = grpcStatusServiceUnknown
+				}
+				return &grpcHealthCheckResponse{Status: status}, nil
+			},
+		}},
+	}, struct{}{})
+	go server.Serve(listener)
+
+	return &url.URL{Scheme: "http", Host: listener.Addr().String()}, server.Stop
+}
+
+func TestCheckGRPCHealth(t *testing.T) {
+	serverURL, stop := serveGRPCHealth(t, map[string]int32{
+		"":         grpcStatusServing,
+		"serving":  grpcStatusServing,
+		"draining": grpcStatusNotServing,
+	})
+	defer stop()
+
+	withoutHealthServer := grpc.NewServer()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go withoutHealthServer.Serve(listener)
+	defer withoutHealthServer.Stop()
+
+	tests := []struct {
+		desc        string
+		serverURL   *url.URL
+		service     string
+		wantHealthy bool
+	}{
+		{
+			desc:        "server serving",
+			serverURL:   serverURL,
+			wantHealthy: true,
+		},
+		{
+			desc:        "service serving",
+			serverURL:   serverURL,
+			service:     "serving",
+			wantHealthy: true,
+		},
+		{
+			desc:        "service not serving",
+			serverURL:   serverURL,
+			service:     "draining",
+			wantHealthy: false,
+		},
+		{
+			desc:        "unknown service",
+			serverURL:   serverURL,
+			service:     "unknown",
+			wantHealthy: false,
+		},
+		{
+			desc:        "health checking protocol not implemented",
+			serverURL:   &url.URL{Scheme: "http", Host: listener.Addr().String()},
+			wantHealthy: false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			backend := NewBackendHealthCheck(Options{Type: TypeGRPC, GRPCService: test.service, Timeout: time.Second}, "backendName")
+
+			err := checkHealth(test.serverURL, backend)
+			if healthy := err == nil; healthy != test.wantHealthy {
+				t.Errorf("got healthy %t (%v), wanted %t", healthy, err, test.wantHealthy)
+			}
+		})
+	}
+}
@@ -2,10 +2,15 @@ package healthcheck
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"net/url"
+	"regexp"
+	"sort"
 	"strconv"
 	"sync"
 	"time"
@@ -27,25 +32,101 @@ func GetHealthCheck(metrics metricsRegistry) *HealthCheck {
 	return singleton
 }
 
+// maxBodyBytes is the size of the largest response body matched against the body pattern of a health check.
+const maxBodyBytes = 1 << 20
+
+// Types of health check.
+const (
+	TypeHTTP = "http"
+	TypeTCP  = "tcp"
+	TypeGRPC = "grpc"
+)
+
 // Options are the public health check options.
 type Options struct {
+	// Type is the protocol of the health checks, http by default
+	Type      string
 	Path      string
 	Port      int
 	Transport http.RoundTripper
 	Interval  time.Duration
 	LB        LoadBalancer
+	// Timeout limits the duration of each health check request, 5s by default
+	Timeout time.Duration
+	// Scheme overrides the scheme of the server URL
+	Scheme string
+	// Method is the method of the health check requests, GET by default
+	Method string
+	// Hostname is the Host header of the health check requests
+	Hostname string
+	Headers  map[string]string
+	// StatusCodeRanges are the status codes of a healthy server, 200 only by default
+	StatusCodeRanges [][2]int
+	// Body, if set, must match the response body of a healthy server
+	Body *regexp.Regexp
+	// Rise is the number of successful checks in a row returning a server to the load-balancer,
+	// and Fall the number of failed checks in a row removing it, 1 by default
+	Rise int
+	Fall int
+	// GRPCService is the service whose health is checked by the grpc health checks, the whole server by default
+	GRPCService string
 }
 
 func (opt Options) String() string {
-	return fmt.Sprintf("[Path: %s Port: %d Interval: %s]", opt.Path, opt.Port, opt.Interval)
+	return fmt.Sprintf("[Type: %s Path: %s Port: %d Interval: %s Timeout: %s Method: %s Rise: %d Fall: %d]",
+		opt.Type, opt.Path, opt.Port, opt.Interval, opt.Timeout, opt.Method, opt.Rise, opt.Fall)
+}
+
+// maxTransitions is the number of the latest status transitions kept for each server.
+const maxTransitions = 10
+
+// Statuses of a server.
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// ServerStatus is the health status of a server of a backend, as given by the API.
+type ServerStatus struct {
+	URL    string `json:"url"`
+	Status string `json:"status"`
+	// LastCheck is the time of the last check, and LastCheckSucceeded its result
+	LastCheck          time.Time `json:"lastCheck,omitempty"`
+	LastCheckSucceeded bool      `json:"lastCheckSucceeded"`
+	// Error is the reason of the last failed check, if the last check failed
+	Error       string       `json:"error,omitempty"`
+	Transitions []Transition `json:"transitions,omitempty"`
+}
+
+func (status *ServerStatus) copy() *ServerStatus {
+	statusCopy := *status
+	statusCopy.Transitions = append([]Transition(nil), status.Transitions...)
+	return &statusCopy
+}
+
+// Transition is a change of the status of a server, removed from or returned to the load-balancer by the health check.
+type Transition struct {
+	Status string    `json:"status"`
+	Time   time.Time `json:"time"`
+	Error  string    `json:"error,omitempty"`
 }
 
 // BackendHealthCheck HealthCheck configuration for a backend
 type BackendHealthCheck struct {
 	Options
-	name           string
-	disabledURLs   []*url.URL
-	requestTimeout time.Duration
+	name string
+	// load-balancers of the backend, one per entry point, the first one being Options.LB
+	loadBalancers []LoadBalancer
+	// lock guards the state of the servers below, kept across configuration reloads
+	lock         sync.Mutex
+	disabledURLs []*url.URL
+	// weights of the disabled servers, by URL
+	disabledWeights map[string]int
+	requestTimeout  time.Duration
+	// consecutive successful checks of the disabled servers, and failed checks of the enabled ones, by URL
+	successes map[string]int
+	failures  map[string]int
+	statuses  map[string]*ServerStatus
 }
 
 //HealthCheck struct
@@ -53,6 +134,7 @@ type HealthCheck struct {
 	Backends map[string]*BackendHealthCheck
 	metrics  metricsRegistry
 	cancel   context.CancelFunc
+	lock     sync.RWMutex
 }
 
 // LoadBalancer includes functionality for load-balancing management.
@@ -62,6 +144,12 @@ type LoadBalancer interface {
 	Servers() []*url.URL
 }
 
+// weightedLoadBalancer is a load-balancer giving the weights of its servers,
+// so that a server returning from a health check failure gets its weight back.
+type weightedLoadBalancer interface {
+	ServerWeight(u *url.URL) (int, bool)
+}
+
 func newHealthCheck(metrics metricsRegistry) *HealthCheck {
 	return &HealthCheck{
 		Backends: make(map[string]*BackendHealthCheck),
@@ -77,19 +165,47 @@ type metricsRegistry interface {
 
 // NewBackendHealthCheck Instantiate a new BackendHealthCheck
 func NewBackendHealthCheck(options Options, backendName string) *BackendHealthCheck {
+	requestTimeout := 5 * time.Second
+	if options.Timeout > 0 {
+		requestTimeout = options.Timeout
+	}
 	return &BackendHealthCheck{
-		Options:        options,
-		name:           backendName,
-		requestTimeout: 5 * time.Second,
+		Options:         options,
+		name:            backendName,
+		loadBalancers:   []LoadBalancer{options.LB},
+		disabledWeights: make(map[string]int),
+		requestTimeout:  requestTimeout,
+		successes:       make(map[string]int),
+		failures:        make(map[string]int),
+		statuses:        make(map[string]*ServerStatus),
 	}
 }
 
+// AddLoadBalancer adds a load-balancer of the backend, e.g. the one of another entry point,
+// to which the results of the health check are applied as well.
+func (backend *BackendHealthCheck) AddLoadBalancer(lb LoadBalancer) {
+	backend.lock.Lock()
+	defer backend.lock.Unlock()
+	backend.loadBalancers = append(backend.loadBalancers, lb)
+}
+
 //SetBackendsConfiguration set backends configuration
+// The health state of the servers of a backend is kept from the previous configuration,
+// so that the servers known to be down are removed from the new load-balancers right away.
 func (hc *HealthCheck) SetBackendsConfiguration(parentCtx context.Context, backends map[string]*BackendHealthCheck) {
-	hc.Backends = backends
 	if hc.cancel != nil {
 		hc.cancel()
 	}
+
+	hc.lock.Lock()
+	for name, backend := range backends {
+		if previous, ok := hc.Backends[name]; ok {
+			backend.restoreState(previous)
+		}
+	}
+	hc.Backends = backends
+	hc.lock.Unlock()
+
 	ctx, cancel := context.WithCancel(parentCtx)
 	hc.cancel = cancel
 
@@ -119,29 +235,24 @@ func (hc *HealthCheck) execute(ctx context.Context, backend *BackendHealthCheck)
 }
 
 func (hc *HealthCheck) checkBackend(backend *BackendHealthCheck) {
+	backend.lock.Lock()
 	enabledURLs := backend.LB.Servers()
-	var newDisabledURLs []*url.URL
-	for _, url := range backend.disabledURLs {
+	disabledURLs := make([]*url.URL, len(backend.disabledURLs))
+	copy(disabledURLs, backend.disabledURLs)
+	backend.lock.Unlock()
+
+	for _, url := range disabledURLs {
 		serverUpMetricValue := float64(0)
-		if err := checkHealth(url, backend); err == nil {
-			log.Warnf("Health check up: Returning to server list. Backend: %q URL: %q", backend.name, url.String())
-			backend.LB.UpsertServer(url, roundrobin.Weight(1))
+		if backend.updateDisabledServer(url, checkHealth(url, backend)) {
 			serverUpMetricValue = 1
-		} else {
-			log.Warnf("Health check still failing. Backend: %q URL: %q Reason: %s", backend.name, url.String(), err)
-			newDisabledURLs = append(newDisabledURLs, url)
 		}
 		labelValues := []string{"backend", backend.name, "url", url.String()}
 		hc.metrics.BackendServerUpGauge().With(labelValues...).Set(serverUpMetricValue)
 	}
-	backend.disabledURLs = newDisabledURLs
 
 	for _, url := range enabledURLs {
 		serverUpMetricValue := float64(1)
-		if err := checkHealth(url, backend); err != nil {
-			log.Warnf("Health check failed: Remove from server list. Backend: %q URL: %q Reason: %s", backend.name, url.String(), err)
-			backend.LB.RemoveServer(url)
-			backend.disabledURLs = append(backend.disabledURLs, url)
+		if !backend.updateEnabledServer(url, checkHealth(url, backend)) {
 			serverUpMetricValue = 0
 		}
 		labelValues := []string{"backend", backend.name, "url", url.String()}
@@ -149,23 +260,271 @@ func (hc *HealthCheck) checkBackend(backend *BackendHealthCheck) {
 	}
 }
 
+// updateDisabledServer updates the state of a disabled server with the result of its check,
+// and returns whether the server is returned to the load-balancers.
+func (backend *BackendHealthCheck) updateDisabledServer(serverURL *url.URL, err error) bool {
+	backend.lock.Lock()
+	defer backend.lock.Unlock()
+
+	backend.recordCheck(serverURL, StatusDown, err)
+	if err != nil {
+		log.Warnf("Health check still failing. Backend: %q URL: %q Reason: %s", backend.name, serverURL.String(), err)
+		delete(backend.successes, serverURL.String())
+		return false
+	}
+
+	backend.successes[serverURL.String()]++
+	if successes := backend.successes[serverURL.String()]; successes < threshold(backend.Rise) {
+		log.Debugf("Health check up %d/%d times. Backend: %q URL: %q", successes, threshold(backend.Rise), backend.name, serverURL.String())
+		return false
+	}
+
+	log.Warnf("Health check up: Returning to server list. Backend: %q URL: %q", backend.name, serverURL.String())
+	for _, lb := range backend.loadBalancers {
+		lb.UpsertServer(serverURL, roundrobin.Weight(backend.disabledWeight(serverURL)))
+	}
+	delete(backend.disabledWeights, serverURL.String())
+	delete(backend.successes, serverURL.String())
+	for i, disabledURL := range backend.disabledURLs {
+		if disabledURL.String() == serverURL.String() {
+			backend.disabledURLs = append(backend.disabledURLs[:i], backend.disabledURLs[i+1:]...)
+			break
+		}
+	}
+	backend.recordTransition(serverURL, StatusUp, nil)
+	return true
+}
+
+// updateEnabledServer updates the state of an enabled server with the result of its check,
+// and returns whether the server stays in the load-balancers.
+func (backend *BackendHealthCheck) updateEnabledServer(serverURL *url.URL, err error) bool {
+	backend.lock.Lock()
+	defer backend.lock.Unlock()
+
+	backend.recordCheck(serverURL, StatusUp, err)
+	if err == nil {
+		delete(backend.failures, serverURL.String())
+		return true
+	}
+
+	backend.failures[serverURL.String()]++
+	if failures := backend.failures[serverURL.String()]; failures < threshold(backend.Fall) {
+		log.Warnf("Health check failed %d/%d times. Backend: %q URL: %q Reason: %s", failures, threshold(backend.Fall), backend.name, serverURL.String(), err)
+		return true
+	}
+
+	delete(backend.failures, serverURL.String())
+	log.Warnf("Health check failed: Remove from server list. Backend: %q URL: %q Reason: %s", backend.name, serverURL.String(), err)
+	backend.removeServer(serverURL)
+	backend.recordTransition(serverURL, StatusDown, err)
+	return false
+}
+
+// removeServer removes a server from the load-balancers, keeping its weight for its return.
+func (backend *BackendHealthCheck) removeServer(serverURL *url.URL) {
+	if lb, ok := backend.LB.(weightedLoadBalancer); ok {
+		if weight, ok := lb.ServerWeight(serverURL); ok {
+			backend.disabledWeights[serverURL.String()] = weight
+		}
+	}
+	for _, lb := range backend.loadBalancers {
+		lb.RemoveServer(serverURL)
+	}
+	if !backend.isDisabled(serverURL) {
+		backend.disabledURLs = append(backend.disabledURLs, serverURL)
+	}
+}
+
+func (backend *BackendHealthCheck) isDisabled(serverURL *url.URL) bool {
+	for _, disabledURL := range backend.disabledURLs {
+		if disabledURL.String() == serverURL.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// isDown returns whether the server has been removed from the load-balancers by the health check.
+func (backend *BackendHealthCheck) isDown(serverURL *url.URL) bool {
+	backend.lock.Lock()
+	defer backend.lock.Unlock()
+	return backend.isDisabled(serverURL)
+}
+
+// restoreState takes over the state of the servers of the previous configuration of the backend which are still configured,
+// and removes the servers which were down from the load-balancers.
+func (backend *BackendHealthCheck) restoreState(previous *BackendHealthCheck) {
+	previous.lock.Lock()
+	defer previous.lock.Unlock()
+	backend.lock.Lock()
+	defer backend.lock.Unlock()
+
+	servers := make(map[string]*url.URL)
+	for _, server := range backend.LB.Servers() {
+		servers[server.String()] = server
+	}
+
+	for _, disabledURL := range previous.disabledURLs {
+		if server, ok := servers[disabledURL.String()]; ok {
+			log.Debugf("Health check still down after reload: Remove from server list. Backend: %q URL: %q", backend.name, server.String())
+			backend.removeServer(server)
+		}
+	}
+	for key := range servers {
+		if successes, ok := previous.successes[key]; ok {
+			backend.successes[key] = successes
+		}
+		if failures, ok := previous.failures[key]; ok {
+			backend.failures[key] = failures
+		}
+		if status, ok := previous.statuses[key]; ok {
+			backend.statuses[key] = status.copy()
+		}
+	}
+}
+
+// recordCheck records the result of the check of a server, whose status is the given one before the check.
+func (backend *BackendHealthCheck) recordCheck(serverURL *url.URL, status string, err error) {
+	serverStatus, ok := backend.statuses[serverURL.String()]
+	if !ok {
+		serverStatus = &ServerStatus{URL: serverURL.String(), Status: status}
+		backend.statuses[serverURL.String()] = serverStatus
+	}
+
+	serverStatus.LastCheck = time.Now()
+	serverStatus.LastCheckSucceeded = err == nil
+	serverStatus.Error = ""
+	if err != nil {
+		serverStatus.Error = err.Error()
+	}
+}
+
+// recordTransition records the change of the status of a server, already checked.
+func (backend *BackendHealthCheck) recordTransition(serverURL *url.URL, status string, err error) {
+	serverStatus := backend.statuses[serverURL.String()]
+	serverStatus.Status = status
+
+	transition := Transition{Status: status, Time: serverStatus.LastCheck}
+	if err != nil {
+		transition.Error = err.Error()
+	}
+	serverStatus.Transitions = append(serverStatus.Transitions, transition)
+	if len(serverStatus.Transitions) > maxTransitions {
+		serverStatus.Transitions = serverStatus.Transitions[len(serverStatus.Transitions)-maxTransitions:]
+	}
+}
+
+// Status returns the health status of the servers of the backend, sorted by URL.
+// The servers not checked yet are listed with their status only.
+func (backend *BackendHealthCheck) Status() []ServerStatus {
+	backend.lock.Lock()
+	defer backend.lock.Unlock()
+
+	var statuses []ServerStatus
+	add := func(serverURL *url.URL, status string) {
+		if serverStatus, ok := backend.statuses[serverURL.String()]; ok {
+			statuses = append(statuses, *serverStatus.copy())
+		} else {
+			statuses = append(statuses, ServerStatus{URL: serverURL.String(), Status: status})
+		}
+	}
+	for _, serverURL := range backend.LB.Servers() {
+		add(serverURL, StatusUp)
+	}
+	for _, serverURL := range backend.disabledURLs {
+		add(serverURL, StatusDown)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].URL < statuses[j].URL })
+	return statuses
+}
+
+// Status returns the health status of the servers of each backend with a health check, by backend.
+func (hc *HealthCheck) Status() map[string][]ServerStatus {
+	hc.lock.RLock()
+	defer hc.lock.RUnlock()
+
+	statuses := make(map[string][]ServerStatus, len(hc.Backends))
+	for name, backend := range hc.Backends {
+		statuses[name] = backend.Status()
+	}
+	return statuses
+}
+
+// threshold returns the number of checks in a row changing the state of a server, which defaults to 1.
+func threshold(checks int) int {
+	if checks > 0 {
+		return checks
+	}
+	return 1
+}
+
+// disabledWeight returns the weight a disabled server had, which defaults to 1.
+func (backend *BackendHealthCheck) disabledWeight(serverURL *url.URL) int {
+	if weight, ok := backend.disabledWeights[serverURL.String()]; ok && weight > 0 {
+		return weight
+	}
+	return 1
+}
+
 func (backend *BackendHealthCheck) newRequest(serverURL *url.URL) (*http.Request, error) {
-	if backend.Port == 0 {
-		return http.NewRequest(http.MethodGet, serverURL.String()+backend.Path, nil)
+	method := http.MethodGet
+	if len(backend.Method) > 0 {
+		method = backend.Method
+	}
+
+	rawURL := serverURL.String() + backend.Path
+	if backend.Port != 0 || len(backend.Scheme) > 0 {
+		// copy the url and add the port to the host
+		u := &url.URL{}
+		*u = *serverURL
+		if backend.Port != 0 {
+			u.Host = net.JoinHostPort(u.Hostname(), strconv.Itoa(backend.Port))
+		}
+		u.Path = u.Path + backend.Path
+
+		if len(backend.Scheme) > 0 {
+			u.Scheme = backend.Scheme
+		}
+		rawURL = u.String()
 	}
 
-	// copy the url and add the port to the host
-	u := &url.URL{}
-	*u = *serverURL
-	u.Host = net.JoinHostPort(u.Hostname(), strconv.Itoa(backend.Port))
-	u.Path = u.Path + backend.Path
+	req, err := http.NewRequest(method, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
 
-	return http.NewRequest(http.MethodGet, u.String(), nil)
+	if len(backend.Hostname) > 0 {
+		req.Host = backend.Hostname
+	}
+	for name, value := range backend.Headers {
+		req.Header.Set(name, value)
+	}
+	return req, nil
+}
+
+func isHTTP(serverURL *url.URL) bool {
+	return serverURL.Scheme == "http" || serverURL.Scheme == "https"
 }
 
 // checkHealth returns a nil error in case it was successful and otherwise
 // a non-nil error with a meaningful description why the health check failed.
 func checkHealth(serverURL *url.URL, backend *BackendHealthCheck) error {
+	switch backend.Type {
+	case TypeTCP:
+		return checkTCPHealth(serverURL, backend)
+	case TypeGRPC:
+		return checkGRPCHealth(serverURL, backend)
+	default:
+		// servers of non-HTTP backends (e.g. UDP) are not checked with HTTP requests
+		if !isHTTP(serverURL) {
+			return fmt.Errorf("HTTP health check of non-HTTP server %s", serverURL)
+		}
+		return checkHTTPHealth(serverURL, backend)
+	}
+}
+
+func checkHTTPHealth(serverURL *url.URL, backend *BackendHealthCheck) error {
 	client := http.Client{
 		Timeout:   backend.requestTimeout,
 		Transport: backend.Options.Transport,
@@ -183,8 +542,83 @@ func checkHealth(serverURL *url.URL, backend *BackendHealthCheck) error {
 	switch {
 	case err != nil:
 		return fmt.Errorf("HTTP request failed: %s", err)
-	case resp.StatusCode != http.StatusOK:
+	case len(backend.StatusCodeRanges) == 0 && resp.StatusCode != http.StatusOK:
 		return fmt.Errorf("received non-200 status code: %v", resp.StatusCode)
+	case len(backend.StatusCodeRanges) > 0 && !inRanges(resp.StatusCode, backend.StatusCodeRanges):
+		return fmt.Errorf("received unexpected status code: %v", resp.StatusCode)
+	}
+
+	if backend.Body != nil {
+		body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxBodyBytes))
+		if err != nil {
+			return fmt.Errorf("failed to read response body: %s", err)
+		}
+		if !backend.Body.Match(body) {
+			return fmt.Errorf("response body doesn't match %q", backend.Body)
+		}
 	}
 	return nil
 }
+
+// checkTCPHealth checks that the server accepts connections, and completes the TLS handshake over https.
+func checkTCPHealth(serverURL *url.URL, backend *BackendHealthCheck) error {
+	dialer := &net.Dialer{Timeout: backend.requestTimeout}
+
+	var conn net.Conn
+	var err error
+	if backend.useTLS(serverURL) {
+		conn, err = tls.DialWithDialer(dialer, "tcp", backend.address(serverURL), backend.tlsConfig())
+	} else {
+		conn, err = dialer.Dial("tcp", backend.address(serverURL))
+	}
+	if err != nil {
+		return fmt.Errorf("TCP connection failed: %s", err)
+	}
+	return conn.Close()
+}
+
+// address returns the address the tcp and grpc health checks connect to.
+func (backend *BackendHealthCheck) address(serverURL *url.URL) string {
+	port := serverURL.Port()
+	if backend.Port != 0 {
+		port = strconv.Itoa(backend.Port)
+	}
+	if len(port) == 0 {
+		port = "80"
+		if serverURL.Scheme == "https" {
+			port = "443"
+		}
+	}
+	return net.JoinHostPort(serverURL.Hostname(), port)
+}
+
+// useTLS returns whether the tcp and grpc health checks connect over TLS,
+// i.e. the scheme of the health check, or else of the server, is https.
+func (backend *BackendHealthCheck) useTLS(serverURL *url.URL) bool {
+	if len(backend.Scheme) > 0 {
+		return backend.Scheme == "https"
+	}
+	return serverURL.Scheme == "https"
+}
+
+// tlsConfig returns the TLS configuration of the tcp and grpc health checks,
+// which is the one of the backend transport, with the hostname of the health check as server name.
+func (backend *BackendHealthCheck) tlsConfig() *tls.Config {
+	tlsConfig := &tls.Config{}
+	if transport, ok := backend.Transport.(*http.Transport); ok && transport.TLSClientConfig != nil {
+		tlsConfig = transport.TLSClientConfig.Clone()
+	}
+	if len(tlsConfig.ServerName) == 0 {
+		tlsConfig.ServerName = backend.Hostname
+	}
+	return tlsConfig
+}
+
+func inRanges(statusCode int, ranges [][2]int) bool {
+	for _, block := range ranges {
+		if statusCode >= block[0] && statusCode <= block[1] {
+			return true
+		}
+	}
+	return false
+}
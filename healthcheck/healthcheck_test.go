@@ -2,9 +2,11 @@ package healthcheck
 
 import (
 	"context"
+	"crypto/tls"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"regexp"
 	"sync"
 	"testing"
 	"time"
@@ -200,6 +202,438 @@ func TestNewRequest(t *testing.T) {
 	}
 }
 
+func TestCheckBackendRestoresWeight(t *testing.T) {
+	healthy := true
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if !healthy {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer ts.Close()
+
+	lb, err := roundrobin.New(nil)
+	if err != nil {
+		t.Fatalf("failed to create load-balancer: %s", err)
+	}
+	serverURL := testhelpers.MustParseURL(ts.URL)
+	if err := lb.UpsertServer(serverURL, roundrobin.Weight(5)); err != nil {
+		t.Fatalf("failed to add server: %s", err)
+	}
+
+	backend := NewBackendHealthCheck(Options{Path: "/path", Interval: healthCheckInterval, LB: lb}, "backendName")
+	check := HealthCheck{
+		Backends: make(map[string]*BackendHealthCheck),
+		metrics:  testhelpers.NewCollectingHealthCheckMetrics(),
+	}
+
+	healthy = false
+	check.checkBackend(backend)
+	if len(lb.Servers()) != 0 {
+		t.Fatalf("got %d servers, wanted the server to be removed", len(lb.Servers()))
+	}
+
+	healthy = true
+	check.checkBackend(backend)
+	if weight, ok := lb.ServerWeight(serverURL); !ok || weight != 5 {
+		t.Errorf("got weight %d for returning server, wanted 5", weight)
+	}
+}
+
+func TestCheckBackendLoadBalancers(t *testing.T) {
+	healthy := true
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if !healthy {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer ts.Close()
+	serverURL := testhelpers.MustParseURL(ts.URL)
+
+	var lbs []*roundrobin.RoundRobin
+	for i := 0; i < 2; i++ {
+		lb, err := roundrobin.New(nil)
+		if err != nil {
+			t.Fatalf("failed to create load-balancer: %s", err)
+		}
+		if err := lb.UpsertServer(serverURL); err != nil {
+			t.Fatalf("failed to add server: %s", err)
+		}
+		lbs = append(lbs, lb)
+	}
+
+	backend := NewBackendHealthCheck(Options{Path: "/path", Interval: healthCheckInterval, LB: lbs[0]}, "backendName")
+	backend.AddLoadBalancer(lbs[1])
+	check := HealthCheck{
+		Backends: make(map[string]*BackendHealthCheck),
+		metrics:  testhelpers.NewCollectingHealthCheckMetrics(),
+	}
+
+	healthy = false
+	check.checkBackend(backend)
+	for i, lb := range lbs {
+		if len(lb.Servers()) != 0 {
+			t.Errorf("got %d servers in load-balancer %d, wanted the server to be removed", len(lb.Servers()), i)
+		}
+	}
+
+	healthy = true
+	check.checkBackend(backend)
+	for i, lb := range lbs {
+		if len(lb.Servers()) != 1 {
+			t.Errorf("got %d servers in load-balancer %d, wanted the server to be returned", len(lb.Servers()), i)
+		}
+	}
+}
+
+func TestSetBackendsConfigurationRestoresState(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+	otherServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}))
+	defer otherServer.Close()
+	serverURL := testhelpers.MustParseURL(ts.URL)
+	otherURL := testhelpers.MustParseURL(otherServer.URL)
+
+	newLB := func() *roundrobin.RoundRobin {
+		lb, err := roundrobin.New(nil)
+		if err != nil {
+			t.Fatalf("failed to create load-balancer: %s", err)
+		}
+		if err := lb.UpsertServer(serverURL); err != nil {
+			t.Fatalf("failed to add server: %s", err)
+		}
+		return lb
+	}
+
+	check := HealthCheck{
+		Backends: make(map[string]*BackendHealthCheck),
+		metrics:  testhelpers.NewCollectingHealthCheckMetrics(),
+	}
+	previous := NewBackendHealthCheck(Options{Path: "/path", Interval: time.Hour, LB: newLB()}, "backendName")
+	check.checkBackend(previous)
+	check.Backends["backendName"] = previous
+
+	lb := newLB()
+	if err := lb.UpsertServer(otherURL); err != nil {
+		t.Fatalf("failed to add server: %s", err)
+	}
+	backend := NewBackendHealthCheck(Options{Path: "/path", Interval: time.Hour, LB: lb}, "backendName")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	check.SetBackendsConfiguration(ctx, map[string]*BackendHealthCheck{"backendName": backend})
+
+	servers := lb.Servers()
+	if len(servers) != 1 || servers[0].String() != otherURL.String() {
+		t.Errorf("got servers %v, wanted the server down before the reload to be removed", servers)
+	}
+
+	statuses := check.Status()["backendName"]
+	for _, status := range statuses {
+		if status.URL != serverURL.String() {
+			continue
+		}
+		if status.Status != StatusDown || status.LastCheckSucceeded || len(status.Error) == 0 {
+			t.Errorf("got status %+v, wanted a failed check of a server down", status)
+		}
+		if len(status.Transitions) == 0 || status.Transitions[0].Status != StatusDown {
+			t.Errorf("got transitions %+v, wanted the server to go down", status.Transitions)
+		}
+		return
+	}
+	t.Errorf("got statuses %+v, wanted the status of %s", statuses, serverURL)
+}
+
+func TestBackendHealthCheckStatus(t *testing.T) {
+	healthy := true
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if !healthy {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer ts.Close()
+	serverURL := testhelpers.MustParseURL(ts.URL)
+
+	lb, err := roundrobin.New(nil)
+	if err != nil {
+		t.Fatalf("failed to create load-balancer: %s", err)
+	}
+	if err := lb.UpsertServer(serverURL); err != nil {
+		t.Fatalf("failed to add server: %s", err)
+	}
+
+	backend := NewBackendHealthCheck(Options{Path: "/path", Interval: healthCheckInterval, LB: lb}, "backendName")
+	check := HealthCheck{
+		Backends: make(map[string]*BackendHealthCheck),
+		metrics:  testhelpers.NewCollectingHealthCheckMetrics(),
+	}
+
+	statuses := backend.Status()
+	if len(statuses) != 1 || statuses[0].Status != StatusUp || !statuses[0].LastCheck.IsZero() {
+		t.Fatalf("got statuses %+v, wanted a server up not checked yet", statuses)
+	}
+
+	for i := 0; i < maxTransitions+2; i++ {
+		healthy = !healthy
+		check.checkBackend(backend)
+	}
+
+	statuses = backend.Status()
+	if len(statuses) != 1 {
+		t.Fatalf("got %d statuses, wanted 1", len(statuses))
+	}
+	status := statuses[0]
+	if status.Status != StatusUp || !status.LastCheckSucceeded || len(status.Error) > 0 {
+		t.Errorf("got status %+v, wanted a successful check of a server up", status)
+	}
+	if len(status.Transitions) != maxTransitions {
+		t.Fatalf("got %d transitions, wanted %d", len(status.Transitions), maxTransitions)
+	}
+	if last := status.Transitions[maxTransitions-1]; last.Status != StatusUp {
+		t.Errorf("got last transition %+v, wanted the server to go up", last)
+	}
+	if first := status.Transitions[0]; first.Status != StatusDown || len(first.Error) == 0 {
+		t.Errorf("got first transition %+v, wanted the server to go down with an error", first)
+	}
+}
+
+func TestNewRequestOptions(t *testing.T) {
+	backend := NewBackendHealthCheck(
+		Options{
+			Path:     "/health",
+			Scheme:   "https",
+			Method:   http.MethodHead,
+			Hostname: "foo.localhost",
+			Headers:  map[string]string{"X-Health": "1"},
+		}, "backendName")
+
+	req, err := backend.newRequest(testhelpers.MustParseURL("http://backend1:80"))
+	if err != nil {
+		t.Fatalf("failed to create new backend request: %s", err)
+	}
+
+	if req.URL.String() != "https://backend1:80/health" {
+		t.Errorf("got %s for healthcheck URL, wanted https://backend1:80/health", req.URL)
+	}
+	if req.Method != http.MethodHead {
+		t.Errorf("got method %s, wanted HEAD", req.Method)
+	}
+	if req.Host != "foo.localhost" {
+		t.Errorf("got Host %s, wanted foo.localhost", req.Host)
+	}
+	if req.Header.Get("X-Health") != "1" {
+		t.Errorf("got X-Health header %q, wanted 1", req.Header.Get("X-Health"))
+	}
+}
+
+func TestCheckHealth(t *testing.T) {
+	tests := []struct {
+		desc        string
+		statusCode  int
+		body        string
+		delay       time.Duration
+		options     Options
+		wantHealthy bool
+	}{
+		{
+			desc:        "200 by default",
+			statusCode:  http.StatusOK,
+			wantHealthy: true,
+		},
+		{
+			desc:        "non-200 by default",
+			statusCode:  http.StatusNoContent,
+			wantHealthy: false,
+		},
+		{
+			desc:        "status code in the ranges",
+			statusCode:  http.StatusNoContent,
+			options:     Options{StatusCodeRanges: [][2]int{{200, 299}}},
+			wantHealthy: true,
+		},
+		{
+			desc:        "status code out of the ranges",
+			statusCode:  http.StatusOK,
+			options:     Options{StatusCodeRanges: [][2]int{{204, 204}}},
+			wantHealthy: false,
+		},
+		{
+			desc:        "body matching",
+			statusCode:  http.StatusOK,
+			body:        `{"status": "ok"}`,
+			options:     Options{Body: regexp.MustCompile(`"status": "ok"`)},
+			wantHealthy: true,
+		},
+		{
+			desc:        "body not matching",
+			statusCode:  http.StatusOK,
+			body:        `{"status": "degraded"}`,
+			options:     Options{Body: regexp.MustCompile(`"status": "ok"`)},
+			wantHealthy: false,
+		},
+		{
+			desc:        "timeout",
+			statusCode:  http.StatusOK,
+			delay:       100 * time.Millisecond,
+			options:     Options{Timeout: 10 * time.Millisecond},
+			wantHealthy: false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+			ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				time.Sleep(test.delay)
+				rw.WriteHeader(test.statusCode)
+				rw.Write([]byte(test.body))
+			}))
+			defer ts.Close()
+
+			options := test.options
+			options.Path = "/path"
+			backend := NewBackendHealthCheck(options, "backendName")
+
+			err := checkHealth(testhelpers.MustParseURL(ts.URL), backend)
+			if healthy := err == nil; healthy != test.wantHealthy {
+				t.Errorf("got healthy %t (%v), wanted %t", healthy, err, test.wantHealthy)
+			}
+		})
+	}
+}
+
+func TestCheckHealthOfNonHTTPServer(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests++
+	}))
+	defer ts.Close()
+
+	backend := NewBackendHealthCheck(Options{Path: "/health"}, "backendName")
+	serverURL := testhelpers.MustParseURL(ts.URL)
+	serverURL.Scheme = "udp"
+
+	if err := checkHealth(serverURL, backend); err == nil {
+		t.Error("got healthy UDP server with an HTTP health check, wanted unhealthy")
+	}
+	if requests != 0 {
+		t.Errorf("got %d HTTP requests to the UDP server, wanted none", requests)
+	}
+}
+
+func TestCheckTCPHealth(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}))
+	defer ts.Close()
+	tlsServer := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}))
+	defer tlsServer.Close()
+	closedServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}))
+	closedServer.Close()
+
+	insecureTransport := &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
+
+	tests := []struct {
+		desc        string
+		serverURL   string
+		options     Options
+		wantHealthy bool
+	}{
+		{
+			desc:        "connection accepted",
+			serverURL:   ts.URL,
+			wantHealthy: true,
+		},
+		{
+			desc:        "connection refused",
+			serverURL:   closedServer.URL,
+			wantHealthy: false,
+		},
+		{
+			desc:        "TLS handshake",
+			serverURL:   tlsServer.URL,
+			options:     Options{Transport: insecureTransport},
+			wantHealthy: true,
+		},
+		{
+			desc:        "TLS handshake with an untrusted certificate",
+			serverURL:   tlsServer.URL,
+			wantHealthy: false,
+		},
+		{
+			desc:        "TLS handshake failing",
+			serverURL:   ts.URL,
+			options:     Options{Scheme: "https", Transport: insecureTransport},
+			wantHealthy: false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			options := test.options
+			options.Type = TypeTCP
+			backend := NewBackendHealthCheck(options, "backendName")
+
+			err := checkHealth(testhelpers.MustParseURL(test.serverURL), backend)
+			if healthy := err == nil; healthy != test.wantHealthy {
+				t.Errorf("got healthy %t (%v), wanted %t", healthy, err, test.wantHealthy)
+			}
+		})
+	}
+}
+
+func TestCheckBackendThresholds(t *testing.T) {
+	healthy := true
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if !healthy {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer ts.Close()
+
+	lb := &testLoadBalancer{RWMutex: &sync.RWMutex{}}
+	lb.servers = append(lb.servers, testhelpers.MustParseURL(ts.URL))
+
+	backend := NewBackendHealthCheck(Options{Path: "/path", Interval: healthCheckInterval, LB: lb, Rise: 3, Fall: 2}, "backendName")
+	check := HealthCheck{
+		Backends: make(map[string]*BackendHealthCheck),
+		metrics:  testhelpers.NewCollectingHealthCheckMetrics(),
+	}
+
+	healthy = false
+	check.checkBackend(backend)
+	if lb.numRemovedServers != 0 {
+		t.Fatalf("got %d removed servers after a failed check, wanted 0", lb.numRemovedServers)
+	}
+
+	// a successful check resets the failures
+	healthy = true
+	check.checkBackend(backend)
+	healthy = false
+	check.checkBackend(backend)
+	if lb.numRemovedServers != 0 {
+		t.Fatalf("got %d removed servers after non consecutive failed checks, wanted 0", lb.numRemovedServers)
+	}
+
+	check.checkBackend(backend)
+	if lb.numRemovedServers != 1 {
+		t.Fatalf("got %d removed servers after 2 failed checks, wanted 1", lb.numRemovedServers)
+	}
+
+	healthy = true
+	check.checkBackend(backend)
+	check.checkBackend(backend)
+	if lb.numUpsertedServers != 0 {
+		t.Fatalf("got %d upserted servers after 2 successful checks, wanted 0", lb.numUpsertedServers)
+	}
+
+	check.checkBackend(backend)
+	if lb.numUpsertedServers != 1 {
+		t.Errorf("got %d upserted servers after 3 successful checks, wanted 1", lb.numUpsertedServers)
+	}
+}
+
 type testLoadBalancer struct {
 	// RWMutex needed due to parallel test execution: Both the system-under-test
 	// and the test assertions reference the counters.
@@ -0,0 +1,182 @@
+package healthcheck
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/containous/traefik/log"
+	"github.com/vulcand/oxy/roundrobin"
+)
+
+// OutlierOptions are the outlier detection options of a backend.
+type OutlierOptions struct {
+	// ConsecutiveErrors is the number of consecutive 5xx responses or network errors ejecting a server
+	ConsecutiveErrors int
+	// BaseEjectionTime is the duration of the first ejection of a server, doubled at each new ejection
+	BaseEjectionTime time.Duration
+	// MaxEjectionTime caps the ejection duration
+	MaxEjectionTime time.Duration
+	// MaxEjectionPercent is the maximum percentage of the servers of the backend ejected at the same time
+	MaxEjectionPercent int
+	LB                 LoadBalancer
+}
+
+func (opt OutlierOptions) String() string {
+	return fmt.Sprintf("[ConsecutiveErrors: %d BaseEjectionTime: %s MaxEjectionTime: %s MaxEjectionPercent: %d]",
+		opt.ConsecutiveErrors, opt.BaseEjectionTime, opt.MaxEjectionTime, opt.MaxEjectionPercent)
+}
+
+// OutlierDetector is a passive health check: it ejects from the load-balancer the servers failing on live traffic,
+// as opposed to the servers failing the active health check requests.
+// A server is ejected for BaseEjectionTime, doubled each time it is ejected again shortly after returning,
+// and then returned to the load-balancer with its weight.
+type OutlierDetector struct {
+	OutlierOptions
+	// HealthCheck is the active health check of the backend, if any, which returns the servers it removed itself
+	HealthCheck *BackendHealthCheck
+	name        string
+	servers     map[string]*outlierServer
+	ejected     int
+	lock        sync.Mutex
+}
+
+type outlierServer struct {
+	url               *url.URL
+	consecutiveErrors int
+	ejected           bool
+	ejections         uint
+	ejectionTime      time.Duration
+	returned          time.Time
+}
+
+// NewOutlierDetector creates a new OutlierDetector for a backend.
+// The load-balancer of the options can be set later, before the first request is recorded.
+func NewOutlierDetector(options OutlierOptions, backendName string) *OutlierDetector {
+	return &OutlierDetector{
+		OutlierOptions: options,
+		name:           backendName,
+		servers:        make(map[string]*outlierServer),
+	}
+}
+
+// ObserveResponse records the response of a server, as a response modifier of the forwarder.
+func (d *OutlierDetector) ObserveResponse(res *http.Response) error {
+	if res.Request != nil {
+		d.Record(res.Request.URL, res.StatusCode >= http.StatusInternalServerError)
+	}
+	return nil
+}
+
+// Record records the outcome of a request forwarded to a server, ejecting the server after too many consecutive errors.
+func (d *OutlierDetector) Record(serverURL *url.URL, failed bool) {
+	if serverURL == nil {
+		return
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	server, ok := d.servers[serverURL.Host]
+	if !ok {
+		if !failed {
+			return
+		}
+		server = &outlierServer{}
+		d.servers[serverURL.Host] = server
+	}
+	if server.ejected {
+		// the in-flight requests of an ejected server don't count
+		return
+	}
+	if !failed {
+		server.consecutiveErrors = 0
+		return
+	}
+
+	server.consecutiveErrors++
+	if server.consecutiveErrors >= d.ConsecutiveErrors {
+		d.eject(server, serverURL)
+	}
+}
+
+// eject removes a server from the load-balancer, unless too many servers of the backend are already ejected.
+func (d *OutlierDetector) eject(server *outlierServer, serverURL *url.URL) {
+	lbServer := d.lbServer(serverURL)
+	if lbServer == nil {
+		// removed by the active health check
+		server.consecutiveErrors = 0
+		return
+	}
+
+	total := len(d.LB.Servers()) + d.ejected
+	maxEjected := total * d.MaxEjectionPercent / 100
+	if maxEjected < 1 {
+		maxEjected = 1
+	}
+	if d.ejected >= maxEjected {
+		log.Warnf("Outlier detection: not ejecting server, %d out of %d servers already ejected. Backend: %q URL: %q", d.ejected, total, d.name, lbServer.String())
+		return
+	}
+
+	// a server failing again long after it returned starts over with the base ejection time
+	if server.ejections > 0 && time.Since(server.returned) > 2*server.ejectionTime {
+		server.ejections = 0
+	}
+	server.ejectionTime = d.BaseEjectionTime << server.ejections
+	if server.ejectionTime > d.MaxEjectionTime || server.ejectionTime <= 0 {
+		server.ejectionTime = d.MaxEjectionTime
+	}
+	server.ejections++
+
+	weight := 1
+	if lb, ok := d.LB.(weightedLoadBalancer); ok {
+		if w, ok := lb.ServerWeight(lbServer); ok && w > 0 {
+			weight = w
+		}
+	}
+
+	log.Warnf("Outlier detection: %d consecutive errors, ejecting server for %s. Backend: %q URL: %q", server.consecutiveErrors, server.ejectionTime, d.name, lbServer.String())
+	if err := d.LB.RemoveServer(lbServer); err != nil {
+		log.Errorf("Outlier detection: failed to eject server. Backend: %q URL: %q Reason: %s", d.name, lbServer.String(), err)
+		return
+	}
+	server.url = lbServer
+	server.ejected = true
+	server.consecutiveErrors = 0
+	d.ejected++
+
+	time.AfterFunc(server.ejectionTime, func() {
+		d.restore(server, weight)
+	})
+}
+
+// restore returns an ejected server to the load-balancer.
+func (d *OutlierDetector) restore(server *outlierServer, weight int) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.HealthCheck != nil && d.HealthCheck.isDown(server.url) {
+		log.Warnf("Outlier detection: server still down for the active health check, not returning it. Backend: %q URL: %q", d.name, server.url.String())
+	} else {
+		log.Warnf("Outlier detection: returning to server list. Backend: %q URL: %q", d.name, server.url.String())
+		if err := d.LB.UpsertServer(server.url, roundrobin.Weight(weight)); err != nil {
+			log.Errorf("Outlier detection: failed to return server. Backend: %q URL: %q Reason: %s", d.name, server.url.String(), err)
+		}
+	}
+	server.ejected = false
+	server.returned = time.Now()
+	d.ejected--
+}
+
+// lbServer returns the server of the load-balancer requests are forwarded to with serverURL.
+func (d *OutlierDetector) lbServer(serverURL *url.URL) *url.URL {
+	for _, u := range d.LB.Servers() {
+		if u.Host == serverURL.Host {
+			return u
+		}
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package healthcheck
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/containous/traefik/testhelpers"
+	"github.com/vulcand/oxy/roundrobin"
+)
+
+func newOutlierTestLoadBalancer(t *testing.T, hosts ...string) *roundrobin.RoundRobin {
+	lb, err := roundrobin.New(nil)
+	if err != nil {
+		t.Fatalf("failed to create load-balancer: %s", err)
+	}
+	for _, host := range hosts {
+		if err := lb.UpsertServer(testhelpers.MustParseURL("http://"+host), roundrobin.Weight(3)); err != nil {
+			t.Fatalf("failed to add server: %s", err)
+		}
+	}
+	return lb
+}
+
+func recordResponses(detector *OutlierDetector, host string, statusCodes ...int) {
+	for _, statusCode := range statusCodes {
+		req := testhelpers.MustNewRequest(http.MethodGet, "http://"+host+"/path", nil)
+		detector.ObserveResponse(&http.Response{StatusCode: statusCode, Request: req})
+	}
+}
+
+func TestOutlierDetectorEjectsAndRestores(t *testing.T) {
+	lb := newOutlierTestLoadBalancer(t, "a", "b")
+	detector := NewOutlierDetector(OutlierOptions{
+		ConsecutiveErrors:  3,
+		BaseEjectionTime:   50 * time.Millisecond,
+		MaxEjectionTime:    time.Second,
+		MaxEjectionPercent: 50,
+		LB:                 lb,
+	}, "backendName")
+
+	// a successful response resets the consecutive errors
+	recordResponses(detector, "a", http.StatusBadGateway, http.StatusInternalServerError, http.StatusOK, http.StatusNotFound, http.StatusServiceUnavailable)
+	if len(lb.Servers()) != 2 {
+		t.Fatalf("got %d servers, wanted no server to be ejected", len(lb.Servers()))
+	}
+
+	recordResponses(detector, "a", http.StatusServiceUnavailable)
+	detector.Record(&url.URL{Scheme: "http", Host: "a"}, true)
+	servers := lb.Servers()
+	if len(servers) != 1 || servers[0].Host != "b" {
+		t.Fatalf("got servers %v, wanted server a to be ejected", servers)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if weight, ok := lb.ServerWeight(testhelpers.MustParseURL("http://a")); !ok || weight != 3 {
+		t.Errorf("got weight %d for returning server, wanted 3", weight)
+	}
+}
+
+func TestOutlierDetectorMaxEjectionPercent(t *testing.T) {
+	lb := newOutlierTestLoadBalancer(t, "a", "b", "c")
+	detector := NewOutlierDetector(OutlierOptions{
+		ConsecutiveErrors:  1,
+		BaseEjectionTime:   time.Hour,
+		MaxEjectionTime:    time.Hour,
+		MaxEjectionPercent: 10,
+		LB:                 lb,
+	}, "backendName")
+
+	recordResponses(detector, "a", http.StatusBadGateway)
+	recordResponses(detector, "b", http.StatusBadGateway)
+	recordResponses(detector, "c", http.StatusBadGateway)
+
+	// at least one server is ejected
+	if len(lb.Servers()) != 2 {
+		t.Errorf("got %d servers, wanted a single server to be ejected", len(lb.Servers()))
+	}
+}
+
+func TestOutlierDetectorEjectionTime(t *testing.T) {
+	lb := newOutlierTestLoadBalancer(t, "a")
+	detector := NewOutlierDetector(OutlierOptions{
+		ConsecutiveErrors:  1,
+		BaseEjectionTime:   time.Hour,
+		MaxEjectionTime:    3 * time.Hour,
+		MaxEjectionPercent: 100,
+		LB:                 lb,
+	}, "backendName")
+
+	expected := []time.Duration{time.Hour, 2 * time.Hour, 3 * time.Hour, 3 * time.Hour}
+	for i, ejectionTime := range expected {
+		recordResponses(detector, "a", http.StatusBadGateway)
+
+		detector.lock.Lock()
+		server := detector.servers["a"]
+		if server.ejectionTime != ejectionTime {
+			t.Errorf("got ejection time %s for ejection %d, wanted %s", server.ejectionTime, i+1, ejectionTime)
+		}
+		detector.lock.Unlock()
+
+		// return the server at once, as if its ejection time had elapsed
+		detector.restore(server, 1)
+	}
+}
+
+func TestOutlierDetectorWithActiveHealthCheck(t *testing.T) {
+	healthy := true
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if !healthy {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer ts.Close()
+	otherServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}))
+	defer otherServer.Close()
+	serverURL := testhelpers.MustParseURL(ts.URL)
+
+	lb := newOutlierTestLoadBalancer(t, testhelpers.MustParseURL(otherServer.URL).Host)
+	if err := lb.UpsertServer(serverURL); err != nil {
+		t.Fatalf("failed to add server: %s", err)
+	}
+
+	backend := NewBackendHealthCheck(Options{Path: "/path", Interval: time.Hour, LB: lb}, "backendName")
+	check := HealthCheck{
+		Backends: make(map[string]*BackendHealthCheck),
+		metrics:  testhelpers.NewCollectingHealthCheckMetrics(),
+	}
+	detector := NewOutlierDetector(OutlierOptions{
+		ConsecutiveErrors:  1,
+		BaseEjectionTime:   50 * time.Millisecond,
+		MaxEjectionTime:    time.Second,
+		MaxEjectionPercent: 50,
+		LB:                 lb,
+	}, "backendName")
+	detector.HealthCheck = backend
+
+	detector.Record(serverURL, true)
+	// the server fails the active health check started before its ejection
+	healthy = false
+	backend.updateEnabledServer(serverURL, errors.New("server down"))
+
+	time.Sleep(100 * time.Millisecond)
+	for _, server := range lb.Servers() {
+		if server.String() == serverURL.String() {
+			t.Fatalf("got servers %v, wanted the server down for the active health check not to be returned", lb.Servers())
+		}
+	}
+
+	healthy = true
+	check.checkBackend(backend)
+	check.checkBackend(backend)
+	var count int
+	for _, server := range lb.Servers() {
+		if server.String() == serverURL.String() {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Errorf("got servers %v, wanted the server to be returned by the active health check", lb.Servers())
+	}
+	if len(backend.disabledURLs) != 0 {
+		t.Errorf("got disabled servers %v, wanted none", backend.disabledURLs)
+	}
+}
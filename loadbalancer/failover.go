@@ -0,0 +1,177 @@
+package loadbalancer
+
+import (
+	"net/url"
+	"sort"
+	"sync"
+
+	"github.com/containous/traefik/healthcheck"
+	"github.com/containous/traefik/log"
+	"github.com/vulcand/oxy/roundrobin"
+)
+
+// Failover wraps the load-balancer of a backend whose servers are split into priority groups,
+// e.g. the servers in the zone of the Traefik instance first, and then the servers in the other zones.
+// The requests are only forwarded to the servers of the first group,
+// unless the healthy weight of the group drops below threshold percent of its weight:
+// then the servers of the next group are added to the load-balancer too, and so on.
+// The healthy servers are the servers added to the Failover, and not removed by the health checks.
+type Failover struct {
+	lb        healthcheck.LoadBalancer
+	backend   string
+	threshold int
+	// priority groups of the servers, by URL, the lowest first
+	groups map[string]int
+	// servers added to the failover, healthy or not, by URL
+	servers map[string]*failoverServer
+	lock    sync.Mutex
+}
+
+type failoverServer struct {
+	url     *url.URL
+	weight  int
+	group   int
+	healthy bool
+	active  bool
+}
+
+// NewFailover creates a new Failover for the load-balancer of a backend.
+// A server without a group belongs to the group 0.
+func NewFailover(lb healthcheck.LoadBalancer, backend string, groups map[string]int, threshold int) *Failover {
+	return &Failover{
+		lb:        lb,
+		backend:   backend,
+		threshold: threshold,
+		groups:    groups,
+		servers:   make(map[string]*failoverServer),
+	}
+}
+
+// UpsertServer adds a healthy server, or updates its weight.
+// The server is added to the load-balancer if its group receives requests.
+func (f *Failover) UpsertServer(u *url.URL, options ...roundrobin.ServerOption) error {
+	weight, err := serverWeight(u, options...)
+	if err != nil {
+		return err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	server, ok := f.servers[u.String()]
+	if !ok {
+		server = &failoverServer{url: u, group: f.groups[u.String()]}
+		f.servers[u.String()] = server
+	}
+	server.weight = weight
+	server.healthy = true
+
+	if server.active {
+		// update the weight of the server
+		if err := f.lb.UpsertServer(u, options...); err != nil {
+			return err
+		}
+	}
+	return f.update()
+}
+
+// RemoveServer marks a server as unhealthy, and removes it from the load-balancer.
+// The servers of the next group are added to the load-balancer if needed.
+func (f *Failover) RemoveServer(u *url.URL) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if server, ok := f.servers[u.String()]; ok {
+		server.healthy = false
+	}
+	return f.update()
+}
+
+// Servers returns the healthy servers, whether they receive requests or not,
+// so that the servers of all the groups are checked by the health checks.
+func (f *Failover) Servers() []*url.URL {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var servers []*url.URL
+	for _, server := range f.sortedServers() {
+		if server.healthy {
+			servers = append(servers, server.url)
+		}
+	}
+	return servers
+}
+
+// ServerWeight returns the configured weight of a server, even if it is unhealthy.
+func (f *Failover) ServerWeight(u *url.URL) (int, bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	server, ok := f.servers[u.String()]
+	if !ok {
+		return 0, false
+	}
+	return server.weight, true
+}
+
+// update adds the healthy servers of the groups receiving requests to the load-balancer, and removes the others.
+func (f *Failover) update() error {
+	servers := f.sortedServers()
+	active := activeGroups(servers, f.threshold)
+
+	for _, server := range servers {
+		switch {
+		case server.healthy && active[server.group] && !server.active:
+			if err := f.lb.UpsertServer(server.url, roundrobin.Weight(server.weight)); err != nil {
+				return err
+			}
+			server.active = true
+			if server.group > 0 {
+				log.Debugf("Failover of backend %s: adding server %s of priority group %d", f.backend, server.url, server.group)
+			}
+		case (!server.healthy || !active[server.group]) && server.active:
+			if err := f.lb.RemoveServer(server.url); err != nil {
+				return err
+			}
+			server.active = false
+		}
+	}
+	return nil
+}
+
+// sortedServers returns the servers by group, and then by URL.
+func (f *Failover) sortedServers() []*failoverServer {
+	servers := make([]*failoverServer, 0, len(f.servers))
+	for _, server := range f.servers {
+		servers = append(servers, server)
+	}
+	sort.Slice(servers, func(i, j int) bool {
+		if servers[i].group != servers[j].group {
+			return servers[i].group < servers[j].group
+		}
+		return servers[i].url.String() < servers[j].url.String()
+	})
+	return servers
+}
+
+// activeGroups returns the groups receiving requests: the groups up to the first one
+// whose healthy weight is at least threshold percent of its weight, or all the groups if none is.
+func activeGroups(sortedServers []*failoverServer, threshold int) map[int]bool {
+	active := make(map[int]bool)
+	for i := 0; i < len(sortedServers); {
+		group := sortedServers[i].group
+		var weight, healthyWeight int
+		for ; i < len(sortedServers) && sortedServers[i].group == group; i++ {
+			weight += sortedServers[i].weight
+			if sortedServers[i].healthy {
+				healthyWeight += sortedServers[i].weight
+			}
+		}
+
+		active[group] = true
+		if healthyWeight > 0 && healthyWeight*100 >= threshold*weight {
+			break
+		}
+	}
+	return active
+}
@@ -0,0 +1,100 @@
+package loadbalancer
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+	"testing"
+
+	"github.com/containous/traefik/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulcand/oxy/roundrobin"
+)
+
+func hosts(servers []*url.URL) []string {
+	var result []string
+	for _, server := range servers {
+		result = append(result, server.Host)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func TestFailover(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		unhealthy []string
+		expected  []string
+	}{
+		{
+			desc:     "all healthy",
+			expected: []string{"a1", "a2", "a3"},
+		},
+		{
+			desc:      "above threshold",
+			unhealthy: []string{"a1"},
+			expected:  []string{"a2", "a3"},
+		},
+		{
+			desc:      "below threshold",
+			unhealthy: []string{"a1", "a2"},
+			expected:  []string{"a3", "b1"},
+		},
+		{
+			desc:      "next group below threshold too",
+			unhealthy: []string{"a1", "a2", "a3", "b1"},
+			expected:  []string{"c1"},
+		},
+		{
+			desc:      "all unhealthy",
+			unhealthy: []string{"a1", "a2", "a3", "b1", "c1"},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			rr, err := roundrobin.New(http.NotFoundHandler())
+			require.NoError(t, err)
+
+			groups := map[string]int{"http://b1": 1, "http://c1": 2}
+			failover := NewFailover(rr, "backend", groups, 50)
+			for _, host := range []string{"a1", "a2", "a3", "b1", "c1"} {
+				require.NoError(t, failover.UpsertServer(testhelpers.MustParseURL("http://"+host)))
+			}
+			for _, host := range test.unhealthy {
+				require.NoError(t, failover.RemoveServer(testhelpers.MustParseURL("http://"+host)))
+			}
+
+			assert.Equal(t, test.expected, hosts(rr.Servers()))
+			assert.Len(t, failover.Servers(), 5-len(test.unhealthy))
+		})
+	}
+}
+
+func TestFailoverRecovery(t *testing.T) {
+	rr, err := roundrobin.New(http.NotFoundHandler())
+	require.NoError(t, err)
+	failover := NewFailover(rr, "backend", map[string]int{"http://b": 1}, 70)
+
+	a := testhelpers.MustParseURL("http://a")
+	require.NoError(t, failover.UpsertServer(a, roundrobin.Weight(3)))
+	require.NoError(t, failover.UpsertServer(testhelpers.MustParseURL("http://b")))
+	assert.Equal(t, []string{"a"}, hosts(rr.Servers()))
+
+	require.NoError(t, failover.RemoveServer(a))
+	assert.Equal(t, []string{"b"}, hosts(rr.Servers()))
+
+	weight, ok := failover.ServerWeight(a)
+	require.True(t, ok)
+	assert.Equal(t, 3, weight)
+
+	// the server of the first group is back with its weight, the next group doesn't receive requests anymore
+	require.NoError(t, failover.UpsertServer(a, roundrobin.Weight(weight)))
+	assert.Equal(t, []string{"a"}, hosts(rr.Servers()))
+	weight, _ = rr.ServerWeight(a)
+	assert.Equal(t, 3, weight)
+}
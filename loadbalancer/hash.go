@@ -0,0 +1,250 @@
+package loadbalancer
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/containous/traefik/whitelist"
+	"github.com/vulcand/oxy/roundrobin"
+)
+
+const (
+	// DefaultHashKey is the key requests are hashed by when none is configured.
+	DefaultHashKey = "client.ip"
+	// DefaultHashLoadFactor is the load factor used when none is configured.
+	DefaultHashLoadFactor = 1.25
+
+	// number of points of a server of weight 1 on the ring
+	hashReplicas = 100
+)
+
+// HashKeyFunc extracts the key a request is hashed by.
+type HashKeyFunc func(req *http.Request) string
+
+// NewHashKeyFunc creates the function extracting the given key from the requests.
+// The key is client.ip, request.path, request.header.<name> or request.cookie.<name>.
+// Requests without the header or the cookie are hashed by client IP, resolved by clientIP.
+func NewHashKeyFunc(key string, clientIP *whitelist.ClientIP) (HashKeyFunc, error) {
+	byClientIP := clientIPKey(clientIP)
+	switch {
+	case key == "client.ip":
+		return byClientIP, nil
+	case key == "request.path":
+		return func(req *http.Request) string { return req.URL.Path }, nil
+	case strings.HasPrefix(key, "request.header."):
+		header := strings.TrimPrefix(key, "request.header.")
+		if header == "" {
+			return nil, errors.New("missing header name in hash key")
+		}
+		return func(req *http.Request) string {
+			if value := req.Header.Get(header); value != "" {
+				return value
+			}
+			return byClientIP(req)
+		}, nil
+	case strings.HasPrefix(key, "request.cookie."):
+		name := strings.TrimPrefix(key, "request.cookie.")
+		if name == "" {
+			return nil, errors.New("missing cookie name in hash key")
+		}
+		return func(req *http.Request) string {
+			if cookie, err := req.Cookie(name); err == nil && cookie.Value != "" {
+				return cookie.Value
+			}
+			return byClientIP(req)
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported hash key %q", key)
+	}
+}
+
+// clientIPKey hashes the requests by the IP of their client, or by their remote address when it can't be parsed.
+func clientIPKey(clientIP *whitelist.ClientIP) HashKeyFunc {
+	return func(req *http.Request) string {
+		if ip := clientIP.IP(req); ip != nil {
+			return ip.String()
+		}
+		return req.RemoteAddr
+	}
+}
+
+// ConsistentHash forwards the requests with the same key to the same server, as long as its load is bounded:
+// a server never has more than loadFactor times the average number of outstanding requests (relative to its weight),
+// the requests above this bound spill over to the next servers of the ring.
+// Adding or removing a server only remaps the keys of this server.
+type ConsistentHash struct {
+	next        http.Handler
+	key         HashKeyFunc
+	loadFactor  float64
+	servers     []*hashServer
+	ring        []ringPoint
+	totalWeight int
+	// total number of outstanding requests
+	totalActive int
+	lock        sync.Mutex
+}
+
+type hashServer struct {
+	url    *url.URL
+	weight int
+	active int
+}
+
+type ringPoint struct {
+	hash   uint64
+	server *hashServer
+}
+
+// NewConsistentHash creates a new, empty, load-balancer forwarding the requests to next.
+// The load factor must be at least 1, 0 stands for DefaultHashLoadFactor.
+func NewConsistentHash(next http.Handler, key HashKeyFunc, loadFactor float64) (*ConsistentHash, error) {
+	if loadFactor == 0 {
+		loadFactor = DefaultHashLoadFactor
+	}
+	if loadFactor < 1 {
+		return nil, fmt.Errorf("invalid load factor %v: must be at least 1", loadFactor)
+	}
+	return &ConsistentHash{next: next, key: key, loadFactor: loadFactor}, nil
+}
+
+func (b *ConsistentHash) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	server := b.acquire(hash(b.key(req)))
+	if server == nil {
+		rw.WriteHeader(http.StatusServiceUnavailable)
+		rw.Write([]byte(http.StatusText(http.StatusServiceUnavailable)))
+		return
+	}
+	defer b.release(server)
+
+	// make a shallow copy of the request before changing its URL
+	newReq := *req
+	newReq.URL = server.url
+	b.next.ServeHTTP(rw, &newReq)
+}
+
+// acquire counts a new request on the first server following the given hash on the ring, which is below its bound.
+func (b *ConsistentHash) acquire(h uint64) *hashServer {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if len(b.ring) == 0 {
+		return nil
+	}
+
+	start := sort.Search(len(b.ring), func(i int) bool { return b.ring[i].hash >= h })
+	for i := range b.ring {
+		server := b.ring[(start+i)%len(b.ring)].server
+		if server.active < b.capacity(server) {
+			server.active++
+			b.totalActive++
+			return server
+		}
+	}
+
+	// the sum of the capacities is above the number of requests, this is not reached
+	server := b.ring[start%len(b.ring)].server
+	server.active++
+	b.totalActive++
+	return server
+}
+
+// capacity returns the maximum number of outstanding requests of a server, including the one being balanced.
+func (b *ConsistentHash) capacity(server *hashServer) int {
+	average := float64(b.totalActive+1) * float64(server.weight) / float64(b.totalWeight)
+	return int(math.Ceil(b.loadFactor * average))
+}
+
+func (b *ConsistentHash) release(server *hashServer) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	server.active--
+	b.totalActive--
+}
+
+// UpsertServer adds a server to the load-balancer, or updates its weight.
+func (b *ConsistentHash) UpsertServer(u *url.URL, options ...roundrobin.ServerOption) error {
+	weight, err := serverWeight(u, options...)
+	if err != nil {
+		return err
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if _, server := b.find(u); server != nil {
+		server.weight = weight
+	} else {
+		b.servers = append(b.servers, &hashServer{url: u, weight: weight})
+	}
+	b.buildRing()
+	return nil
+}
+
+// RemoveServer removes a server from the load-balancer.
+// Its outstanding requests are not interrupted.
+func (b *ConsistentHash) RemoveServer(u *url.URL) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	i, server := b.find(u)
+	if server == nil {
+		return errors.New("server not found")
+	}
+	b.servers = append(b.servers[:i], b.servers[i+1:]...)
+	b.buildRing()
+	return nil
+}
+
+// Servers returns the servers of the load-balancer.
+func (b *ConsistentHash) Servers() []*url.URL {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	var urls []*url.URL
+	for _, server := range b.servers {
+		urls = append(urls, server.url)
+	}
+	return urls
+}
+
+func (b *ConsistentHash) find(u *url.URL) (int, *hashServer) {
+	for i, server := range b.servers {
+		if sameURL(server.url, u) {
+			return i, server
+		}
+	}
+	return -1, nil
+}
+
+// buildRing places the servers on the ring, each one at points derived from its URL only,
+// so that the points of the other servers don't move when a server is added or removed.
+func (b *ConsistentHash) buildRing() {
+	b.ring = nil
+	b.totalWeight = 0
+	for _, server := range b.servers {
+		b.totalWeight += server.weight
+		for i := 0; i < server.weight*hashReplicas; i++ {
+			b.ring = append(b.ring, ringPoint{hash: hash(server.url.String() + "#" + strconv.Itoa(i)), server: server})
+		}
+	}
+	sort.Slice(b.ring, func(i, j int) bool { return b.ring[i].hash < b.ring[j].hash })
+}
+
+func hash(key string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+
+	// FNV spreads close keys (e.g. the points of a server) poorly, mix the bits as in SplitMix64
+	x := h.Sum64()
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
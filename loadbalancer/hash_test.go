@@ -0,0 +1,212 @@
+package loadbalancer
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/containous/traefik/testhelpers"
+	"github.com/containous/traefik/whitelist"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulcand/oxy/roundrobin"
+)
+
+func TestNewHashKeyFunc(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		key           string
+		header        http.Header
+		remoteAddr    string
+		trustedIPs    []string
+		expectedKey   string
+		expectedError bool
+	}{
+		{
+			desc:        "client IP",
+			key:         "client.ip",
+			expectedKey: "10.0.0.1",
+		},
+		{
+			desc:        "client IP forwarded by a trusted proxy",
+			key:         "client.ip",
+			header:      http.Header{"X-Forwarded-For": {"192.168.1.1, 10.0.0.2"}},
+			trustedIPs:  []string{"10.0.0.0/24"},
+			expectedKey: "192.168.1.1",
+		},
+		{
+			desc:        "client IP forwarded by an untrusted proxy",
+			key:         "client.ip",
+			header:      http.Header{"X-Forwarded-For": {"192.168.1.1"}},
+			trustedIPs:  []string{"10.0.1.0/24"},
+			expectedKey: "10.0.0.1",
+		},
+		{
+			desc:        "unparsable remote address",
+			key:         "client.ip",
+			remoteAddr:  "unix",
+			expectedKey: "unix",
+		},
+		{
+			desc:        "path",
+			key:         "request.path",
+			expectedKey: "/images/cat.png",
+		},
+		{
+			desc:        "header",
+			key:         "request.header.X-User",
+			header:      http.Header{"X-User": {"bob"}},
+			expectedKey: "bob",
+		},
+		{
+			desc:        "missing header",
+			key:         "request.header.X-User",
+			expectedKey: "10.0.0.1",
+		},
+		{
+			desc:        "cookie",
+			key:         "request.cookie.session",
+			header:      http.Header{"Cookie": {"session=abc; other=def"}},
+			expectedKey: "abc",
+		},
+		{
+			desc:        "missing cookie",
+			key:         "request.cookie.session",
+			header:      http.Header{"Cookie": {"other=def"}},
+			expectedKey: "10.0.0.1",
+		},
+		{
+			desc:          "missing header name",
+			key:           "request.header.",
+			expectedError: true,
+		},
+		{
+			desc:          "missing cookie name",
+			key:           "request.cookie.",
+			expectedError: true,
+		},
+		{
+			desc:          "unsupported key",
+			key:           "request.host",
+			expectedError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			clientIP, err := whitelist.NewClientIP(test.trustedIPs, false)
+			require.NoError(t, err)
+
+			keyFunc, err := NewHashKeyFunc(test.key, clientIP)
+			if test.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			req := testhelpers.MustNewRequest(http.MethodGet, "http://frontend/images/cat.png", nil)
+			req.RemoteAddr = "10.0.0.1:34567"
+			if test.remoteAddr != "" {
+				req.RemoteAddr = test.remoteAddr
+			}
+			if test.header != nil {
+				req.Header = test.header
+			}
+			assert.Equal(t, test.expectedKey, keyFunc(req))
+		})
+	}
+}
+
+func TestNewConsistentHashLoadFactor(t *testing.T) {
+	lb, err := NewConsistentHash(nil, clientIPKey(&whitelist.ClientIP{}), 0)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultHashLoadFactor, lb.loadFactor)
+
+	_, err = NewConsistentHash(nil, clientIPKey(&whitelist.ClientIP{}), 0.9)
+	assert.Error(t, err)
+}
+
+func TestConsistentHashAffinity(t *testing.T) {
+	next := &recordingHandler{}
+	lb, err := NewConsistentHash(next, func(req *http.Request) string { return req.URL.Path }, 0)
+	require.NoError(t, err)
+	for _, host := range []string{"a", "b", "c"} {
+		require.NoError(t, lb.UpsertServer(testhelpers.MustParseURL("http://"+host)))
+	}
+
+	servers := map[string]string{}
+	for i := 0; i < 100; i++ {
+		path := fmt.Sprintf("/%d", i)
+		for j := 0; j < 3; j++ {
+			lb.ServeHTTP(httptest.NewRecorder(), testhelpers.MustNewRequest(http.MethodGet, "http://frontend"+path, nil))
+			host := next.hosts[len(next.hosts)-1]
+			if j == 0 {
+				servers[path] = host
+			}
+			assert.Equal(t, servers[path], host, "requests of the same key go to the same server")
+		}
+	}
+
+	counts := map[string]int{}
+	for _, host := range servers {
+		counts[host]++
+	}
+	assert.Len(t, counts, 3, "keys are spread over all the servers")
+
+	// only the keys of the removed server are remapped
+	require.NoError(t, lb.RemoveServer(testhelpers.MustParseURL("http://b")))
+	for path, host := range servers {
+		lb.ServeHTTP(httptest.NewRecorder(), testhelpers.MustNewRequest(http.MethodGet, "http://frontend"+path, nil))
+		newHost := next.hosts[len(next.hosts)-1]
+		if host == "b" {
+			assert.NotEqual(t, "b", newHost)
+		} else {
+			assert.Equal(t, host, newHost)
+		}
+	}
+}
+
+func TestConsistentHashBoundedLoad(t *testing.T) {
+	next := newBlockingHandler()
+	lb, err := NewConsistentHash(next, func(req *http.Request) string { return "hot" }, 1.5)
+	require.NoError(t, err)
+	require.NoError(t, lb.UpsertServer(testhelpers.MustParseURL("http://a"), roundrobin.Weight(1)))
+	require.NoError(t, lb.UpsertServer(testhelpers.MustParseURL("http://b"), roundrobin.Weight(1)))
+
+	const outstanding = 10
+	done := make(chan struct{})
+	for i := 0; i < outstanding; i++ {
+		go func() {
+			lb.ServeHTTP(httptest.NewRecorder(), testhelpers.MustNewRequest(http.MethodGet, "http://frontend", nil))
+			done <- struct{}{}
+		}()
+		<-next.calls
+	}
+
+	// the hot key spills over to the other server once its server holds 1.5 times the average load
+	lb.lock.Lock()
+	for _, server := range lb.servers {
+		assert.True(t, server.active <= 8, "%s has %d outstanding requests", server.url, server.active)
+		assert.True(t, server.active >= 2, "%s has %d outstanding requests", server.url, server.active)
+	}
+	lb.lock.Unlock()
+
+	close(next.release)
+	for i := 0; i < outstanding; i++ {
+		<-done
+	}
+	assert.Zero(t, lb.totalActive)
+}
+
+func TestConsistentHashNoServer(t *testing.T) {
+	lb, err := NewConsistentHash(&recordingHandler{}, clientIPKey(&whitelist.ClientIP{}), 0)
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	lb.ServeHTTP(recorder, testhelpers.MustNewRequest(http.MethodGet, "http://frontend", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+}
@@ -0,0 +1,161 @@
+package loadbalancer
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/containous/traefik/log"
+	"github.com/vulcand/oxy/roundrobin"
+)
+
+// LeastConn forwards each request to the server with the fewest outstanding requests, relative to its weight.
+// It implements healthcheck.LoadBalancer, so that unhealthy servers are given no requests.
+type LeastConn struct {
+	next          http.Handler
+	stickySession *roundrobin.StickySession
+	servers       []*leastConnServer
+	// index of the server the search for the least loaded server starts from, so that ties are spread evenly
+	start int
+	lock  sync.Mutex
+}
+
+type leastConnServer struct {
+	url    *url.URL
+	weight int
+	// number of outstanding requests, guarded by the load-balancer lock
+	active int
+}
+
+// NewLeastConn creates a new, empty, load-balancer forwarding the requests to next.
+// The sticky session is optional.
+func NewLeastConn(next http.Handler, stickySession *roundrobin.StickySession) *LeastConn {
+	return &LeastConn{next: next, stickySession: stickySession}
+}
+
+func (b *LeastConn) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	// make a shallow copy of the request before changing its URL
+	newReq := *req
+
+	var server *leastConnServer
+	if b.stickySession != nil {
+		cookieURL, present, err := b.stickySession.GetBackend(&newReq, b.Servers())
+		if err != nil {
+			log.Warnf("Error using server from cookie: %v", err)
+		}
+		if present {
+			server = b.acquire(cookieURL)
+		}
+	}
+
+	if server == nil {
+		server = b.acquireLeastLoaded()
+		if server == nil {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			rw.Write([]byte(http.StatusText(http.StatusServiceUnavailable)))
+			return
+		}
+		if b.stickySession != nil {
+			b.stickySession.StickBackend(server.url, &rw)
+		}
+	}
+	defer b.release(server)
+
+	newReq.URL = server.url
+	b.next.ServeHTTP(rw, &newReq)
+}
+
+// acquire counts a new request on the server with the given URL, if it is still in the load-balancer.
+func (b *LeastConn) acquire(u *url.URL) *leastConnServer {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	_, server := b.find(u)
+	if server != nil {
+		server.active++
+	}
+	return server
+}
+
+// acquireLeastLoaded counts a new request on the server with the fewest outstanding requests per weight.
+func (b *LeastConn) acquireLeastLoaded() *leastConnServer {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if len(b.servers) == 0 {
+		return nil
+	}
+
+	var selected *leastConnServer
+	for i := range b.servers {
+		server := b.servers[(b.start+i)%len(b.servers)]
+		// compare active/weight ratios without divisions
+		if selected == nil || server.active*selected.weight < selected.active*server.weight {
+			selected = server
+		}
+	}
+	b.start = (b.start + 1) % len(b.servers)
+	selected.active++
+	return selected
+}
+
+func (b *LeastConn) release(server *leastConnServer) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	server.active--
+}
+
+// UpsertServer adds a server to the load-balancer, or updates its weight.
+func (b *LeastConn) UpsertServer(u *url.URL, options ...roundrobin.ServerOption) error {
+	weight, err := serverWeight(u, options...)
+	if err != nil {
+		return err
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if _, server := b.find(u); server != nil {
+		server.weight = weight
+		return nil
+	}
+	b.servers = append(b.servers, &leastConnServer{url: u, weight: weight})
+	return nil
+}
+
+// RemoveServer removes a server from the load-balancer.
+// Its outstanding requests are not interrupted.
+func (b *LeastConn) RemoveServer(u *url.URL) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	i, server := b.find(u)
+	if server == nil {
+		return errors.New("server not found")
+	}
+	b.servers = append(b.servers[:i], b.servers[i+1:]...)
+	b.start = 0
+	return nil
+}
+
+// Servers returns the servers of the load-balancer.
+func (b *LeastConn) Servers() []*url.URL {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	var urls []*url.URL
+	for _, server := range b.servers {
+		urls = append(urls, server.url)
+	}
+	return urls
+}
+
+func (b *LeastConn) find(u *url.URL) (int, *leastConnServer) {
+	for i, server := range b.servers {
+		if sameURL(server.url, u) {
+			return i, server
+		}
+	}
+	return -1, nil
+}
@@ -0,0 +1,172 @@
+package loadbalancer
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/containous/traefik/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulcand/oxy/roundrobin"
+)
+
+// blockingHandler records the servers it is called for, and holds the requests until it is released.
+type blockingHandler struct {
+	calls   chan string
+	release chan struct{}
+}
+
+func newBlockingHandler() *blockingHandler {
+	return &blockingHandler{calls: make(chan string, 100), release: make(chan struct{})}
+}
+
+func (h *blockingHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	h.calls <- req.URL.Host
+	<-h.release
+}
+
+func TestLeastConnPicksLeastLoadedServer(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		weights       map[string]int
+		outstanding   int
+		expectedCalls map[string]int
+	}{
+		{
+			desc:          "equal weights",
+			weights:       map[string]int{"a": 1, "b": 1},
+			outstanding:   4,
+			expectedCalls: map[string]int{"a": 2, "b": 2},
+		},
+		{
+			desc:          "weighted",
+			weights:       map[string]int{"a": 3, "b": 1},
+			outstanding:   8,
+			expectedCalls: map[string]int{"a": 6, "b": 2},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			next := newBlockingHandler()
+			lb := NewLeastConn(next, nil)
+			for host, weight := range test.weights {
+				require.NoError(t, lb.UpsertServer(testhelpers.MustParseURL("http://"+host), roundrobin.Weight(weight)))
+			}
+
+			done := make(chan struct{})
+			for i := 0; i < test.outstanding; i++ {
+				go func() {
+					lb.ServeHTTP(httptest.NewRecorder(), testhelpers.MustNewRequest(http.MethodGet, "http://frontend", nil))
+					done <- struct{}{}
+				}()
+				// wait for the request to be outstanding before sending the next one
+				<-next.calls
+			}
+
+			lb.lock.Lock()
+			calls := map[string]int{}
+			for _, server := range lb.servers {
+				calls[server.url.Host] = server.active
+			}
+			lb.lock.Unlock()
+			assert.Equal(t, test.expectedCalls, calls)
+
+			close(next.release)
+			for i := 0; i < test.outstanding; i++ {
+				<-done
+			}
+			for _, server := range lb.servers {
+				assert.Zero(t, server.active)
+			}
+		})
+	}
+}
+
+func TestLeastConnAvoidsBusyServer(t *testing.T) {
+	next := newBlockingHandler()
+	lb := NewLeastConn(next, nil)
+	require.NoError(t, lb.UpsertServer(testhelpers.MustParseURL("http://a")))
+	require.NoError(t, lb.UpsertServer(testhelpers.MustParseURL("http://b")))
+
+	done := make(chan struct{})
+	go func() {
+		lb.ServeHTTP(httptest.NewRecorder(), testhelpers.MustNewRequest(http.MethodGet, "http://frontend", nil))
+		close(done)
+	}()
+	busy := <-next.calls
+
+	// all the following requests go to the idle server, as long as the first one is outstanding
+	idle := &recordingHandler{}
+	lb.next = idle
+	for i := 0; i < 3; i++ {
+		lb.ServeHTTP(httptest.NewRecorder(), testhelpers.MustNewRequest(http.MethodGet, "http://frontend", nil))
+	}
+	assert.Len(t, idle.hosts, 3)
+	for _, host := range idle.hosts {
+		assert.NotEqual(t, busy, host)
+	}
+
+	close(next.release)
+	<-done
+}
+
+type recordingHandler struct {
+	hosts []string
+}
+
+func (h *recordingHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	h.hosts = append(h.hosts, req.URL.Host)
+}
+
+func TestLeastConnStickySession(t *testing.T) {
+	next := &recordingHandler{}
+	lb := NewLeastConn(next, roundrobin.NewStickySession("test"))
+	require.NoError(t, lb.UpsertServer(testhelpers.MustParseURL("http://a")))
+	require.NoError(t, lb.UpsertServer(testhelpers.MustParseURL("http://b")))
+
+	recorder := httptest.NewRecorder()
+	lb.ServeHTTP(recorder, testhelpers.MustNewRequest(http.MethodGet, "http://frontend", nil))
+	cookies := recorder.Result().Cookies()
+	require.Len(t, cookies, 1)
+
+	for i := 0; i < 3; i++ {
+		req := testhelpers.MustNewRequest(http.MethodGet, "http://frontend", nil)
+		req.AddCookie(cookies[0])
+		lb.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	require.Len(t, next.hosts, 4)
+	for _, host := range next.hosts {
+		assert.Equal(t, next.hosts[0], host)
+	}
+
+	// the cookie of a removed server is ignored
+	require.NoError(t, lb.RemoveServer(testhelpers.MustParseURL("http://"+next.hosts[0])))
+	req := testhelpers.MustNewRequest(http.MethodGet, "http://frontend", nil)
+	req.AddCookie(cookies[0])
+	lb.ServeHTTP(httptest.NewRecorder(), req)
+	assert.NotEqual(t, next.hosts[0], next.hosts[4])
+}
+
+func TestLeastConnServers(t *testing.T) {
+	lb := NewLeastConn(&recordingHandler{}, nil)
+
+	recorder := httptest.NewRecorder()
+	lb.ServeHTTP(recorder, testhelpers.MustNewRequest(http.MethodGet, "http://frontend", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+
+	require.NoError(t, lb.UpsertServer(testhelpers.MustParseURL("http://a"), roundrobin.Weight(2)))
+	require.NoError(t, lb.UpsertServer(testhelpers.MustParseURL("http://b")))
+	require.NoError(t, lb.UpsertServer(testhelpers.MustParseURL("http://a"), roundrobin.Weight(5)))
+	assert.Equal(t, []*url.URL{testhelpers.MustParseURL("http://a"), testhelpers.MustParseURL("http://b")}, lb.Servers())
+	assert.Equal(t, 5, lb.servers[0].weight)
+
+	require.NoError(t, lb.RemoveServer(testhelpers.MustParseURL("http://a")))
+	assert.Error(t, lb.RemoveServer(testhelpers.MustParseURL("http://a")))
+	assert.Equal(t, []*url.URL{testhelpers.MustParseURL("http://b")}, lb.Servers())
+}
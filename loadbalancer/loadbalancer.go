@@ -0,0 +1,30 @@
+// Package loadbalancer provides load-balancers, in addition to the round robin ones of oxy,
+// to forward the requests of a frontend to the servers of its backend.
+package loadbalancer
+
+import (
+	"net/url"
+
+	"github.com/vulcand/oxy/roundrobin"
+)
+
+// serverWeight returns the weight set by the options of a server, which defaults to 1.
+// The options of oxy can only be applied to its own load-balancer.
+func serverWeight(u *url.URL, options ...roundrobin.ServerOption) (int, error) {
+	rr, err := roundrobin.New(nil)
+	if err != nil {
+		return 0, err
+	}
+	if err := rr.UpsertServer(u, options...); err != nil {
+		return 0, err
+	}
+	weight, _ := rr.ServerWeight(u)
+	if weight < 1 {
+		weight = 1
+	}
+	return weight, nil
+}
+
+func sameURL(a, b *url.URL) bool {
+	return a.Path == b.Path && a.Host == b.Host && a.Scheme == b.Scheme
+}
@@ -0,0 +1,271 @@
+package loadbalancer
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/containous/traefik/log"
+	"github.com/vulcand/oxy/forward"
+	"github.com/vulcand/oxy/roundrobin"
+)
+
+const (
+	// time after which a latency observation only weighs 1/e in the moving average
+	p2cDecay = 10 * time.Second
+	// latency observed for a request the forwarder failed to get a response for,
+	// so that a server failing fast doesn't look fast
+	p2cFailurePenalty = time.Second
+)
+
+type observationKey struct{}
+
+// observation holds the latency of a request forwarded by the P2C load-balancer to a server.
+// A hedged copy of the request, sent to another server, shares the observation:
+// only the latency of the server picked by the load-balancer is recorded.
+type observation struct {
+	server *url.URL
+	start  time.Time
+	lock   sync.Mutex
+	// latency is the time to the response headers of the server
+	latency time.Duration
+	// answered is set once any server, e.g. the one of a hedged request, sent its response headers
+	answered bool
+}
+
+// ObserveResponse is a response modifier of the forwarder feeding the P2C load-balancer:
+// it records the time to the response headers of the requests forwarded by a P2C load-balancer.
+func ObserveResponse(res *http.Response) error {
+	if res.Request == nil {
+		return nil
+	}
+	if obs, ok := res.Request.Context().Value(observationKey{}).(*observation); ok {
+		obs.observe(res.Request.URL)
+	}
+	return nil
+}
+
+func (o *observation) observe(u *url.URL) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	o.answered = true
+	if o.latency == 0 && u.Host == o.server.Host && u.Scheme == o.server.Scheme {
+		o.latency = time.Since(o.start)
+	}
+}
+
+// result returns the latency to record for the server, which is penalized if no server answered.
+func (o *observation) result(now time.Time) time.Duration {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	if o.latency != 0 {
+		return o.latency
+	}
+	latency := now.Sub(o.start)
+	// no response was received: the server is only penalized if no other server answered,
+	// otherwise it was just slower than the server of the hedged request, and was cancelled
+	if !o.answered && latency < p2cFailurePenalty {
+		latency = p2cFailurePenalty
+	}
+	return latency
+}
+
+// P2C picks the best of two random servers, the one with the lowest cost.
+// The cost of a server is the peak exponentially weighted moving average of its latency,
+// multiplied by its number of in-flight requests, relative to its weight.
+// A peak moving average jumps to the latency of a slow request, so that a degrading server is avoided at once,
+// and decays slowly back to the average once the server recovers.
+type P2C struct {
+	next          http.Handler
+	stickySession *roundrobin.StickySession
+	servers       []*p2cServer
+	rand          *rand.Rand
+	lock          sync.Mutex
+}
+
+type p2cServer struct {
+	url      *url.URL
+	weight   int
+	inflight int
+	// moving average of the latency, in nanoseconds
+	ewma       float64
+	lastUpdate time.Time
+}
+
+// NewP2C creates a new, empty, load-balancer forwarding the requests to next.
+// The latencies are observed by the forwarder with ObserveResponse.
+// The sticky session is optional.
+func NewP2C(next http.Handler, stickySession *roundrobin.StickySession) *P2C {
+	return &P2C{
+		next:          next,
+		stickySession: stickySession,
+		rand:          rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (b *P2C) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	var server *p2cServer
+	if b.stickySession != nil {
+		cookieURL, present, err := b.stickySession.GetBackend(req, b.Servers())
+		if err != nil {
+			log.Warnf("Error using server from cookie: %v", err)
+		}
+		if present {
+			server = b.acquire(cookieURL)
+		}
+	}
+
+	if server == nil {
+		server = b.acquireBestOfTwo()
+		if server == nil {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			rw.Write([]byte(http.StatusText(http.StatusServiceUnavailable)))
+			return
+		}
+		if b.stickySession != nil {
+			b.stickySession.StickBackend(server.url, &rw)
+		}
+	}
+
+	// make a shallow copy of the request before changing its URL
+	newReq := *req
+	var obs *observation
+	// the duration of a websocket is not a latency
+	if !forward.IsWebsocketRequest(req) {
+		obs = &observation{server: server.url, start: time.Now()}
+		newReq = *req.WithContext(context.WithValue(req.Context(), observationKey{}, obs))
+	}
+	defer b.release(server, obs)
+
+	newReq.URL = server.url
+	b.next.ServeHTTP(rw, &newReq)
+}
+
+// acquire counts a new request on the server with the given URL, if it is still in the load-balancer.
+func (b *P2C) acquire(u *url.URL) *p2cServer {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	server := b.find(u)
+	if server != nil {
+		server.inflight++
+	}
+	return server
+}
+
+// acquireBestOfTwo counts a new request on the cheapest of two random servers.
+func (b *P2C) acquireBestOfTwo() *p2cServer {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	var server *p2cServer
+	switch len(b.servers) {
+	case 0:
+		return nil
+	case 1:
+		server = b.servers[0]
+	default:
+		i := b.rand.Intn(len(b.servers))
+		j := b.rand.Intn(len(b.servers) - 1)
+		if j >= i {
+			j++
+		}
+		server = b.servers[i]
+		if b.servers[j].cost() < server.cost() {
+			server = b.servers[j]
+		}
+	}
+	server.inflight++
+	return server
+}
+
+// release uncounts a request of a server, and records its latency.
+func (b *P2C) release(server *p2cServer, obs *observation) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	server.inflight--
+	if obs == nil {
+		return
+	}
+
+	now := time.Now()
+	server.observe(obs.result(now), now)
+}
+
+func (s *p2cServer) observe(latency time.Duration, now time.Time) {
+	rtt := float64(latency)
+	if rtt > s.ewma {
+		s.ewma = rtt
+	} else {
+		w := math.Exp(-float64(now.Sub(s.lastUpdate)) / float64(p2cDecay))
+		s.ewma = s.ewma*w + rtt*(1-w)
+	}
+	s.lastUpdate = now
+}
+
+func (s *p2cServer) cost() float64 {
+	// servers without observations cost their in-flight requests only
+	return (s.ewma + 1) * float64(s.inflight+1) / float64(s.weight)
+}
+
+// UpsertServer adds a server to the load-balancer, or updates its weight.
+func (b *P2C) UpsertServer(u *url.URL, options ...roundrobin.ServerOption) error {
+	weight, err := serverWeight(u, options...)
+	if err != nil {
+		return err
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if server := b.find(u); server != nil {
+		server.weight = weight
+		return nil
+	}
+	b.servers = append(b.servers, &p2cServer{url: u, weight: weight})
+	return nil
+}
+
+// RemoveServer removes a server from the load-balancer.
+// Its in-flight requests are not interrupted.
+func (b *P2C) RemoveServer(u *url.URL) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	for i, server := range b.servers {
+		if sameURL(server.url, u) {
+			b.servers = append(b.servers[:i], b.servers[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New("server not found")
+}
+
+// Servers returns the servers of the load-balancer.
+func (b *P2C) Servers() []*url.URL {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	var urls []*url.URL
+	for _, server := range b.servers {
+		urls = append(urls, server.url)
+	}
+	return urls
+}
+
+func (b *P2C) find(u *url.URL) *p2cServer {
+	for _, server := range b.servers {
+		if sameURL(server.url, u) {
+			return server
+		}
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+package loadbalancer
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/containous/traefik/middlewares"
+	"github.com/containous/traefik/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulcand/oxy/forward"
+	"github.com/vulcand/oxy/roundrobin"
+)
+
+func TestP2CAvoidsSlowServer(t *testing.T) {
+	var fastCount, slowCount int
+	fast := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		fastCount++
+	}))
+	defer fast.Close()
+	slow := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		slowCount++
+		time.Sleep(50 * time.Millisecond)
+	}))
+	defer slow.Close()
+
+	fwd, err := forward.New(forward.ResponseModifier(ObserveResponse))
+	require.NoError(t, err)
+	lb := NewP2C(fwd, nil)
+	require.NoError(t, lb.UpsertServer(testhelpers.MustParseURL(fast.URL)))
+	require.NoError(t, lb.UpsertServer(testhelpers.MustParseURL(slow.URL)))
+
+	for i := 0; i < 20; i++ {
+		recorder := httptest.NewRecorder()
+		lb.ServeHTTP(recorder, testhelpers.MustNewRequest(http.MethodGet, "http://frontend", nil))
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	}
+
+	// the slow server is only tried until its latency is known
+	assert.Equal(t, 1, slowCount)
+	assert.Equal(t, 19, fastCount)
+}
+
+func TestP2CPenalizesFailingServer(t *testing.T) {
+	var count int
+	healthy := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		count++
+		time.Sleep(10 * time.Millisecond)
+	}))
+	defer healthy.Close()
+	failing := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}))
+	failing.Close()
+
+	fwd, err := forward.New(forward.ResponseModifier(ObserveResponse))
+	require.NoError(t, err)
+	lb := NewP2C(fwd, nil)
+	require.NoError(t, lb.UpsertServer(testhelpers.MustParseURL(healthy.URL)))
+	require.NoError(t, lb.UpsertServer(testhelpers.MustParseURL(failing.URL)))
+
+	for i := 0; i < 10; i++ {
+		lb.ServeHTTP(httptest.NewRecorder(), testhelpers.MustNewRequest(http.MethodGet, "http://frontend", nil))
+	}
+
+	// a refused connection fails fast, but it must not make the failing server look fast
+	assert.True(t, count >= 9, "healthy server got %d requests", count)
+}
+
+func TestP2CWithHedgingLatency(t *testing.T) {
+	// both servers answer, the hedged request first, with 5xx responses so that no attempt is cancelled
+	primary := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		time.Sleep(40 * time.Millisecond)
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer primary.Close()
+	hedged := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer hedged.Close()
+
+	fwd, err := forward.New(forward.ResponseModifier(ObserveResponse))
+	require.NoError(t, err)
+	hedging := middlewares.NewHedging(middlewares.HedgingPolicy{Delay: 10 * time.Millisecond}, fwd, nil)
+	hedging.LB = staticServers{testhelpers.MustParseURL(primary.URL), testhelpers.MustParseURL(hedged.URL)}
+	lb := NewP2C(hedging, nil)
+	require.NoError(t, lb.UpsertServer(testhelpers.MustParseURL(primary.URL)))
+
+	recorder := httptest.NewRecorder()
+	lb.ServeHTTP(recorder, testhelpers.MustNewRequest(http.MethodGet, "http://frontend", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+
+	// the latency of the hedged request isn't charged to the server picked by the load-balancer
+	require.Len(t, lb.servers, 1)
+	assert.True(t, lb.servers[0].ewma >= float64(40*time.Millisecond), "got latency %s", time.Duration(lb.servers[0].ewma))
+	assert.True(t, lb.servers[0].ewma < float64(p2cFailurePenalty), "got latency %s", time.Duration(lb.servers[0].ewma))
+}
+
+func TestP2CWithHedgingConcurrentResponses(t *testing.T) {
+	// both servers answer at the same time, once both attempts reached them
+	arrived := make(chan struct{}, 2)
+	handler := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		arrived <- struct{}{}
+		for len(arrived) < 2 {
+			time.Sleep(time.Millisecond)
+		}
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	})
+	primary := httptest.NewServer(handler)
+	defer primary.Close()
+	hedged := httptest.NewServer(handler)
+	defer hedged.Close()
+
+	// without keep-alive, the attempts share no connection pool synchronizing their responses
+	fwd, err := forward.New(forward.ResponseModifier(ObserveResponse), forward.RoundTripper(&http.Transport{DisableKeepAlives: true}))
+	require.NoError(t, err)
+	hedging := middlewares.NewHedging(middlewares.HedgingPolicy{Delay: time.Millisecond}, fwd, nil)
+	hedging.LB = staticServers{testhelpers.MustParseURL(primary.URL), testhelpers.MustParseURL(hedged.URL)}
+	lb := NewP2C(hedging, nil)
+	require.NoError(t, lb.UpsertServer(testhelpers.MustParseURL(primary.URL)))
+
+	for i := 0; i < 50; i++ {
+		recorder := httptest.NewRecorder()
+		lb.ServeHTTP(recorder, testhelpers.MustNewRequest(http.MethodGet, "http://frontend", nil))
+		assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+		<-arrived
+		<-arrived
+	}
+}
+
+func TestObservationOfCancelledServer(t *testing.T) {
+	server := testhelpers.MustParseURL("http://primary")
+	start := time.Now()
+
+	obs := &observation{server: server, start: start}
+	assert.Equal(t, p2cFailurePenalty, obs.result(start.Add(50*time.Millisecond)))
+
+	// the server picked by the load-balancer was cancelled once the server of the hedged request answered
+	obs.observe(testhelpers.MustParseURL("http://hedged/path"))
+	assert.Equal(t, 50*time.Millisecond, obs.result(start.Add(50*time.Millisecond)))
+}
+
+type staticServers []*url.URL
+
+func (s staticServers) Servers() []*url.URL {
+	return s
+}
+
+func TestP2CPeakEWMA(t *testing.T) {
+	now := time.Now()
+	server := &p2cServer{weight: 1}
+
+	server.observe(100*time.Millisecond, now)
+	assert.Equal(t, float64(100*time.Millisecond), server.ewma)
+
+	// a slower response is taken into account at once
+	server.observe(time.Second, now.Add(time.Millisecond))
+	assert.Equal(t, float64(time.Second), server.ewma)
+
+	// faster responses only lower the average progressively
+	server.observe(100*time.Millisecond, now.Add(time.Millisecond+p2cDecay))
+	assert.InDelta(t, float64(100*time.Millisecond)+float64(900*time.Millisecond)/2.718281828, server.ewma, float64(time.Millisecond))
+}
+
+func TestP2CCost(t *testing.T) {
+	idle := &p2cServer{weight: 1, ewma: float64(10 * time.Millisecond)}
+	busy := &p2cServer{weight: 1, ewma: float64(10 * time.Millisecond), inflight: 3}
+	heavy := &p2cServer{weight: 4, ewma: float64(10 * time.Millisecond), inflight: 3}
+
+	assert.True(t, idle.cost() < busy.cost())
+	assert.True(t, heavy.cost() < busy.cost())
+	assert.True(t, (&p2cServer{weight: 1}).cost() < (&p2cServer{weight: 1, inflight: 1}).cost())
+}
+
+func TestP2CStickySession(t *testing.T) {
+	next := &recordingHandler{}
+	lb := NewP2C(next, roundrobin.NewStickySession("test"))
+	require.NoError(t, lb.UpsertServer(testhelpers.MustParseURL("http://a")))
+	require.NoError(t, lb.UpsertServer(testhelpers.MustParseURL("http://b")))
+
+	recorder := httptest.NewRecorder()
+	lb.ServeHTTP(recorder, testhelpers.MustNewRequest(http.MethodGet, "http://frontend", nil))
+	cookies := recorder.Result().Cookies()
+	require.Len(t, cookies, 1)
+
+	for i := 0; i < 5; i++ {
+		req := testhelpers.MustNewRequest(http.MethodGet, "http://frontend", nil)
+		req.AddCookie(cookies[0])
+		lb.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	require.Len(t, next.hosts, 6)
+	for _, host := range next.hosts {
+		assert.Equal(t, next.hosts[0], host)
+	}
+}
+
+func TestP2CServers(t *testing.T) {
+	lb := NewP2C(&recordingHandler{}, nil)
+
+	recorder := httptest.NewRecorder()
+	lb.ServeHTTP(recorder, testhelpers.MustNewRequest(http.MethodGet, "http://frontend", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+
+	require.NoError(t, lb.UpsertServer(testhelpers.MustParseURL("http://a"), roundrobin.Weight(2)))
+	require.NoError(t, lb.UpsertServer(testhelpers.MustParseURL("http://b")))
+	require.NoError(t, lb.UpsertServer(testhelpers.MustParseURL("http://a"), roundrobin.Weight(5)))
+	assert.Equal(t, []*url.URL{testhelpers.MustParseURL("http://a"), testhelpers.MustParseURL("http://b")}, lb.Servers())
+	assert.Equal(t, 5, lb.servers[0].weight)
+
+	require.NoError(t, lb.RemoveServer(testhelpers.MustParseURL("http://a")))
+	assert.Error(t, lb.RemoveServer(testhelpers.MustParseURL("http://a")))
+	assert.Equal(t, []*url.URL{testhelpers.MustParseURL("http://b")}, lb.Servers())
+}
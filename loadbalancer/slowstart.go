@@ -0,0 +1,224 @@
+package loadbalancer
+
+import (
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/containous/traefik/healthcheck"
+	"github.com/containous/traefik/safe"
+	"github.com/vulcand/oxy/roundrobin"
+)
+
+// slowStartScale is the factor applied to the weights of the servers of a load-balancer with a slow start,
+// so that the weight of a server of weight 1 can be ramped up too.
+const slowStartScale = 10
+
+// minRampInterval is the minimum interval between the updates of the weights of the servers being ramped up.
+const minRampInterval = 10 * time.Millisecond
+
+// WarmUps records when the servers of the backends were started, across the reloads of the configuration,
+// so that the servers which were already receiving requests before a reload are not slowly started again.
+type WarmUps struct {
+	starts map[string]time.Time
+	lock   sync.Mutex
+}
+
+// NewWarmUps creates a new, empty, WarmUps.
+func NewWarmUps() *WarmUps {
+	return &WarmUps{starts: make(map[string]time.Time)}
+}
+
+// start returns when the server with the given key was started, which is now if it is a new server.
+func (w *WarmUps) start(key string, now time.Time) time.Time {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	start, ok := w.starts[key]
+	if !ok {
+		start = now
+		w.starts[key] = start
+	}
+	return start
+}
+
+// stop forgets the server with the given key, which is started again once it is added back.
+func (w *WarmUps) stop(key string) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	delete(w.starts, key)
+}
+
+// Retain forgets the servers which are not in the given servers of the backends, by backend name,
+// so that the servers removed from the configuration are not recorded forever.
+func (w *WarmUps) Retain(servers map[string][]*url.URL) {
+	keys := make(map[string]bool)
+	for backend, urls := range servers {
+		for _, u := range urls {
+			keys[backend+u.String()] = true
+		}
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	for key := range w.starts {
+		if !keys[key] {
+			delete(w.starts, key)
+		}
+	}
+}
+
+// SlowStart wraps the load-balancer of a backend to keep track of the configured weights of its servers,
+// so that a server returning from a health check failure gets its weight back.
+// With a slow start window, the weight of the servers added to the load-balancer, new or returning from a health check failure,
+// is ramped up linearly over the window, so that they are not flooded with requests while warming up.
+type SlowStart struct {
+	lb      healthcheck.LoadBalancer
+	backend string
+	window  time.Duration
+	warmUps *WarmUps
+	// configured weights of the servers, by URL
+	weights map[string]int
+	// start of the servers being ramped up, by URL
+	ramps   map[string]time.Time
+	ramping bool
+	lock    sync.Mutex
+}
+
+// NewSlowStart creates a new SlowStart for the load-balancer of a backend.
+// The servers are not slowly started if the window is not positive.
+func NewSlowStart(lb healthcheck.LoadBalancer, backend string, window time.Duration, warmUps *WarmUps) *SlowStart {
+	return &SlowStart{
+		lb:      lb,
+		backend: backend,
+		window:  window,
+		warmUps: warmUps,
+		weights: make(map[string]int),
+		ramps:   make(map[string]time.Time),
+	}
+}
+
+// UpsertServer adds a server to the load-balancer, or updates its weight.
+// A server added to the load-balancer starts with a fraction of its weight, if it is not yet warmed up.
+func (s *SlowStart) UpsertServer(u *url.URL, options ...roundrobin.ServerOption) error {
+	weight, err := serverWeight(u, options...)
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.weights[u.String()] = weight
+	if s.window <= 0 {
+		return s.lb.UpsertServer(u, options...)
+	}
+
+	if _, ok := s.ramps[u.String()]; !ok && !s.contains(u) {
+		now := time.Now()
+		start := s.warmUps.start(s.backend+u.String(), now)
+		if now.Sub(start) < s.window {
+			s.ramps[u.String()] = start
+			s.startRamping()
+		}
+	}
+
+	if start, ok := s.ramps[u.String()]; ok {
+		return s.lb.UpsertServer(u, roundrobin.Weight(rampWeight(weight, time.Since(start), s.window)))
+	}
+	return s.lb.UpsertServer(u, roundrobin.Weight(weight*slowStartScale))
+}
+
+// RemoveServer removes a server from the load-balancer, which is slowly started again once it is added back.
+func (s *SlowStart) RemoveServer(u *url.URL) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.window > 0 {
+		delete(s.ramps, u.String())
+		s.warmUps.stop(s.backend + u.String())
+	}
+	return s.lb.RemoveServer(u)
+}
+
+// Servers returns the servers of the load-balancer.
+func (s *SlowStart) Servers() []*url.URL {
+	return s.lb.Servers()
+}
+
+// ServerWeight returns the configured weight of a server, even if it was removed from the load-balancer.
+func (s *SlowStart) ServerWeight(u *url.URL) (int, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	weight, ok := s.weights[u.String()]
+	return weight, ok
+}
+
+func (s *SlowStart) contains(u *url.URL) bool {
+	for _, server := range s.lb.Servers() {
+		if sameURL(server, u) {
+			return true
+		}
+	}
+	return false
+}
+
+// startRamping starts ramping up the weights of the servers, unless it is already running.
+func (s *SlowStart) startRamping() {
+	if s.ramping {
+		return
+	}
+	s.ramping = true
+
+	safe.Go(func() {
+		interval := s.window / slowStartScale
+		if interval < minRampInterval {
+			interval = minRampInterval
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for now := range ticker.C {
+			if !s.ramp(now) {
+				return
+			}
+		}
+	})
+}
+
+// ramp updates the weights of the servers being ramped up, and returns whether some servers are still being ramped up.
+func (s *SlowStart) ramp(now time.Time) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for rawURL, start := range s.ramps {
+		elapsed := now.Sub(start)
+		if elapsed >= s.window {
+			delete(s.ramps, rawURL)
+		}
+
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			continue
+		}
+		s.lb.UpsertServer(u, roundrobin.Weight(rampWeight(s.weights[rawURL], elapsed, s.window)))
+	}
+
+	s.ramping = len(s.ramps) > 0
+	return s.ramping
+}
+
+// rampWeight returns the scaled weight of a server started elapsed ago, which increases linearly over the window.
+func rampWeight(weight int, elapsed time.Duration, window time.Duration) int {
+	if elapsed >= window {
+		return weight * slowStartScale
+	}
+
+	ramped := int(int64(weight*slowStartScale) * int64(elapsed) / int64(window))
+	if ramped < 1 {
+		return 1
+	}
+	return ramped
+}
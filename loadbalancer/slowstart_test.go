@@ -0,0 +1,163 @@
+package loadbalancer
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/containous/traefik/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulcand/oxy/roundrobin"
+)
+
+func TestRampWeight(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		weight   int
+		elapsed  time.Duration
+		expected int
+	}{
+		{
+			desc:     "just started",
+			weight:   3,
+			elapsed:  0,
+			expected: 1,
+		},
+		{
+			desc:     "half way",
+			weight:   3,
+			elapsed:  5 * time.Second,
+			expected: 15,
+		},
+		{
+			desc:     "window elapsed",
+			weight:   3,
+			elapsed:  10 * time.Second,
+			expected: 30,
+		},
+		{
+			desc:     "after the window",
+			weight:   1,
+			elapsed:  time.Minute,
+			expected: 10,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, rampWeight(test.weight, test.elapsed, 10*time.Second))
+		})
+	}
+}
+
+func TestSlowStartRampsUpNewServers(t *testing.T) {
+	rr, err := roundrobin.New(http.NotFoundHandler())
+	require.NoError(t, err)
+	warmUps := NewWarmUps()
+	slowStart := NewSlowStart(rr, "backend", time.Hour, warmUps)
+
+	server := testhelpers.MustParseURL("http://a")
+	require.NoError(t, slowStart.UpsertServer(server, roundrobin.Weight(5)))
+
+	weight, ok := rr.ServerWeight(server)
+	require.True(t, ok)
+	assert.Equal(t, 1, weight)
+
+	start := warmUps.start("backend"+server.String(), time.Now())
+	assert.True(t, slowStart.ramp(start.Add(30*time.Minute)))
+	weight, _ = rr.ServerWeight(server)
+	assert.Equal(t, 25, weight)
+
+	assert.False(t, slowStart.ramp(start.Add(time.Hour)))
+	weight, _ = rr.ServerWeight(server)
+	assert.Equal(t, 50, weight)
+
+	configured, ok := slowStart.ServerWeight(server)
+	require.True(t, ok)
+	assert.Equal(t, 5, configured)
+
+	// a server removed by the health check is slowly started again
+	require.NoError(t, slowStart.RemoveServer(server))
+	configured, ok = slowStart.ServerWeight(server)
+	require.True(t, ok)
+	assert.Equal(t, 5, configured)
+
+	require.NoError(t, slowStart.UpsertServer(server, roundrobin.Weight(configured)))
+	weight, _ = rr.ServerWeight(server)
+	assert.Equal(t, 1, weight)
+}
+
+func TestSlowStartKeepsWarmServersOnReload(t *testing.T) {
+	warmUps := NewWarmUps()
+	server := testhelpers.MustParseURL("http://a")
+	warmUps.start("backend"+server.String(), time.Now().Add(-time.Hour))
+
+	rr, err := roundrobin.New(http.NotFoundHandler())
+	require.NoError(t, err)
+	slowStart := NewSlowStart(rr, "backend", time.Minute, warmUps)
+	require.NoError(t, slowStart.UpsertServer(server, roundrobin.Weight(2)))
+
+	weight, ok := rr.ServerWeight(server)
+	require.True(t, ok)
+	assert.Equal(t, 20, weight)
+}
+
+func TestSlowStartWithTinyWindow(t *testing.T) {
+	rr, err := roundrobin.New(http.NotFoundHandler())
+	require.NoError(t, err)
+	slowStart := NewSlowStart(rr, "backend", time.Nanosecond, NewWarmUps())
+
+	server := testhelpers.MustParseURL("http://a")
+	require.NoError(t, slowStart.UpsertServer(server, roundrobin.Weight(2)))
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		weight, _ := rr.ServerWeight(server)
+		if weight == 20 {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("got weight %d, want 20", weight)
+		}
+		time.Sleep(minRampInterval)
+	}
+}
+
+func TestWarmUpsRetain(t *testing.T) {
+	warmUps := NewWarmUps()
+	kept := testhelpers.MustParseURL("http://a")
+	removed := testhelpers.MustParseURL("http://b")
+	now := time.Now()
+	warmUps.start("backend"+kept.String(), now.Add(-time.Hour))
+	warmUps.start("backend"+removed.String(), now.Add(-time.Hour))
+	warmUps.start("other"+kept.String(), now.Add(-time.Hour))
+
+	warmUps.Retain(map[string][]*url.URL{"backend": {kept}})
+
+	assert.Len(t, warmUps.starts, 1)
+	assert.Equal(t, now.Add(-time.Hour), warmUps.start("backend"+kept.String(), now))
+	assert.Equal(t, now, warmUps.start("backend"+removed.String(), now))
+}
+
+func TestSlowStartWithoutWindow(t *testing.T) {
+	rr, err := roundrobin.New(http.NotFoundHandler())
+	require.NoError(t, err)
+	slowStart := NewSlowStart(rr, "backend", 0, NewWarmUps())
+
+	server := testhelpers.MustParseURL("http://a")
+	require.NoError(t, slowStart.UpsertServer(server, roundrobin.Weight(3)))
+	weight, ok := rr.ServerWeight(server)
+	require.True(t, ok)
+	assert.Equal(t, 3, weight)
+
+	require.NoError(t, slowStart.RemoveServer(server))
+	configured, ok := slowStart.ServerWeight(server)
+	require.True(t, ok)
+	assert.Equal(t, 3, configured)
+	assert.Empty(t, rr.Servers())
+}
@@ -0,0 +1,223 @@
+package loadbalancer
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/containous/traefik/healthcheck"
+	"github.com/containous/traefik/server/cookie"
+	"github.com/vulcand/oxy/roundrobin"
+)
+
+// StickyLoadBalancer is a load-balancer with sticky sessions, storing the URL of the server in a cookie.
+type StickyLoadBalancer interface {
+	http.Handler
+	healthcheck.LoadBalancer
+}
+
+// StickyCookie hides the URLs of the servers stored in the sticky session cookie of a load-balancer:
+// the cookies sent to the clients hold an opaque value and the configured attributes,
+// and the values of the cookies sent by the clients are translated back to URLs before reaching the load-balancer.
+// The servers must be added and removed through the StickyCookie, which keeps the opaque values of their cookies.
+type StickyCookie struct {
+	lb      StickyLoadBalancer
+	name    string
+	options cookie.Options
+	// URLs of the servers, by opaque cookie value
+	servers map[string]string
+	// opaque cookie values of the servers, by URL
+	values map[string]string
+	lock   sync.RWMutex
+}
+
+// NewStickyCookie creates a new StickyCookie for the cookie with the given name, set by lb.
+func NewStickyCookie(lb StickyLoadBalancer, name string, options cookie.Options) *StickyCookie {
+	s := &StickyCookie{
+		lb:      lb,
+		name:    name,
+		options: options,
+		servers: make(map[string]string),
+		values:  make(map[string]string),
+	}
+	for _, u := range lb.Servers() {
+		s.addValue(u.String())
+	}
+	return s
+}
+
+// UpsertServer adds a server to the load-balancer, or updates it.
+func (s *StickyCookie) UpsertServer(u *url.URL, options ...roundrobin.ServerOption) error {
+	if err := s.lb.UpsertServer(u, options...); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.addValue(u.String())
+	return nil
+}
+
+// RemoveServer removes a server from the load-balancer, the cookies holding its value no longer match any server.
+func (s *StickyCookie) RemoveServer(u *url.URL) error {
+	if err := s.lb.RemoveServer(u); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if value, ok := s.values[u.String()]; ok {
+		delete(s.servers, value)
+		delete(s.values, u.String())
+	}
+	return nil
+}
+
+// Servers returns the servers of the load-balancer.
+func (s *StickyCookie) Servers() []*url.URL {
+	return s.lb.Servers()
+}
+
+func (s *StickyCookie) addValue(serverURL string) {
+	if _, ok := s.values[serverURL]; ok {
+		return
+	}
+	value := s.options.Value(serverURL)
+	s.servers[value] = serverURL
+	s.values[serverURL] = value
+}
+
+// value returns the opaque value of the cookie of a server.
+func (s *StickyCookie) value(serverURL string) string {
+	s.lock.RLock()
+	value, ok := s.values[serverURL]
+	s.lock.RUnlock()
+	if ok {
+		return value
+	}
+	return s.options.Value(serverURL)
+}
+
+func (s *StickyCookie) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if c, err := req.Cookie(s.name); err == nil {
+		req = s.translateRequest(req, c.Value)
+	}
+	stickyRW := newStickyResponseWriter(rw, s)
+	s.lb.ServeHTTP(stickyRW, req)
+	// the response headers are sent once the handler returns, when nothing was written
+	stickyRW.translate()
+}
+
+// translateRequest returns a copy of the request where the opaque value of the sticky cookie is replaced by the URL of its server.
+// The cookie is removed if it doesn't match any server, so that a client can't choose a server by its URL.
+func (s *StickyCookie) translateRequest(req *http.Request, value string) *http.Request {
+	s.lock.RLock()
+	serverURL := s.servers[value]
+	s.lock.RUnlock()
+
+	var cookies []string
+	for _, c := range req.Cookies() {
+		if c.Name == s.name {
+			if len(serverURL) == 0 {
+				continue
+			}
+			c.Value = serverURL
+		}
+		cookies = append(cookies, c.Name+"="+c.Value)
+	}
+
+	// make a shallow copy of the request before changing its headers
+	newReq := *req
+	newReq.Header = make(http.Header, len(req.Header))
+	for key, values := range req.Header {
+		newReq.Header[key] = values
+	}
+	newReq.Header.Del("Cookie")
+	if len(cookies) > 0 {
+		newReq.Header.Set("Cookie", strings.Join(cookies, "; "))
+	}
+	return &newReq
+}
+
+// translateResponse replaces the sticky cookie set by the load-balancer, holding the URL of the server,
+// by a cookie holding its opaque value.
+func (s *StickyCookie) translateResponse(header http.Header) {
+	setCookies := header["Set-Cookie"]
+	for i, line := range setCookies {
+		cookies := (&http.Response{Header: http.Header{"Set-Cookie": {line}}}).Cookies()
+		if len(cookies) != 1 || cookies[0].Name != s.name {
+			continue
+		}
+		setCookies[i] = s.options.String(s.name, s.value(cookies[0].Value))
+	}
+}
+
+type stickyResponseWriter interface {
+	http.ResponseWriter
+	http.Flusher
+	http.Hijacker
+	translate()
+}
+
+func newStickyResponseWriter(rw http.ResponseWriter, sticky *StickyCookie) stickyResponseWriter {
+	responseWriter := &stickyResponseWriterWithoutCloseNotify{
+		responseWriter: rw,
+		sticky:         sticky,
+	}
+	if _, ok := rw.(http.CloseNotifier); ok {
+		return &stickyResponseWriterWithCloseNotify{responseWriter}
+	}
+	return responseWriter
+}
+
+type stickyResponseWriterWithoutCloseNotify struct {
+	responseWriter http.ResponseWriter
+	sticky         *StickyCookie
+	translated     bool
+}
+
+func (w *stickyResponseWriterWithoutCloseNotify) translate() {
+	if !w.translated {
+		w.translated = true
+		w.sticky.translateResponse(w.responseWriter.Header())
+	}
+}
+
+func (w *stickyResponseWriterWithoutCloseNotify) Header() http.Header {
+	return w.responseWriter.Header()
+}
+
+func (w *stickyResponseWriterWithoutCloseNotify) Write(buf []byte) (int, error) {
+	w.translate()
+	return w.responseWriter.Write(buf)
+}
+
+func (w *stickyResponseWriterWithoutCloseNotify) WriteHeader(code int) {
+	w.translate()
+	w.responseWriter.WriteHeader(code)
+}
+
+func (w *stickyResponseWriterWithoutCloseNotify) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.translate()
+	return w.responseWriter.(http.Hijacker).Hijack()
+}
+
+func (w *stickyResponseWriterWithoutCloseNotify) Flush() {
+	w.translate()
+	if flusher, ok := w.responseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+type stickyResponseWriterWithCloseNotify struct {
+	*stickyResponseWriterWithoutCloseNotify
+}
+
+func (w *stickyResponseWriterWithCloseNotify) CloseNotify() <-chan bool {
+	return w.responseWriter.(http.CloseNotifier).CloseNotify()
+}
@@ -0,0 +1,76 @@
+package loadbalancer
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/containous/traefik/server/cookie"
+	"github.com/containous/traefik/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulcand/oxy/roundrobin"
+)
+
+func TestStickyCookie(t *testing.T) {
+	next := &recordingHandler{}
+	rr, err := roundrobin.New(next, roundrobin.EnableStickySession(roundrobin.NewStickySession("test")))
+	require.NoError(t, err)
+
+	options := cookie.Options{
+		Secret:   "secret",
+		Secure:   true,
+		HTTPOnly: true,
+		SameSite: "strict",
+		MaxAge:   60,
+		Domain:   "example.com",
+		Path:     "/app",
+	}
+	lb := NewStickyCookie(rr, "test", options)
+	require.NoError(t, lb.UpsertServer(testhelpers.MustParseURL("http://a")))
+	require.NoError(t, lb.UpsertServer(testhelpers.MustParseURL("http://b")))
+
+	recorder := httptest.NewRecorder()
+	lb.ServeHTTP(recorder, testhelpers.MustNewRequest(http.MethodGet, "http://frontend", nil))
+	require.Len(t, next.hosts, 1)
+
+	setCookie := recorder.Header().Get("Set-Cookie")
+	assert.Equal(t, "test="+options.Value("http://"+next.hosts[0])+"; Path=/app; Domain=example.com; Max-Age=60; HttpOnly; Secure; SameSite=Strict", setCookie)
+	assert.NotContains(t, setCookie, "http://")
+	cookies := recorder.Result().Cookies()
+	require.Len(t, cookies, 1)
+
+	for i := 0; i < 5; i++ {
+		recorder := httptest.NewRecorder()
+		req := testhelpers.MustNewRequest(http.MethodGet, "http://frontend", nil)
+		req.AddCookie(&http.Cookie{Name: "other", Value: "foo"})
+		req.AddCookie(cookies[0])
+		lb.ServeHTTP(recorder, req)
+		assert.Empty(t, recorder.Header().Get("Set-Cookie"))
+
+		// the request of the client is left untouched
+		assert.Equal(t, "other=foo; test="+cookies[0].Value, req.Header.Get("Cookie"))
+	}
+	require.Len(t, next.hosts, 6)
+	for _, host := range next.hosts {
+		assert.Equal(t, next.hosts[0], host)
+	}
+
+	// the URL of a server can't be used as the value of the cookie
+	for _, value := range []string{"http://a", "http://b", "unknown"} {
+		recorder := httptest.NewRecorder()
+		req := testhelpers.MustNewRequest(http.MethodGet, "http://frontend", nil)
+		req.AddCookie(&http.Cookie{Name: "test", Value: value})
+		lb.ServeHTTP(recorder, req)
+		assert.Contains(t, recorder.Header().Get("Set-Cookie"), "test="+options.Value("http://"+next.hosts[len(next.hosts)-1]))
+	}
+
+	// the cookie of a removed server no longer matches any server
+	require.NoError(t, lb.RemoveServer(testhelpers.MustParseURL("http://"+next.hosts[0])))
+	recorder = httptest.NewRecorder()
+	req := testhelpers.MustNewRequest(http.MethodGet, "http://frontend", nil)
+	req.AddCookie(cookies[0])
+	lb.ServeHTTP(recorder, req)
+	assert.NotEqual(t, next.hosts[0], next.hosts[len(next.hosts)-1])
+	assert.NotContains(t, recorder.Header().Get("Set-Cookie"), cookies[0].Value)
+}
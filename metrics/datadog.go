@@ -22,6 +22,8 @@ const (
 	ddMetricsReqsName    = "requests.total"
 	ddMetricsLatencyName = "request.duration"
 	ddRetriesTotalName   = "backend.retries.total"
+	ddGRPCReqsName       = "grpc.requests.total"
+	ddHedgesTotalName    = "backend.hedges.total"
 )
 
 // RegisterDatadog registers the metrics pusher if this didn't happen yet and creates a datadog Registry instance.
@@ -35,6 +37,8 @@ func RegisterDatadog(config *types.Datadog) Registry {
 		backendReqsCounter:          datadogClient.NewCounter(ddMetricsReqsName, 1.0),
 		backendReqDurationHistogram: datadogClient.NewHistogram(ddMetricsLatencyName, 1.0),
 		backendRetriesCounter:       datadogClient.NewCounter(ddRetriesTotalName, 1.0),
+		backendGRPCReqsCounter:      datadogClient.NewCounter(ddGRPCReqsName, 1.0),
+		backendHedgesCounter:        datadogClient.NewCounter(ddHedgesTotalName, 1.0),
 	}
 
 	return registry
@@ -28,6 +28,8 @@ const (
 	influxDBMetricsReqsName    = "traefik.requests.total"
 	influxDBMetricsLatencyName = "traefik.request.duration"
 	influxDBRetriesTotalName   = "traefik.backend.retries.total"
+	influxDBGRPCReqsName       = "traefik.grpc.requests.total"
+	influxDBHedgesTotalName    = "traefik.backend.hedges.total"
 )
 
 // RegisterInfluxDB registers the metrics pusher if this didn't happen yet and creates a InfluxDB Registry instance.
@@ -41,6 +43,8 @@ func RegisterInfluxDB(config *types.InfluxDB) Registry {
 		backendReqsCounter:          influxDBClient.NewCounter(influxDBMetricsReqsName),
 		backendReqDurationHistogram: influxDBClient.NewHistogram(influxDBMetricsLatencyName),
 		backendRetriesCounter:       influxDBClient.NewCounter(influxDBRetriesTotalName),
+		backendGRPCReqsCounter:      influxDBClient.NewCounter(influxDBGRPCReqsName),
+		backendHedgesCounter:        influxDBClient.NewCounter(influxDBHedgesTotalName),
 	}
 }
 
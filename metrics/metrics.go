@@ -19,6 +19,7 @@ type Registry interface {
 	EntrypointReqsCounter() metrics.Counter
 	EntrypointReqDurationHistogram() metrics.Histogram
 	EntrypointOpenConnsGauge() metrics.Gauge
+	EntrypointGRPCReqsCounter() metrics.Counter
 
 	// backend metrics
 	BackendReqsCounter() metrics.Counter
@@ -26,6 +27,8 @@ type Registry interface {
 	BackendOpenConnsGauge() metrics.Gauge
 	BackendRetriesCounter() metrics.Counter
 	BackendServerUpGauge() metrics.Gauge
+	BackendGRPCReqsCounter() metrics.Counter
+	BackendHedgesCounter() metrics.Counter
 }
 
 // NewVoidRegistry is a noop implementation of metrics.Registry.
@@ -45,11 +48,14 @@ func NewMultiRegistry(registries []Registry) Registry {
 	entrypointReqsCounter := []metrics.Counter{}
 	entrypointReqDurationHistogram := []metrics.Histogram{}
 	entrypointOpenConnsGauge := []metrics.Gauge{}
+	entrypointGRPCReqsCounter := []metrics.Counter{}
 	backendReqsCounter := []metrics.Counter{}
 	backendReqDurationHistogram := []metrics.Histogram{}
 	backendOpenConnsGauge := []metrics.Gauge{}
 	backendRetriesCounter := []metrics.Counter{}
 	backendServerUpGauge := []metrics.Gauge{}
+	backendGRPCReqsCounter := []metrics.Counter{}
+	backendHedgesCounter := []metrics.Counter{}
 
 	for _, r := range registries {
 		if r.ConfigReloadsCounter() != nil {
@@ -73,6 +79,9 @@ func NewMultiRegistry(registries []Registry) Registry {
 		if r.EntrypointOpenConnsGauge() != nil {
 			entrypointOpenConnsGauge = append(entrypointOpenConnsGauge, r.EntrypointOpenConnsGauge())
 		}
+		if r.EntrypointGRPCReqsCounter() != nil {
+			entrypointGRPCReqsCounter = append(entrypointGRPCReqsCounter, r.EntrypointGRPCReqsCounter())
+		}
 		if r.BackendReqsCounter() != nil {
 			backendReqsCounter = append(backendReqsCounter, r.BackendReqsCounter())
 		}
@@ -88,6 +97,12 @@ func NewMultiRegistry(registries []Registry) Registry {
 		if r.BackendServerUpGauge() != nil {
 			backendServerUpGauge = append(backendServerUpGauge, r.BackendServerUpGauge())
 		}
+		if r.BackendGRPCReqsCounter() != nil {
+			backendGRPCReqsCounter = append(backendGRPCReqsCounter, r.BackendGRPCReqsCounter())
+		}
+		if r.BackendHedgesCounter() != nil {
+			backendHedgesCounter = append(backendHedgesCounter, r.BackendHedgesCounter())
+		}
 	}
 
 	return &standardRegistry{
@@ -99,11 +114,14 @@ func NewMultiRegistry(registries []Registry) Registry {
 		entrypointReqsCounter:          multi.NewCounter(entrypointReqsCounter...),
 		entrypointReqDurationHistogram: multi.NewHistogram(entrypointReqDurationHistogram...),
 		entrypointOpenConnsGauge:       multi.NewGauge(entrypointOpenConnsGauge...),
+		entrypointGRPCReqsCounter:      multi.NewCounter(entrypointGRPCReqsCounter...),
 		backendReqsCounter:             multi.NewCounter(backendReqsCounter...),
 		backendReqDurationHistogram:    multi.NewHistogram(backendReqDurationHistogram...),
 		backendOpenConnsGauge:          multi.NewGauge(backendOpenConnsGauge...),
 		backendRetriesCounter:          multi.NewCounter(backendRetriesCounter...),
 		backendServerUpGauge:           multi.NewGauge(backendServerUpGauge...),
+		backendGRPCReqsCounter:         multi.NewCounter(backendGRPCReqsCounter...),
+		backendHedgesCounter:           multi.NewCounter(backendHedgesCounter...),
 	}
 }
 
@@ -116,11 +134,14 @@ type standardRegistry struct {
 	entrypointReqsCounter          metrics.Counter
 	entrypointReqDurationHistogram metrics.Histogram
 	entrypointOpenConnsGauge       metrics.Gauge
+	entrypointGRPCReqsCounter      metrics.Counter
 	backendReqsCounter             metrics.Counter
 	backendReqDurationHistogram    metrics.Histogram
 	backendOpenConnsGauge          metrics.Gauge
 	backendRetriesCounter          metrics.Counter
 	backendServerUpGauge           metrics.Gauge
+	backendGRPCReqsCounter         metrics.Counter
+	backendHedgesCounter           metrics.Counter
 }
 
 func (r *standardRegistry) IsEnabled() bool {
@@ -155,6 +176,10 @@ func (r *standardRegistry) EntrypointOpenConnsGauge() metrics.Gauge {
 	return r.entrypointOpenConnsGauge
 }
 
+func (r *standardRegistry) EntrypointGRPCReqsCounter() metrics.Counter {
+	return r.entrypointGRPCReqsCounter
+}
+
 func (r *standardRegistry) BackendReqsCounter() metrics.Counter {
 	return r.backendReqsCounter
 }
@@ -174,3 +199,11 @@ func (r *standardRegistry) BackendRetriesCounter() metrics.Counter {
 func (r *standardRegistry) BackendServerUpGauge() metrics.Gauge {
 	return r.backendServerUpGauge
 }
+
+func (r *standardRegistry) BackendGRPCReqsCounter() metrics.Counter {
+	return r.backendGRPCReqsCounter
+}
+
+func (r *standardRegistry) BackendHedgesCounter() metrics.Counter {
+	return r.backendHedgesCounter
+}
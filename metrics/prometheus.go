@@ -27,6 +27,7 @@ const (
 	entrypointReqsTotalName   = metricNamePrefix + "entrypoint_requests_total"
 	entrypointReqDurationName = metricNamePrefix + "entrypoint_request_duration_seconds"
 	entrypointOpenConnsName   = metricNamePrefix + "entrypoint_open_connections"
+	entrypointGRPCReqsName    = metricNamePrefix + "entrypoint_grpc_requests_total"
 
 	// backend level
 	backendReqsTotalName    = metricNamePrefix + "backend_requests_total"
@@ -34,6 +35,8 @@ const (
 	backendOpenConnsName    = metricNamePrefix + "backend_open_connections"
 	backendRetriesTotalName = metricNamePrefix + "backend_retries_total"
 	backendServerUpName     = metricNamePrefix + "backend_server_up"
+	backendGRPCReqsName     = metricNamePrefix + "backend_grpc_requests_total"
+	backendHedgesTotalName  = metricNamePrefix + "backend_hedges_total"
 )
 
 const (
@@ -107,6 +110,10 @@ func RegisterPrometheus(config *types.Prometheus) Registry {
 		Name: entrypointOpenConnsName,
 		Help: "How many open connections exist on an entrypoint, partitioned by method and protocol.",
 	}, []string{"method", "protocol", "entrypoint"})
+	entrypointGRPCReqs := newCounterFrom(promState.collectors, stdprometheus.CounterOpts{
+		Name: entrypointGRPCReqsName,
+		Help: "How many gRPC requests processed on an entrypoint, partitioned by gRPC status code and method.",
+	}, []string{"grpc_code", "grpc_method", "entrypoint"})
 
 	backendReqs := newCounterFrom(promState.collectors, stdprometheus.CounterOpts{
 		Name: backendReqsTotalName,
@@ -129,6 +136,14 @@ func RegisterPrometheus(config *types.Prometheus) Registry {
 		Name: backendServerUpName,
 		Help: "Backend server is up, described by gauge value of 0 or 1.",
 	}, []string{"backend", "url"})
+	backendGRPCReqs := newCounterFrom(promState.collectors, stdprometheus.CounterOpts{
+		Name: backendGRPCReqsName,
+		Help: "How many gRPC requests processed on a backend, partitioned by gRPC status code and method.",
+	}, []string{"grpc_code", "grpc_method", "backend"})
+	backendHedges := newCounterFrom(promState.collectors, stdprometheus.CounterOpts{
+		Name: backendHedgesTotalName,
+		Help: "How many hedged requests were sent on a backend, partitioned by whether they won.",
+	}, []string{"won", "backend"})
 
 	promState.describers = []func(chan<- *stdprometheus.Desc){
 		configReloads.cv.Describe,
@@ -138,11 +153,14 @@ func RegisterPrometheus(config *types.Prometheus) Registry {
 		entrypointReqs.cv.Describe,
 		entrypointReqDurations.hv.Describe,
 		entrypointOpenConns.gv.Describe,
+		entrypointGRPCReqs.cv.Describe,
 		backendReqs.cv.Describe,
 		backendReqDurations.hv.Describe,
 		backendOpenConns.gv.Describe,
 		backendRetries.cv.Describe,
 		backendServerUp.gv.Describe,
+		backendGRPCReqs.cv.Describe,
+		backendHedges.cv.Describe,
 	}
 	stdprometheus.MustRegister(promState)
 
@@ -155,11 +173,14 @@ func RegisterPrometheus(config *types.Prometheus) Registry {
 		entrypointReqsCounter:          entrypointReqs,
 		entrypointReqDurationHistogram: entrypointReqDurations,
 		entrypointOpenConnsGauge:       entrypointOpenConns,
+		entrypointGRPCReqsCounter:      entrypointGRPCReqs,
 		backendReqsCounter:             backendReqs,
 		backendReqDurationHistogram:    backendReqDurations,
 		backendOpenConnsGauge:          backendOpenConns,
 		backendRetriesCounter:          backendRetries,
 		backendServerUpGauge:           backendServerUp,
+		backendGRPCReqsCounter:         backendGRPCReqs,
+		backendHedgesCounter:           backendHedges,
 	}
 }
 
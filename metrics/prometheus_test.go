@@ -37,6 +37,10 @@ func TestPrometheus(t *testing.T) {
 		EntrypointOpenConnsGauge().
 		With("method", http.MethodGet, "protocol", "http", "entrypoint", "http").
 		Set(1)
+	prometheusRegistry.
+		EntrypointGRPCReqsCounter().
+		With("grpc_code", "0", "grpc_method", "helloworld.Greeter/SayHello", "entrypoint", "http").
+		Add(1)
 
 	prometheusRegistry.
 		BackendReqsCounter().
@@ -58,6 +62,14 @@ func TestPrometheus(t *testing.T) {
 		BackendServerUpGauge().
 		With("backend", "backend1", "url", "http://127.0.0.10:80").
 		Set(1)
+	prometheusRegistry.
+		BackendGRPCReqsCounter().
+		With("backend", "backend1", "grpc_code", "0", "grpc_method", "helloworld.Greeter/SayHello").
+		Add(1)
+	prometheusRegistry.
+		BackendHedgesCounter().
+		With("backend", "backend1", "won", "true").
+		Add(1)
 
 	delayForTrackingCompletion()
 
@@ -113,6 +125,15 @@ func TestPrometheus(t *testing.T) {
 			},
 			assert: buildGaugeAssert(t, entrypointOpenConnsName, 1),
 		},
+		{
+			name: entrypointGRPCReqsName,
+			labels: map[string]string{
+				"grpc_code":   "0",
+				"grpc_method": "helloworld.Greeter/SayHello",
+				"entrypoint":  "http",
+			},
+			assert: buildCounterAssert(t, entrypointGRPCReqsName, 1),
+		},
 		{
 			name: backendReqsTotalName,
 			labels: map[string]string{
@@ -157,6 +178,23 @@ func TestPrometheus(t *testing.T) {
 			},
 			assert: buildGaugeAssert(t, backendServerUpName, 1),
 		},
+		{
+			name: backendGRPCReqsName,
+			labels: map[string]string{
+				"grpc_code":   "0",
+				"grpc_method": "helloworld.Greeter/SayHello",
+				"backend":     "backend1",
+			},
+			assert: buildCounterAssert(t, backendGRPCReqsName, 1),
+		},
+		{
+			name: backendHedgesTotalName,
+			labels: map[string]string{
+				"won":     "true",
+				"backend": "backend1",
+			},
+			assert: buildCounterAssert(t, backendHedgesTotalName, 1),
+		},
 	}
 
 	for _, test := range tests {
@@ -21,6 +21,8 @@ const (
 	statsdMetricsReqsName    = "requests.total"
 	statsdMetricsLatencyName = "request.duration"
 	statsdRetriesTotalName   = "backend.retries.total"
+	statsdGRPCReqsName       = "grpc.requests.total"
+	statsdHedgesTotalName    = "backend.hedges.total"
 )
 
 // RegisterStatsd registers the metrics pusher if this didn't happen yet and creates a statsd Registry instance.
@@ -34,6 +36,8 @@ func RegisterStatsd(config *types.Statsd) Registry {
 		backendReqsCounter:          statsdClient.NewCounter(statsdMetricsReqsName, 1.0),
 		backendReqDurationHistogram: statsdClient.NewTiming(statsdMetricsLatencyName, 1.0),
 		backendRetriesCounter:       statsdClient.NewCounter(statsdRetriesTotalName, 1.0),
+		backendGRPCReqsCounter:      statsdClient.NewCounter(statsdGRPCReqsName, 1.0),
+		backendHedgesCounter:        statsdClient.NewCounter(statsdHedgesTotalName, 1.0),
 	}
 }
 
@@ -70,6 +70,8 @@ const (
 	Overhead = "Overhead"
 	// RetryAttempts is the map key used for the amount of attempts the request was retried.
 	RetryAttempts = "RetryAttempts"
+	// GRPCStatus is the map key used for the gRPC status code returned to the client, for gRPC requests only.
+	GRPCStatus = "GRPCStatus"
 )
 
 // These are written out in the default case when no config is provided to specify keys of interest.
@@ -113,6 +115,7 @@ func init() {
 	allCoreKeys[StartLocal] = struct{}{}
 	allCoreKeys[Overhead] = struct{}{}
 	allCoreKeys[RetryAttempts] = struct{}{}
+	allCoreKeys[GRPCStatus] = struct{}{}
 }
 
 // CoreLogData holds the fields computed from the request/response.
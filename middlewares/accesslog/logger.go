@@ -12,6 +12,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/containous/traefik/middlewares"
 	"github.com/containous/traefik/types"
 	"github.com/sirupsen/logrus"
 )
@@ -137,6 +138,9 @@ func (l *LogHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request, next h
 	next.ServeHTTP(crw, reqWithDataTable)
 
 	core[ClientUsername] = usernameIfPresent(reqWithDataTable.URL)
+	if middlewares.IsGRPCRequest(req) {
+		core[GRPCStatus] = middlewares.GRPCStatus(crw.Header(), crw.Status())
+	}
 
 	logDataTable.DownstreamResponse = crw.Header()
 	l.logTheRoundTrip(logDataTable, crr, crw)
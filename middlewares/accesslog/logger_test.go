@@ -244,6 +244,34 @@ func TestLoggerJSON(t *testing.T) {
 	assert.Equal(t, len(jsonData), assertCount, string(logData))
 }
 
+func TestLoggerJSONGRPCStatus(t *testing.T) {
+	tmpDir := createTempDir(t, JSONFormat)
+	defer os.RemoveAll(tmpDir)
+
+	logFilePath := filepath.Join(tmpDir, logFileNameSuffix)
+	logger, err := NewLogHandler(&types.AccessLog{FilePath: logFilePath, Format: JSONFormat})
+	require.NoError(t, err)
+	defer logger.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "http://foo.bar/helloworld.Greeter/SayHello", nil)
+	req.Header.Set("Content-Type", "application/grpc")
+
+	logger.ServeHTTP(httptest.NewRecorder(), req, func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Trailer", "Grpc-Status")
+		rw.Write([]byte(testContent))
+		rw.Header().Set("Grpc-Status", "5")
+	})
+
+	logData, err := ioutil.ReadFile(logFilePath)
+	require.NoError(t, err)
+
+	jsonData := make(map[string]interface{})
+	err = json.Unmarshal(logData, &jsonData)
+	require.NoError(t, err)
+
+	assert.Equal(t, "5", jsonData[GRPCStatus])
+}
+
 func TestNewLogHandlerOutputStdout(t *testing.T) {
 	file, restoreStdout := captureStdout(t)
 	defer restoreStdout()
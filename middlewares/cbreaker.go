@@ -21,11 +21,15 @@ func NewCircuitBreaker(next http.Handler, expression string, options ...cbreaker
 	return &CircuitBreaker{circuitBreaker}, nil
 }
 
-// NewCircuitBreakerOptions returns a new CircuitBreakerOption
+// NewCircuitBreakerOptions returns a new CircuitBreakerOption,
+// responding with 503 or forwarding to the fallback backend of the frontend when the circuit breaker is open.
 func NewCircuitBreakerOptions(expression string) cbreaker.CircuitBreakerOption {
 	return cbreaker.Fallback(
 		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			tracing.LogEventf(r, "blocked by circuitbreaker (%q)", expression)
+			if serveFallback(w, r) {
+				return
+			}
 			w.WriteHeader(http.StatusServiceUnavailable)
 			w.Write([]byte(http.StatusText(http.StatusServiceUnavailable)))
 		}))
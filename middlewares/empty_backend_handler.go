@@ -8,7 +8,8 @@ import (
 
 // EmptyBackendHandler is a middlware that checks whether the current Backend
 // has at least one active Server in respect to the healthchecks and if this
-// is not the case, it will stop the middleware chain and respond with 503.
+// is not the case, it will stop the middleware chain and respond with 503,
+// or forward the request to the fallback backend of the frontend if any.
 type EmptyBackendHandler struct {
 	lb   healthcheck.LoadBalancer
 	next http.Handler
@@ -23,6 +24,9 @@ func NewEmptyBackendHandler(lb healthcheck.LoadBalancer, next http.Handler) *Emp
 // invokes the next handler in the middleware chain.
 func (h *EmptyBackendHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 	if len(h.lb.Servers()) == 0 {
+		if serveFallback(rw, r) {
+			return
+		}
 		rw.WriteHeader(http.StatusServiceUnavailable)
 		rw.Write([]byte(http.StatusText(http.StatusServiceUnavailable)))
 	} else {
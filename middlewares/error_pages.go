@@ -31,10 +31,22 @@ func NewErrorPagesHandler(errorPage *types.ErrorPage, backendURL string) (*Error
 		return nil, err
 	}
 
-	//Break out the http status code ranges into a low int and high int
-	//for ease of use at runtime
+	blocks, err := ParseHTTPCodeRanges(errorPage.Status)
+	if err != nil {
+		return nil, err
+	}
+	return &ErrorPagesHandler{
+			HTTPCodeRanges:     blocks,
+			BackendURL:         backendURL + errorPage.Query,
+			errorPageForwarder: fwd},
+		nil
+}
+
+// ParseHTTPCodeRanges breaks out the http status code ranges (e.g. "404" or "500-599") into a low int and high int
+// for ease of use at runtime
+func ParseHTTPCodeRanges(codeRanges []string) ([][2]int, error) {
 	var blocks [][2]int
-	for _, block := range errorPage.Status {
+	for _, block := range codeRanges {
 		codes := strings.Split(block, "-")
 		//if only a single HTTP code was configured, assume the best and create the correct configuration on the user's behalf
 		if len(codes) == 1 {
@@ -50,11 +62,7 @@ func NewErrorPagesHandler(errorPage *types.ErrorPage, backendURL string) (*Error
 		}
 		blocks = append(blocks, [2]int{lowCode, highCode})
 	}
-	return &ErrorPagesHandler{
-			HTTPCodeRanges:     blocks,
-			BackendURL:         backendURL + errorPage.Query,
-			errorPageForwarder: fwd},
-		nil
+	return blocks, nil
 }
 
 func (ep *ErrorPagesHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
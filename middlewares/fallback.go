@@ -0,0 +1,37 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+)
+
+type fallbackKey struct{}
+
+// Fallback forwards the requests of a frontend to its backend,
+// unless the backend is unavailable: its servers are all down, or its circuit breaker is open.
+// The requests are then forwarded to the fallback backend.
+type Fallback struct {
+	next     http.Handler
+	fallback http.Handler
+}
+
+// NewFallback creates a new Fallback, forwarding the requests to next, or to fallback when the backend of next is unavailable.
+func NewFallback(next http.Handler, fallback http.Handler) *Fallback {
+	return &Fallback{next: next, fallback: fallback}
+}
+
+func (f *Fallback) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	f.next.ServeHTTP(rw, req.WithContext(context.WithValue(req.Context(), fallbackKey{}, f.fallback)))
+}
+
+// serveFallback forwards a request to the fallback backend of its frontend if any,
+// and returns whether it did.
+func serveFallback(rw http.ResponseWriter, req *http.Request) bool {
+	fallback, ok := req.Context().Value(fallbackKey{}).(http.Handler)
+	if !ok || fallback == nil {
+		return false
+	}
+	// the fallback backend has no fallback
+	fallback.ServeHTTP(rw, req.WithContext(context.WithValue(req.Context(), fallbackKey{}, nil)))
+	return true
+}
@@ -0,0 +1,64 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFallback(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		amountServer int
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			desc:         "backend up",
+			amountServer: 1,
+			expectedCode: http.StatusOK,
+			expectedBody: "backend",
+		},
+		{
+			desc:         "backend down",
+			amountServer: 0,
+			expectedCode: http.StatusAccepted,
+			expectedBody: "fallback",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			backend := NewEmptyBackendHandler(&healthCheckLoadBalancer{test.amountServer}, http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				rw.Write([]byte("backend"))
+			}))
+			fallback := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusAccepted)
+				rw.Write([]byte("fallback"))
+			})
+			handler := NewFallback(backend, fallback)
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "http://localhost", nil))
+
+			assert.Equal(t, test.expectedCode, recorder.Code)
+			assert.Equal(t, test.expectedBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestFallbackBackendDown(t *testing.T) {
+	// the fallback backend has no fallback itself
+	emptyBackend := NewEmptyBackendHandler(&healthCheckLoadBalancer{0}, http.NotFoundHandler())
+	handler := NewFallback(emptyBackend, emptyBackend)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "http://localhost", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+}
@@ -0,0 +1,69 @@
+package middlewares
+
+import (
+	"net/http"
+	"strings"
+)
+
+const (
+	grpcContentType  = "application/grpc"
+	grpcStatusHeader = "Grpc-Status"
+)
+
+// gRPC status codes, as defined in https://github.com/grpc/grpc/blob/master/doc/statuscodes.md
+const (
+	grpcCodeUnknown          = "2"
+	grpcCodePermissionDenied = "7"
+	grpcCodeUnimplemented    = "12"
+	grpcCodeInternal         = "13"
+	grpcCodeUnavailable      = "14"
+	grpcCodeUnauthenticated  = "16"
+)
+
+// IsGRPCRequest determines if the specified HTTP request is a gRPC call.
+func IsGRPCRequest(req *http.Request) bool {
+	contentType := strings.ToLower(req.Header.Get("Content-Type"))
+	if !strings.HasPrefix(contentType, grpcContentType) {
+		return false
+	}
+	// application/grpc, application/grpc+proto, application/grpc;charset=...
+	suffix := contentType[len(grpcContentType):]
+	return suffix == "" || suffix[0] == '+' || suffix[0] == ';'
+}
+
+// GRPCMethod returns the full name of the gRPC method called by the request (e.g. helloworld.Greeter/SayHello).
+func GRPCMethod(req *http.Request) string {
+	return strings.TrimPrefix(req.URL.Path, "/")
+}
+
+// GRPCStatus returns the gRPC status code of a response given its headers and HTTP status code.
+// The status is sent by the server as a trailer, or as a header for trailers-only responses.
+// When the server didn't send any, the status is derived from the HTTP status code.
+func GRPCStatus(header http.Header, statusCode int) string {
+	if status := header.Get(grpcStatusHeader); status != "" {
+		return status
+	}
+	if status := header.Get(http.TrailerPrefix + grpcStatusHeader); status != "" {
+		return status
+	}
+	return grpcStatusFromHTTP(statusCode)
+}
+
+// grpcStatusFromHTTP maps an HTTP status code to a gRPC status code,
+// as described in https://github.com/grpc/grpc/blob/master/doc/http-grpc-status-mapping.md
+func grpcStatusFromHTTP(statusCode int) string {
+	switch statusCode {
+	case http.StatusBadRequest:
+		return grpcCodeInternal
+	case http.StatusUnauthorized:
+		return grpcCodeUnauthenticated
+	case http.StatusForbidden:
+		return grpcCodePermissionDenied
+	case http.StatusNotFound:
+		return grpcCodeUnimplemented
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return grpcCodeUnavailable
+	default:
+		return grpcCodeUnknown
+	}
+}
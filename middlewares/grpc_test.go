@@ -0,0 +1,85 @@
+package middlewares
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/containous/traefik/testhelpers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsGRPCRequest(t *testing.T) {
+	testCases := []struct {
+		contentType string
+		expected    bool
+	}{
+		{contentType: "application/grpc", expected: true},
+		{contentType: "application/grpc+proto", expected: true},
+		{contentType: "application/grpc; charset=utf-8", expected: true},
+		{contentType: "Application/GRPC", expected: true},
+		{contentType: "application/grpc-web", expected: false},
+		{contentType: "application/json", expected: false},
+		{contentType: "", expected: false},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.contentType, func(t *testing.T) {
+			t.Parallel()
+
+			req := testhelpers.MustNewRequest(http.MethodPost, "http://foo.bar/helloworld.Greeter/SayHello", nil)
+			req.Header.Set("Content-Type", test.contentType)
+
+			assert.Equal(t, test.expected, IsGRPCRequest(req))
+		})
+	}
+}
+
+func TestGRPCStatus(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		header     http.Header
+		statusCode int
+		expected   string
+	}{
+		{
+			desc:       "status header",
+			header:     http.Header{"Grpc-Status": {"5"}},
+			statusCode: http.StatusOK,
+			expected:   "5",
+		},
+		{
+			desc:       "undeclared status trailer",
+			header:     http.Header{http.TrailerPrefix + "Grpc-Status": {"0"}},
+			statusCode: http.StatusOK,
+			expected:   "0",
+		},
+		{
+			desc:       "no status",
+			header:     http.Header{},
+			statusCode: http.StatusOK,
+			expected:   "2",
+		},
+		{
+			desc:       "bad gateway",
+			header:     http.Header{},
+			statusCode: http.StatusBadGateway,
+			expected:   "14",
+		},
+		{
+			desc:       "not found",
+			header:     http.Header{},
+			statusCode: http.StatusNotFound,
+			expected:   "12",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, GRPCStatus(test.header, test.statusCode))
+		})
+	}
+}
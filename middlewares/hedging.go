@@ -0,0 +1,394 @@
+package middlewares
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/containous/traefik/log"
+	"github.com/vulcand/oxy/utils"
+)
+
+// Compile time validation that the response writer implements http interfaces correctly.
+var _ Stateful = &hedgeAttempt{}
+
+const (
+	// hedgeLatencySamples is the number of the latest response header latencies from which the percentile delay is computed.
+	hedgeLatencySamples = 100
+	// hedgeMinLatencySamples is the number of latencies needed before the percentile delay replaces the fixed delay.
+	hedgeMinLatencySamples = 10
+)
+
+// HedgingPolicy defines when a hedged request is sent.
+type HedgingPolicy struct {
+	// Delay is the wait for the response headers of the first attempt before the hedged request is sent,
+	// or the wait used until enough latencies are known for Percentile
+	Delay time.Duration
+	// Percentile, if set, is the percentile of the latest response header latencies used as the delay
+	Percentile int
+}
+
+// HedgingLoadBalancer gives the servers a hedged request may be sent to.
+type HedgingLoadBalancer interface {
+	Servers() []*url.URL
+}
+
+// Hedging sends a second copy of an idempotent request to another server of the backend
+// when the response headers of the first attempt don't arrive within the delay.
+// The first response wins, and the other attempt is cancelled.
+// It wraps the forwarder, after the load-balancer picked the server of the first attempt.
+type Hedging struct {
+	// LB gives the servers of the backend, it is set once the load-balancer is built
+	LB HedgingLoadBalancer
+
+	policy    HedgingPolicy
+	next      http.Handler
+	listener  HedgeListener
+	latencies *latencyWindow
+}
+
+// NewHedging creates a new Hedging.
+func NewHedging(policy HedgingPolicy, next http.Handler, listener HedgeListener) *Hedging {
+	return &Hedging{
+		policy:    policy,
+		next:      next,
+		listener:  listener,
+		latencies: newLatencyWindow(policy.Percentile),
+	}
+}
+
+func (h *Hedging) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if !isIdempotent(req.Method) || req.ContentLength != 0 || len(req.Header.Get("Upgrade")) > 0 {
+		h.next.ServeHTTP(rw, req)
+		return
+	}
+
+	race := &hedgeRace{responseWriter: rw, decided: make(chan struct{}), latencies: h.latencies}
+	primary := race.start(h.next, req, req.URL)
+
+	timer := time.NewTimer(h.delay())
+	defer timer.Stop()
+
+	var hedge *hedgeAttempt
+	select {
+	case <-race.decided:
+	case <-primary.done:
+	case <-timer.C:
+		if server := h.pickServer(req.URL); server != nil && !race.isDecided() {
+			log.Debugf("Hedging request %v to %s", req.URL, server)
+			hedge = race.start(h.next, req, server)
+		}
+	}
+
+	attempts := []*hedgeAttempt{primary}
+	if hedge != nil {
+		attempts = append(attempts, hedge)
+	}
+	winner := race.wait(attempts)
+
+	if hedge != nil && h.listener != nil {
+		h.listener.Hedged(req, winner == hedge)
+	}
+
+	if winner == nil {
+		// every attempt failed without a response: the failure of the last one is the response
+		last := race.lastFailed
+		if last.netErrorOccurred {
+			DefaultNetErrorRecorder{}.Record(req.Context())
+		}
+		http.Error(rw, http.StatusText(last.failedCode), last.failedCode)
+		return
+	}
+	if winner.netErrorOccurred {
+		DefaultNetErrorRecorder{}.Record(req.Context())
+	}
+}
+
+func (h *Hedging) delay() time.Duration {
+	if delay, ok := h.latencies.percentile(); ok {
+		return delay
+	}
+	return h.policy.Delay
+}
+
+// pickServer returns a random server of the backend other than the server of the first attempt, if any.
+func (h *Hedging) pickServer(first *url.URL) *url.URL {
+	if h.LB == nil {
+		return nil
+	}
+
+	var servers []*url.URL
+	for _, server := range h.LB.Servers() {
+		if server.Host != first.Host || server.Scheme != first.Scheme {
+			servers = append(servers, server)
+		}
+	}
+	if len(servers) == 0 {
+		return nil
+	}
+	return servers[rand.Intn(len(servers))]
+}
+
+// HedgeListener is used to inform about hedged requests.
+type HedgeListener interface {
+	// Hedged will be called when a hedged request has been sent, once the response is known,
+	// with won set if the response of the hedged request won.
+	Hedged(req *http.Request, won bool)
+}
+
+// HedgeListeners is a convenience type to construct a list of HedgeListener and notify
+// each of them about a hedged request.
+type HedgeListeners []HedgeListener
+
+// Hedged exists to implement the HedgeListener interface. It calls Hedged on each of its slice entries.
+func (l HedgeListeners) Hedged(req *http.Request, won bool) {
+	for _, hedgeListener := range l {
+		hedgeListener.Hedged(req, won)
+	}
+}
+
+// hedgeRace picks the attempt whose response is written.
+// The first attempt writing its response headers wins, unless its status code is a 5xx and another attempt is still pending.
+type hedgeRace struct {
+	responseWriter http.ResponseWriter
+	latencies      *latencyWindow
+
+	lock       sync.Mutex
+	pending    int
+	winner     *hedgeAttempt
+	lastFailed *hedgeAttempt
+	decided    chan struct{}
+}
+
+// start serves the request with the given server in a new attempt.
+func (r *hedgeRace) start(next http.Handler, req *http.Request, server *url.URL) *hedgeAttempt {
+	ctx, cancel := context.WithCancel(req.Context())
+	attempt := &hedgeAttempt{
+		race:       r,
+		header:     make(http.Header),
+		start:      time.Now(),
+		cancel:     cancel,
+		done:       make(chan struct{}),
+		failedCode: http.StatusBadGateway,
+	}
+	// each attempt records its own network errors, only those of the response written matter
+	ctx = context.WithValue(ctx, defaultNetErrCtxKey, &attempt.netErrorOccurred)
+
+	// each attempt has its own headers, as the handlers of the attempts, e.g. the tracing, write them concurrently
+	attemptReq := req.WithContext(ctx)
+	attemptReq.URL = server
+	attemptReq.Header = cloneHeader(req.Header)
+
+	r.lock.Lock()
+	r.pending++
+	r.lock.Unlock()
+
+	go func() {
+		defer close(attempt.done)
+		defer cancel()
+		defer attempt.end()
+		next.ServeHTTP(attempt, attemptReq)
+	}()
+	return attempt
+}
+
+// wait returns the winner once its response has been written, or nil once all the attempts failed.
+// The other attempts are cancelled, and waited for as they still use the response writer of the request.
+func (r *hedgeRace) wait(attempts []*hedgeAttempt) *hedgeAttempt {
+	for _, attempt := range attempts {
+		select {
+		case <-r.decided:
+		case <-attempt.done:
+		}
+	}
+
+	r.lock.Lock()
+	winner := r.winner
+	r.lock.Unlock()
+
+	for _, attempt := range attempts {
+		if attempt != winner {
+			attempt.cancel()
+		}
+	}
+	for _, attempt := range attempts {
+		<-attempt.done
+	}
+	return winner
+}
+
+func (r *hedgeRace) isDecided() bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.winner != nil
+}
+
+func (r *hedgeRace) writeHeader(attempt *hedgeAttempt, code int) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.winner != nil {
+		return r.winner == attempt
+	}
+	if code >= http.StatusInternalServerError && r.pending > 1 {
+		return false
+	}
+
+	if code < http.StatusInternalServerError {
+		r.latencies.add(time.Since(attempt.start))
+	}
+	r.winner = attempt
+	utils.CopyHeaders(r.responseWriter.Header(), attempt.header)
+	r.responseWriter.WriteHeader(code)
+	close(r.decided)
+	return true
+}
+
+func (r *hedgeRace) end(attempt *hedgeAttempt) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.pending--
+	if r.winner != attempt {
+		r.lastFailed = attempt
+	}
+}
+
+// hedgeAttempt is the response writer of an attempt, writing to the response writer of the request only if it wins.
+type hedgeAttempt struct {
+	race             *hedgeRace
+	header           http.Header
+	start            time.Time
+	cancel           context.CancelFunc
+	done             chan struct{}
+	netErrorOccurred bool
+	failedCode       int
+	written          bool
+	won              bool
+}
+
+func (a *hedgeAttempt) end() {
+	a.race.end(a)
+}
+
+func (a *hedgeAttempt) Header() http.Header {
+	if a.won {
+		return a.race.responseWriter.Header()
+	}
+	return a.header
+}
+
+func (a *hedgeAttempt) Write(buf []byte) (int, error) {
+	if !a.written {
+		a.WriteHeader(http.StatusOK)
+	}
+	if !a.won {
+		return len(buf), nil
+	}
+	return a.race.responseWriter.Write(buf)
+}
+
+func (a *hedgeAttempt) WriteHeader(code int) {
+	if a.written {
+		return
+	}
+	a.written = true
+	a.won = a.race.writeHeader(a, code)
+	if !a.won {
+		a.failedCode = code
+	}
+}
+
+func (a *hedgeAttempt) Flush() {
+	if !a.won {
+		return
+	}
+	if flusher, ok := a.race.responseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (a *hedgeAttempt) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if !a.won {
+		return nil, nil, errors.New("hedged request attempt can't be hijacked before it wins")
+	}
+	return a.race.responseWriter.(http.Hijacker).Hijack()
+}
+
+// CloseNotify returns a channel that receives at most a
+// single value (true) when the client connection has gone away.
+func (a *hedgeAttempt) CloseNotify() <-chan bool {
+	if closeNotifier, ok := a.race.responseWriter.(http.CloseNotifier); ok {
+		return closeNotifier.CloseNotify()
+	}
+	return make(chan bool)
+}
+
+func cloneHeader(header http.Header) http.Header {
+	cloned := make(http.Header, len(header))
+	for key, values := range header {
+		cloned[key] = append([]string(nil), values...)
+	}
+	return cloned
+}
+
+// latencyWindow keeps the latest response header latencies to compute their percentile.
+type latencyWindow struct {
+	percentileRank int
+	lock           sync.Mutex
+	samples        []time.Duration
+	next           int
+	count          int
+	// current is the percentile of the samples, recomputed every hedgeMinLatencySamples samples
+	current int64
+}
+
+func newLatencyWindow(percentile int) *latencyWindow {
+	return &latencyWindow{percentileRank: percentile, samples: make([]time.Duration, hedgeLatencySamples)}
+}
+
+func (w *latencyWindow) add(latency time.Duration) {
+	if w.percentileRank <= 0 {
+		return
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.samples[w.next] = latency
+	w.next = (w.next + 1) % len(w.samples)
+	w.count++
+	if w.count%hedgeMinLatencySamples != 0 {
+		return
+	}
+
+	size := w.count
+	if size > len(w.samples) {
+		size = len(w.samples)
+	}
+	sorted := make([]time.Duration, size)
+	copy(sorted, w.samples[:size])
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	index := (size*w.percentileRank+99)/100 - 1
+	if index < 0 {
+		index = 0
+	} else if index >= size {
+		index = size - 1
+	}
+	atomic.StoreInt64(&w.current, int64(sorted[index]))
+}
+
+// percentile returns the percentile of the latest latencies, once enough of them are known.
+func (w *latencyWindow) percentile() (time.Duration, bool) {
+	current := atomic.LoadInt64(&w.current)
+	return time.Duration(current), current > 0
+}
@@ -0,0 +1,265 @@
+package middlewares
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type hedgingLoadBalancer []*url.URL
+
+func (lb hedgingLoadBalancer) Servers() []*url.URL {
+	return lb
+}
+
+type collectingHedgeListener struct {
+	lock sync.Mutex
+	won  []bool
+}
+
+func (l *collectingHedgeListener) Hedged(req *http.Request, won bool) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.won = append(l.won, won)
+}
+
+func TestHedging(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		method       string
+		body         string
+		servers      []string
+		handler      http.HandlerFunc
+		expectedCode int
+		expectedBody string
+		expectedWon  []bool
+	}{
+		{
+			desc:    "fast response",
+			method:  http.MethodGet,
+			servers: []string{"http://slow", "http://fast"},
+			handler: func(rw http.ResponseWriter, req *http.Request) {
+				rw.Write([]byte(req.URL.Host))
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: "slow",
+		},
+		{
+			desc:    "slow response",
+			method:  http.MethodGet,
+			servers: []string{"http://slow", "http://fast"},
+			handler: func(rw http.ResponseWriter, req *http.Request) {
+				if req.URL.Host == "slow" {
+					<-req.Context().Done()
+					http.Error(rw, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+					return
+				}
+				rw.Write([]byte(req.URL.Host))
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: "fast",
+			expectedWon:  []bool{true},
+		},
+		{
+			desc:    "slow response winning",
+			method:  http.MethodGet,
+			servers: []string{"http://slow", "http://slower"},
+			handler: func(rw http.ResponseWriter, req *http.Request) {
+				if req.URL.Host == "slower" {
+					<-req.Context().Done()
+					http.Error(rw, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+					return
+				}
+				time.Sleep(50 * time.Millisecond)
+				rw.Write([]byte(req.URL.Host))
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: "slow",
+			expectedWon:  []bool{false},
+		},
+		{
+			desc:    "error response of the first attempt while the hedged request is pending",
+			method:  http.MethodGet,
+			servers: []string{"http://slow", "http://fast"},
+			handler: func(rw http.ResponseWriter, req *http.Request) {
+				if req.URL.Host == "slow" {
+					time.Sleep(50 * time.Millisecond)
+					http.Error(rw, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+					return
+				}
+				time.Sleep(100 * time.Millisecond)
+				rw.Write([]byte(req.URL.Host))
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: "fast",
+			expectedWon:  []bool{true},
+		},
+		{
+			desc:    "error responses of both attempts",
+			method:  http.MethodGet,
+			servers: []string{"http://slow", "http://fast"},
+			handler: func(rw http.ResponseWriter, req *http.Request) {
+				time.Sleep(50 * time.Millisecond)
+				http.Error(rw, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+			},
+			expectedCode: http.StatusServiceUnavailable,
+			expectedWon:  []bool{true},
+		},
+		{
+			desc:    "single server",
+			method:  http.MethodGet,
+			servers: []string{"http://slow"},
+			handler: func(rw http.ResponseWriter, req *http.Request) {
+				time.Sleep(50 * time.Millisecond)
+				rw.Write([]byte(req.URL.Host))
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: "slow",
+		},
+		{
+			desc:    "non idempotent request",
+			method:  http.MethodPost,
+			servers: []string{"http://slow", "http://fast"},
+			handler: func(rw http.ResponseWriter, req *http.Request) {
+				if req.URL.Host == "slow" {
+					time.Sleep(50 * time.Millisecond)
+				}
+				rw.Write([]byte(req.URL.Host))
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: "slow",
+		},
+		{
+			desc:    "request with a body",
+			method:  http.MethodPut,
+			body:    "payload",
+			servers: []string{"http://slow", "http://fast"},
+			handler: func(rw http.ResponseWriter, req *http.Request) {
+				if req.URL.Host == "slow" {
+					time.Sleep(50 * time.Millisecond)
+				}
+				rw.Write([]byte(req.URL.Host))
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: "slow",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var lb hedgingLoadBalancer
+			for _, server := range test.servers {
+				u, err := url.Parse(server)
+				require.NoError(t, err)
+				lb = append(lb, u)
+			}
+
+			listener := &collectingHedgeListener{}
+			hedging := NewHedging(HedgingPolicy{Delay: 10 * time.Millisecond}, test.handler, listener)
+			hedging.LB = lb
+
+			var body io.Reader
+			if len(test.body) > 0 {
+				body = strings.NewReader(test.body)
+			}
+			req := httptest.NewRequest(test.method, "http://localhost/", body)
+			req.URL = lb[0]
+
+			recorder := httptest.NewRecorder()
+			hedging.ServeHTTP(recorder, req)
+
+			assert.Equal(t, test.expectedCode, recorder.Code)
+			if len(test.expectedBody) > 0 {
+				assert.Equal(t, test.expectedBody, recorder.Body.String())
+			}
+			assert.Equal(t, test.expectedWon, listener.won)
+		})
+	}
+}
+
+func TestHedgingNetErrors(t *testing.T) {
+	lb := hedgingLoadBalancer{{Scheme: "http", Host: "slow"}, {Scheme: "http", Host: "fast"}}
+	handler := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Host == "slow" {
+			<-req.Context().Done()
+			// the network error of the cancelled attempt isn't recorded for the request
+			DefaultNetErrorRecorder{}.Record(req.Context())
+			http.Error(rw, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+			return
+		}
+		rw.Write([]byte(req.URL.Host))
+	})
+
+	hedging := NewHedging(HedgingPolicy{Delay: 10 * time.Millisecond}, handler, nil)
+	hedging.LB = lb
+
+	netErrorOccurred := false
+	req := httptest.NewRequest(http.MethodGet, "http://localhost/", nil)
+	req = req.WithContext(context.WithValue(req.Context(), defaultNetErrCtxKey, &netErrorOccurred))
+	req.URL = lb[0]
+
+	recorder := httptest.NewRecorder()
+	hedging.ServeHTTP(recorder, req)
+
+	assert.Equal(t, "fast", recorder.Body.String())
+	assert.False(t, netErrorOccurred)
+}
+
+func TestHedgingAttemptHeaders(t *testing.T) {
+	lb := hedgingLoadBalancer{{Scheme: "http", Host: "slow"}, {Scheme: "http", Host: "fast"}}
+	handler := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		// both attempts write their headers, like the tracing injecting its headers
+		for i := 0; i < 100; i++ {
+			req.Header.Set("X-Attempt", req.URL.Host)
+			_ = req.Header.Get("X-Foo")
+		}
+		if req.URL.Host == "slow" {
+			<-req.Context().Done()
+			return
+		}
+		rw.Write([]byte(req.Header.Get("X-Attempt")))
+	})
+
+	hedging := NewHedging(HedgingPolicy{Delay: time.Millisecond}, handler, nil)
+	hedging.LB = lb
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost/", nil)
+	req.Header.Set("X-Foo", "bar")
+	req.URL = lb[0]
+
+	recorder := httptest.NewRecorder()
+	hedging.ServeHTTP(recorder, req)
+
+	assert.Equal(t, "fast", recorder.Body.String())
+	assert.Empty(t, req.Header.Get("X-Attempt"))
+}
+
+func TestHedgingPercentileDelay(t *testing.T) {
+	hedging := NewHedging(HedgingPolicy{Delay: time.Second, Percentile: 90}, nil, nil)
+	assert.Equal(t, time.Second, hedging.delay())
+
+	for i := 1; i < hedgeMinLatencySamples; i++ {
+		hedging.latencies.add(time.Duration(i) * time.Millisecond)
+	}
+	assert.Equal(t, time.Second, hedging.delay(), "not enough latencies")
+
+	hedging.latencies.add(10 * time.Millisecond)
+	assert.Equal(t, 9*time.Millisecond, hedging.delay())
+
+	for i := 0; i < hedgeLatencySamples; i++ {
+		hedging.latencies.add(100 * time.Millisecond)
+	}
+	assert.Equal(t, 100*time.Millisecond, hedging.delay(), "older latencies")
+}
@@ -18,6 +18,7 @@ const (
 	protoHTTP      = "http"
 	protoSSE       = "sse"
 	protoWebsocket = "websocket"
+	protoGRPC      = "grpc"
 )
 
 // NewEntryPointMetricsMiddleware creates a new metrics middleware for an Entrypoint.
@@ -26,6 +27,7 @@ func NewEntryPointMetricsMiddleware(registry metrics.Registry, entryPointName st
 		reqsCounter:          registry.EntrypointReqsCounter(),
 		reqDurationHistogram: registry.EntrypointReqDurationHistogram(),
 		openConnsGauge:       registry.EntrypointOpenConnsGauge(),
+		grpcReqsCounter:      registry.EntrypointGRPCReqsCounter(),
 		baseLabels:           []string{"entrypoint", entryPointName},
 	}
 }
@@ -36,6 +38,7 @@ func NewBackendMetricsMiddleware(registry metrics.Registry, backendName string)
 		reqsCounter:          registry.BackendReqsCounter(),
 		reqDurationHistogram: registry.BackendReqDurationHistogram(),
 		openConnsGauge:       registry.BackendOpenConnsGauge(),
+		grpcReqsCounter:      registry.BackendGRPCReqsCounter(),
 		baseLabels:           []string{"backend", backendName},
 	}
 }
@@ -44,6 +47,7 @@ type metricsMiddleware struct {
 	reqsCounter          gokitmetrics.Counter
 	reqDurationHistogram gokitmetrics.Histogram
 	openConnsGauge       gokitmetrics.Gauge
+	grpcReqsCounter      gokitmetrics.Counter
 	baseLabels           []string
 	openConns            int64
 }
@@ -66,10 +70,17 @@ func (m *metricsMiddleware) ServeHTTP(rw http.ResponseWriter, r *http.Request, n
 	labels = append(labels, "code", strconv.Itoa(recorder.statusCode))
 	m.reqsCounter.With(labels...).Add(1)
 	m.reqDurationHistogram.With(labels...).Observe(float64(time.Since(start).Seconds()))
+
+	if IsGRPCRequest(r) {
+		grpcLabels := []string{"grpc_method", GRPCMethod(r), "grpc_code", GRPCStatus(recorder.Header(), recorder.statusCode)}
+		m.grpcReqsCounter.With(append(grpcLabels, m.baseLabels...)...).Add(1)
+	}
 }
 
 func getRequestProtocol(req *http.Request) string {
 	switch {
+	case IsGRPCRequest(req):
+		return protoGRPC
 	case isWebsocketRequest(req):
 		return protoWebsocket
 	case isSSERequest(req):
@@ -127,3 +138,24 @@ type MetricsRetryListener struct {
 func (m *MetricsRetryListener) Retried(req *http.Request, attempt int) {
 	m.retryMetrics.BackendRetriesCounter().With("backend", m.backendName).Add(1)
 }
+
+type hedgeMetrics interface {
+	BackendHedgesCounter() gokitmetrics.Counter
+}
+
+// NewMetricsHedgeListener instantiates a MetricsHedgeListener with the given hedgeMetrics.
+func NewMetricsHedgeListener(hedgeMetrics hedgeMetrics, backendName string) HedgeListener {
+	return &MetricsHedgeListener{hedgeMetrics: hedgeMetrics, backendName: backendName}
+}
+
+// MetricsHedgeListener is an implementation of the HedgeListener interface to
+// record RequestMetrics about hedged requests.
+type MetricsHedgeListener struct {
+	hedgeMetrics hedgeMetrics
+	backendName  string
+}
+
+// Hedged tracks the hedged request in the RequestMetrics implementation.
+func (m *MetricsHedgeListener) Hedged(req *http.Request, won bool) {
+	m.hedgeMetrics.BackendHedgesCounter().With("backend", m.backendName, "won", strconv.FormatBool(won)).Add(1)
+}
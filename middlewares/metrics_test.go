@@ -40,3 +40,30 @@ func newCollectingRetryMetrics() *collectingRetryMetrics {
 func (metrics *collectingRetryMetrics) BackendRetriesCounter() metrics.Counter {
 	return metrics.retriesCounter
 }
+
+func TestMetricsHedgeListener(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	hedgeMetrics := &collectingHedgeMetrics{hedgesCounter: &testhelpers.CollectingCounter{}}
+	hedgeListener := NewMetricsHedgeListener(hedgeMetrics, "backendName")
+	hedgeListener.Hedged(req, false)
+	hedgeListener.Hedged(req, true)
+
+	wantCounterValue := float64(2)
+	if hedgeMetrics.hedgesCounter.CounterValue != wantCounterValue {
+		t.Errorf("got counter value of %f, want %f", hedgeMetrics.hedgesCounter.CounterValue, wantCounterValue)
+	}
+
+	wantLabelValues := []string{"backend", "backendName", "won", "true"}
+	if !reflect.DeepEqual(hedgeMetrics.hedgesCounter.LastLabelValues, wantLabelValues) {
+		t.Errorf("wrong label values %v used, want %v", hedgeMetrics.hedgesCounter.LastLabelValues, wantLabelValues)
+	}
+}
+
+// collectingHedgeMetrics is an implementation of the hedgeMetrics interface that can be used inside tests to collect the times Add() was called.
+type collectingHedgeMetrics struct {
+	hedgesCounter *testhelpers.CollectingCounter
+}
+
+func (metrics *collectingHedgeMetrics) BackendHedgesCounter() metrics.Counter {
+	return metrics.hedgesCounter
+}
@@ -0,0 +1,163 @@
+package mirror
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"sync/atomic"
+
+	"github.com/containous/traefik/log"
+	"github.com/containous/traefik/middlewares/accesslog"
+	"github.com/containous/traefik/safe"
+)
+
+const (
+	// DefaultMaxBodySize is the size of the largest request body copied to the mirror by default.
+	DefaultMaxBodySize int64 = 1 << 20
+	// maximum number of mirrored requests in flight, beyond which requests are not mirrored
+	maxInFlight = 1000
+)
+
+// Handler forwards the requests to the next handler, and copies a share of them to a mirror.
+// The copies are sent asynchronously, and the responses of the mirror are discarded,
+// so that the mirror doesn't add any latency nor failure to the requests.
+type Handler struct {
+	next        http.Handler
+	mirror      http.Handler
+	percent     int
+	maxBodySize int64
+	// percents accumulated by the requests since the last mirrored request
+	credit   int
+	lock     sync.Mutex
+	inFlight int32
+}
+
+// NewHandler creates a new Handler copying percent of the requests to the mirror,
+// provided that their body is not larger than maxBodySize (DefaultMaxBodySize if not positive).
+func NewHandler(next http.Handler, mirror http.Handler, percent int, maxBodySize int64) *Handler {
+	if maxBodySize <= 0 {
+		maxBodySize = DefaultMaxBodySize
+	}
+	return &Handler{
+		next:        next,
+		mirror:      mirror,
+		percent:     percent,
+		maxBodySize: maxBodySize,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if h.shouldMirror(req) {
+		if mirrorReq := h.copyRequest(req); mirrorReq != nil {
+			h.serveMirror(mirrorReq)
+		}
+	}
+	h.next.ServeHTTP(rw, req)
+}
+
+// shouldMirror spreads the mirrored requests evenly over the requests.
+func (h *Handler) shouldMirror(req *http.Request) bool {
+	// an upgraded connection can't be copied
+	if req.Header.Get("Upgrade") != "" {
+		return false
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.credit += h.percent
+	if h.credit < 100 {
+		return false
+	}
+	h.credit -= 100
+	return true
+}
+
+// copyRequest returns a copy of the request for the mirror, detached from the context of the request,
+// or nil if the body of the request is too large, in which case the body of the request is left unread.
+func (h *Handler) copyRequest(req *http.Request) *http.Request {
+	if req.ContentLength > h.maxBodySize {
+		return nil
+	}
+
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		body, err = ioutil.ReadAll(io.LimitReader(req.Body, h.maxBodySize+1))
+		if err != nil || int64(len(body)) > h.maxBodySize {
+			// the request gets the body as if it was never read
+			req.Body = &readCloser{Reader: io.MultiReader(bytes.NewReader(body), req.Body), Closer: req.Body}
+			if err != nil {
+				log.Debugf("Not mirroring request %s: %v", req.URL, err)
+			}
+			return nil
+		}
+		req.Body = &readCloser{Reader: bytes.NewReader(body), Closer: req.Body}
+	}
+
+	// the request context is canceled once its response is sent,
+	// and its access log data must not be written by the mirror
+	header := cloneHeader(req.Header)
+	ctx := context.WithValue(context.Background(), accesslog.DataTableKey, &accesslog.LogData{
+		Core:    accesslog.CoreLogData{},
+		Request: header,
+	})
+	mirrorReq := req.WithContext(ctx)
+	mirrorReq.Header = header
+	mirrorURL := *req.URL
+	mirrorReq.URL = &mirrorURL
+	mirrorReq.ContentLength = int64(len(body))
+	mirrorReq.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if len(body) == 0 {
+		mirrorReq.Body = http.NoBody
+	}
+	return mirrorReq
+}
+
+func (h *Handler) serveMirror(req *http.Request) {
+	if atomic.AddInt32(&h.inFlight, 1) > maxInFlight {
+		atomic.AddInt32(&h.inFlight, -1)
+		log.Debugf("Not mirroring request %s: too many mirrored requests in flight", req.URL)
+		return
+	}
+
+	safe.Go(func() {
+		defer atomic.AddInt32(&h.inFlight, -1)
+		h.mirror.ServeHTTP(newDiscardResponseWriter(), req)
+	})
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+func cloneHeader(header http.Header) http.Header {
+	cloned := make(http.Header, len(header))
+	for key, values := range header {
+		cloned[key] = append([]string(nil), values...)
+	}
+	return cloned
+}
+
+// discardResponseWriter is a response writer discarding the response of the mirror.
+type discardResponseWriter struct {
+	header http.Header
+}
+
+func newDiscardResponseWriter() *discardResponseWriter {
+	return &discardResponseWriter{header: make(http.Header)}
+}
+
+func (w *discardResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *discardResponseWriter) Write(b []byte) (int, error) {
+	return len(b), nil
+}
+
+func (w *discardResponseWriter) WriteHeader(int) {}
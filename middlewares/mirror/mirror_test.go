@@ -0,0 +1,160 @@
+package mirror
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/containous/traefik/middlewares/accesslog"
+	"github.com/containous/traefik/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingHandler struct {
+	lock     sync.Mutex
+	bodies   []string
+	requests chan *http.Request
+}
+
+func newRecordingHandler() *recordingHandler {
+	return &recordingHandler{requests: make(chan *http.Request, 100)}
+}
+
+func (h *recordingHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if req.Body != nil {
+		body, _ := ioutil.ReadAll(req.Body)
+		h.lock.Lock()
+		h.bodies = append(h.bodies, string(body))
+		h.lock.Unlock()
+	}
+	rw.WriteHeader(http.StatusTeapot)
+	h.requests <- req
+}
+
+func (h *recordingHandler) waitRequests(t *testing.T, count int) []*http.Request {
+	var requests []*http.Request
+	for i := 0; i < count; i++ {
+		select {
+		case req := <-h.requests:
+			requests = append(requests, req)
+		case <-time.After(time.Second):
+			t.Fatalf("got %d requests, expected %d", len(requests), count)
+		}
+	}
+	return requests
+}
+
+func TestMirrorPercent(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		percent  int
+		expected int
+	}{
+		{
+			desc:     "all",
+			percent:  100,
+			expected: 20,
+		},
+		{
+			desc:     "quarter",
+			percent:  25,
+			expected: 5,
+		},
+		{
+			desc:     "none",
+			percent:  0,
+			expected: 0,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			next, mirror := newRecordingHandler(), newRecordingHandler()
+			handler := NewHandler(next, mirror, test.percent, 0)
+
+			for i := 0; i < 20; i++ {
+				recorder := httptest.NewRecorder()
+				handler.ServeHTTP(recorder, testhelpers.MustNewRequest(http.MethodGet, "http://frontend", nil))
+				assert.Equal(t, http.StatusTeapot, recorder.Code)
+			}
+			next.waitRequests(t, 20)
+			mirror.waitRequests(t, test.expected)
+
+			select {
+			case <-mirror.requests:
+				t.Fatal("too many mirrored requests")
+			case <-time.After(10 * time.Millisecond):
+			}
+		})
+	}
+}
+
+func TestMirrorBody(t *testing.T) {
+	next, mirror := newRecordingHandler(), newRecordingHandler()
+	handler := NewHandler(next, mirror, 100, 10)
+
+	handler.ServeHTTP(httptest.NewRecorder(), testhelpers.MustNewRequest(http.MethodPost, "http://frontend", strings.NewReader("small")))
+	next.waitRequests(t, 1)
+	mirror.waitRequests(t, 1)
+
+	// a request with a body larger than the limit is forwarded untouched, but not mirrored
+	req := testhelpers.MustNewRequest(http.MethodPost, "http://frontend", ioutil.NopCloser(strings.NewReader("a body which is too large")))
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	next.waitRequests(t, 1)
+
+	select {
+	case <-mirror.requests:
+		t.Fatal("request with a large body mirrored")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	next.lock.Lock()
+	assert.Equal(t, []string{"small", "a body which is too large"}, next.bodies)
+	next.lock.Unlock()
+	mirror.lock.Lock()
+	assert.Equal(t, []string{"small"}, mirror.bodies)
+	mirror.lock.Unlock()
+}
+
+func TestMirrorRequestIsDetached(t *testing.T) {
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		req.Header.Set("X-Modified", "true")
+	})
+	mirror := newRecordingHandler()
+	handler := NewHandler(next, mirror, 100, 0)
+
+	req := testhelpers.MustNewRequest(http.MethodGet, "http://frontend/path", nil)
+	req.Header.Set("X-Test", "foo")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	mirrored := mirror.waitRequests(t, 1)[0]
+	assert.Equal(t, "foo", mirrored.Header.Get("X-Test"))
+	assert.Empty(t, mirrored.Header.Get("X-Modified"))
+	assert.Equal(t, "/path", mirrored.URL.Path)
+	require.NotNil(t, mirrored.Context().Value(accesslog.DataTableKey))
+	assert.NoError(t, mirrored.Context().Err())
+}
+
+func TestMirrorSkipsUpgrades(t *testing.T) {
+	next, mirror := newRecordingHandler(), newRecordingHandler()
+	handler := NewHandler(next, mirror, 100, 0)
+
+	req := testhelpers.MustNewRequest(http.MethodGet, "http://frontend", nil)
+	req.Header.Set("Upgrade", "websocket")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	next.waitRequests(t, 1)
+
+	select {
+	case <-mirror.requests:
+		t.Fatal("upgrade request mirrored")
+	case <-time.After(10 * time.Millisecond):
+	}
+}
@@ -2,10 +2,15 @@ package middlewares
 
 import (
 	"bufio"
+	"bytes"
 	"context"
+	"io"
 	"io/ioutil"
+	"math/rand"
 	"net"
 	"net/http"
+	"sync/atomic"
+	"time"
 
 	"github.com/containous/traefik/log"
 )
@@ -13,50 +18,200 @@ import (
 // Compile time validation that the response writer implements http interfaces correctly.
 var _ Stateful = &retryResponseWriterWithCloseNotify{}
 
+// maxReplayedBodyBytes is the size of the largest request body replayed when retrying a response status code.
+const maxReplayedBodyBytes = 1 << 20
+
+// RetryPolicy defines which requests are retried, and how.
+type RetryPolicy struct {
+	// Attempts is the maximum number of attempts of a request
+	Attempts int
+	// StatusCodeRanges are the response status codes retried, in addition to the network errors
+	StatusCodeRanges [][2]int
+	// IdempotentOnly prevents the retries of the requests whose method isn't idempotent
+	IdempotentOnly bool
+	// PerTryTimeout limits the duration of each attempt
+	PerTryTimeout time.Duration
+	// Backoff is the maximum wait before the first retry, doubled at each retry up to MaxBackoff,
+	// the actual wait being a random duration up to it
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// Budget limits the retries in flight, if any
+	Budget *RetryBudget
+}
+
 // Retry is a middleware that retries requests
 type Retry struct {
-	attempts int
+	policy   RetryPolicy
 	next     http.Handler
 	listener RetryListener
 }
 
 // NewRetry returns a new Retry instance
 func NewRetry(attempts int, next http.Handler, listener RetryListener) *Retry {
+	return NewRetryWithPolicy(RetryPolicy{Attempts: attempts}, next, listener)
+}
+
+// NewRetryWithPolicy returns a new Retry instance retrying the requests according to the policy.
+func NewRetryWithPolicy(policy RetryPolicy, next http.Handler, listener RetryListener) *Retry {
 	return &Retry{
-		attempts: attempts,
+		policy:   policy,
 		next:     next,
 		listener: listener,
 	}
 }
 
 func (retry *Retry) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	maxAttempts := retry.policy.Attempts
+	if retry.policy.IdempotentOnly && !isIdempotent(r.Method) {
+		maxAttempts = 1
+	}
+	statusCodeRanges := retry.policy.StatusCodeRanges
+
+	var body []byte
 	// if we might make multiple attempts, swap the body for an ioutil.NopCloser
 	// cf https://github.com/containous/traefik/issues/1008
-	if retry.attempts > 1 {
-		body := r.Body
-		defer body.Close()
-		r.Body = ioutil.NopCloser(body)
+	if maxAttempts > 1 && r.Body != nil {
+		originalBody := r.Body
+		defer originalBody.Close()
+		r.Body = ioutil.NopCloser(originalBody)
+
+		// the body has been sent when a status code is retried, so it is replayed, unless it's too large
+		if len(statusCodeRanges) > 0 && originalBody != http.NoBody {
+			var err error
+			body, err = ioutil.ReadAll(io.LimitReader(originalBody, maxReplayedBodyBytes+1))
+			if err != nil {
+				log.Debugf("Error reading the body of request %v: %v", r.URL, err)
+				http.Error(rw, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+				return
+			}
+			if len(body) > maxReplayedBodyBytes {
+				r.Body = ioutil.NopCloser(io.MultiReader(bytes.NewReader(body), originalBody))
+				body = nil
+				statusCodeRanges = nil
+			}
+		}
+	}
+
+	budget := retry.policy.Budget
+	if budget != nil {
+		budget.requestStarted()
+		defer budget.requestDone()
 	}
 
 	attempts := 1
 	for {
+		if body != nil {
+			r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		}
+
 		netErrorOccurred := false
 		// We pass in a pointer to netErrorOccurred so that we can set it to true on network errors
 		// when proxying the HTTP requests to the backends. This happens in the custom RecordingErrorHandler.
 		newCtx := context.WithValue(r.Context(), defaultNetErrCtxKey, &netErrorOccurred)
-		retryResponseWriter := newRetryResponseWriter(rw, attempts >= retry.attempts, &netErrorOccurred)
+		cancel := func() {}
+		if retry.policy.PerTryTimeout > 0 {
+			newCtx, cancel = context.WithTimeout(newCtx, retry.policy.PerTryTimeout)
+		}
+		attemptsExhausted := attempts >= maxAttempts || (budget != nil && !budget.allowRetry())
+		retryResponseWriter := newRetryResponseWriter(rw, attemptsExhausted, &netErrorOccurred, statusCodeRanges)
 
 		retry.next.ServeHTTP(retryResponseWriter, r.WithContext(newCtx))
+		cancel()
 		if !retryResponseWriter.ShouldRetry() {
 			break
 		}
 
+		if !retry.wait(r.Context(), attempts) {
+			// the client is gone, or the request timed out
+			http.Error(rw, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+			return
+		}
+
 		attempts++
+		if budget != nil {
+			budget.retryStarted()
+			defer budget.retryDone()
+		}
 		log.Debugf("New attempt %d for request: %v", attempts, r.URL)
 		retry.listener.Retried(r, attempts)
 	}
 }
 
+// wait waits before the next retry, returning false if the request context is done in the meantime.
+func (retry *Retry) wait(ctx context.Context, attempts int) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if retry.policy.Backoff <= 0 {
+		return true
+	}
+
+	backoff := retry.policy.Backoff << uint(attempts-1)
+	if retry.policy.MaxBackoff > 0 && (backoff > retry.policy.MaxBackoff || backoff <= 0) {
+		backoff = retry.policy.MaxBackoff
+	}
+	// full jitter, so that the retries of concurrent requests are spread
+	timer := time.NewTimer(time.Duration(rand.Int63n(int64(backoff) + 1)))
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// minRetriesInFlight is the number of retries in flight always allowed by a RetryBudget,
+// so that the retries aren't prevented when there are few requests.
+const minRetriesInFlight = 3
+
+// RetryBudget limits the retries in flight to a percentage of the requests in flight,
+// so that the retries can't overload a failing backend (retry storm).
+type RetryBudget struct {
+	percent  int64
+	requests int64
+	retries  int64
+}
+
+// NewRetryBudget creates a new RetryBudget, allowing percent retries in flight for 100 requests in flight.
+func NewRetryBudget(percent int) *RetryBudget {
+	return &RetryBudget{percent: int64(percent)}
+}
+
+func (b *RetryBudget) allowRetry() bool {
+	allowed := atomic.LoadInt64(&b.requests) * b.percent / 100
+	if allowed < minRetriesInFlight {
+		allowed = minRetriesInFlight
+	}
+	return atomic.LoadInt64(&b.retries) < allowed
+}
+
+func (b *RetryBudget) requestStarted() {
+	atomic.AddInt64(&b.requests, 1)
+}
+
+func (b *RetryBudget) requestDone() {
+	atomic.AddInt64(&b.requests, -1)
+}
+
+func (b *RetryBudget) retryStarted() {
+	atomic.AddInt64(&b.retries, 1)
+}
+
+func (b *RetryBudget) retryDone() {
+	atomic.AddInt64(&b.retries, -1)
+}
+
 // netErrorCtxKey is a custom type that is used as key for the context.
 type netErrorCtxKey string
 
@@ -106,11 +261,13 @@ type retryResponseWriter interface {
 	ShouldRetry() bool
 }
 
-func newRetryResponseWriter(rw http.ResponseWriter, attemptsExhausted bool, netErrorOccured *bool) retryResponseWriter {
+func newRetryResponseWriter(rw http.ResponseWriter, attemptsExhausted bool, netErrorOccured *bool, statusCodeRanges [][2]int) retryResponseWriter {
 	responseWriter := &retryResponseWriterWithoutCloseNotify{
 		responseWriter:    rw,
+		headers:           make(http.Header),
 		attemptsExhausted: attemptsExhausted,
 		netErrorOccured:   netErrorOccured,
+		statusCodeRanges:  statusCodeRanges,
 	}
 	if _, ok := rw.(http.CloseNotifier); ok {
 		return &retryResponseWriterWithCloseNotify{responseWriter}
@@ -118,42 +275,78 @@ func newRetryResponseWriter(rw http.ResponseWriter, attemptsExhausted bool, netE
 	return responseWriter
 }
 
+// retryResponseWriterWithoutCloseNotify discards the response of an attempt to be retried.
+// The headers are kept apart until the status code is known, so that the headers of a retried response don't leak.
 type retryResponseWriterWithoutCloseNotify struct {
 	responseWriter    http.ResponseWriter
+	headers           http.Header
 	attemptsExhausted bool
 	netErrorOccured   *bool
+	statusCodeRanges  [][2]int
+	retriedStatus     bool
+	written           bool
 }
 
 func (rr *retryResponseWriterWithoutCloseNotify) ShouldRetry() bool {
-	return *rr.netErrorOccured == true && !rr.attemptsExhausted
+	return (*rr.netErrorOccured || rr.retriedStatus) && !rr.attemptsExhausted && !rr.written
 }
 
 func (rr *retryResponseWriterWithoutCloseNotify) Header() http.Header {
-	if rr.ShouldRetry() {
-		return make(http.Header)
+	if rr.written {
+		return rr.responseWriter.Header()
 	}
-	return rr.responseWriter.Header()
+	return rr.headers
 }
 
 func (rr *retryResponseWriterWithoutCloseNotify) Write(buf []byte) (int, error) {
+	if !rr.written {
+		rr.WriteHeader(http.StatusOK)
+	}
 	if rr.ShouldRetry() {
-		return 0, nil
+		return len(buf), nil
 	}
 	return rr.responseWriter.Write(buf)
 }
 
 func (rr *retryResponseWriterWithoutCloseNotify) WriteHeader(code int) {
+	if rr.written {
+		return
+	}
+	if !rr.attemptsExhausted && rr.isRetriedStatus(code) {
+		rr.retriedStatus = true
+	}
 	if rr.ShouldRetry() {
 		return
 	}
+
+	header := rr.responseWriter.Header()
+	for key, values := range rr.headers {
+		header[key] = values
+	}
+	rr.written = true
 	rr.responseWriter.WriteHeader(code)
 }
 
+func (rr *retryResponseWriterWithoutCloseNotify) isRetriedStatus(code int) bool {
+	for _, codeRange := range rr.statusCodeRanges {
+		if code >= codeRange[0] && code <= codeRange[1] {
+			return true
+		}
+	}
+	return false
+}
+
 func (rr *retryResponseWriterWithoutCloseNotify) Hijack() (net.Conn, *bufio.ReadWriter, error) {
 	return rr.responseWriter.(http.Hijacker).Hijack()
 }
 
 func (rr *retryResponseWriterWithoutCloseNotify) Flush() {
+	if rr.ShouldRetry() {
+		return
+	}
+	if !rr.written {
+		rr.WriteHeader(http.StatusOK)
+	}
 	if flusher, ok := rr.responseWriter.(http.Flusher); ok {
 		flusher.Flush()
 	}
@@ -6,7 +6,10 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestRetry(t *testing.T) {
@@ -152,3 +155,164 @@ func TestRetryWithFlush(t *testing.T) {
 		t.Errorf("Wrong body %q want %q", responseRecorder.Body.String(), "FULL DATA")
 	}
 }
+
+func TestRetryPolicy(t *testing.T) {
+	testCases := []struct {
+		desc             string
+		method           string
+		policy           RetryPolicy
+		statusCodes      []int
+		expectedStatus   int
+		expectedAttempts int
+	}{
+		{
+			desc:             "retried status code",
+			method:           http.MethodGet,
+			policy:           RetryPolicy{Attempts: 3, StatusCodeRanges: [][2]int{{502, 504}}},
+			statusCodes:      []int{http.StatusServiceUnavailable, http.StatusOK},
+			expectedStatus:   http.StatusOK,
+			expectedAttempts: 2,
+		},
+		{
+			desc:             "status code not retried",
+			method:           http.MethodGet,
+			policy:           RetryPolicy{Attempts: 3, StatusCodeRanges: [][2]int{{502, 502}}},
+			statusCodes:      []int{http.StatusServiceUnavailable, http.StatusOK},
+			expectedStatus:   http.StatusServiceUnavailable,
+			expectedAttempts: 1,
+		},
+		{
+			desc:             "attempts exhausted",
+			method:           http.MethodGet,
+			policy:           RetryPolicy{Attempts: 2, StatusCodeRanges: [][2]int{{500, 599}}},
+			statusCodes:      []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusOK},
+			expectedStatus:   http.StatusServiceUnavailable,
+			expectedAttempts: 2,
+		},
+		{
+			desc:             "non idempotent method",
+			method:           http.MethodPost,
+			policy:           RetryPolicy{Attempts: 3, StatusCodeRanges: [][2]int{{500, 599}}, IdempotentOnly: true},
+			statusCodes:      []int{http.StatusServiceUnavailable, http.StatusOK},
+			expectedStatus:   http.StatusServiceUnavailable,
+			expectedAttempts: 1,
+		},
+		{
+			desc:             "idempotent method",
+			method:           http.MethodPut,
+			policy:           RetryPolicy{Attempts: 3, StatusCodeRanges: [][2]int{{500, 599}}, IdempotentOnly: true},
+			statusCodes:      []int{http.StatusServiceUnavailable, http.StatusOK},
+			expectedStatus:   http.StatusOK,
+			expectedAttempts: 2,
+		},
+		{
+			desc:             "with backoff",
+			method:           http.MethodGet,
+			policy:           RetryPolicy{Attempts: 3, StatusCodeRanges: [][2]int{{500, 599}}, Backoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond},
+			statusCodes:      []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK},
+			expectedStatus:   http.StatusOK,
+			expectedAttempts: 3,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			attempts := 0
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				body, _ := ioutil.ReadAll(req.Body)
+				if string(body) != "payload" {
+					t.Errorf("got body %q for attempt %d, want %q", body, attempts+1, "payload")
+				}
+				rw.Header().Set("X-Attempt", strconv.Itoa(attempts+1))
+				rw.WriteHeader(test.statusCodes[attempts])
+				attempts++
+			})
+			listener := &countingRetryListener{}
+			retry := NewRetryWithPolicy(test.policy, next, listener)
+
+			recorder := httptest.NewRecorder()
+			req := httptest.NewRequest(test.method, "http://localhost/", strings.NewReader("payload"))
+			retry.ServeHTTP(recorder, req)
+
+			if recorder.Code != test.expectedStatus {
+				t.Errorf("got status code %d, want %d", recorder.Code, test.expectedStatus)
+			}
+			if attempts != test.expectedAttempts {
+				t.Errorf("got %d attempts, want %d", attempts, test.expectedAttempts)
+			}
+			if listener.timesCalled != test.expectedAttempts-1 {
+				t.Errorf("RetryListener called %d times, want %d times", listener.timesCalled, test.expectedAttempts-1)
+			}
+			// the headers of the discarded responses don't leak
+			if values := recorder.Header()["X-Attempt"]; len(values) != 1 || values[0] != strconv.Itoa(test.expectedAttempts) {
+				t.Errorf("got X-Attempt header %v, want [%d]", values, test.expectedAttempts)
+			}
+		})
+	}
+}
+
+func TestRetryPerTryTimeout(t *testing.T) {
+	attempts := 0
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		attempts++
+		if attempts == 1 {
+			// the first attempt times out, as a slow server would
+			<-req.Context().Done()
+			DefaultNetErrorRecorder{}.Record(req.Context())
+			rw.WriteHeader(http.StatusGatewayTimeout)
+			return
+		}
+		rw.WriteHeader(http.StatusOK)
+	})
+	retry := NewRetryWithPolicy(RetryPolicy{Attempts: 2, PerTryTimeout: 10 * time.Millisecond}, next, &countingRetryListener{})
+
+	recorder := httptest.NewRecorder()
+	retry.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "http://localhost/", nil))
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("got status code %d, want %d", recorder.Code, http.StatusOK)
+	}
+	if attempts != 2 {
+		t.Errorf("got %d attempts, want 2", attempts)
+	}
+}
+
+func TestRetryBudget(t *testing.T) {
+	budget := NewRetryBudget(10)
+	for i := 0; i < 20; i++ {
+		budget.requestStarted()
+	}
+
+	// at least minRetriesInFlight retries are allowed
+	for i := 0; i < minRetriesInFlight; i++ {
+		if !budget.allowRetry() {
+			t.Fatalf("retry %d not allowed, want it to be allowed", i+1)
+		}
+		budget.retryStarted()
+	}
+	if budget.allowRetry() {
+		t.Errorf("retry %d allowed, want the budget to be exhausted", minRetriesInFlight+1)
+	}
+
+	// 10% of 100 requests in flight
+	for i := 0; i < 80; i++ {
+		budget.requestStarted()
+	}
+	for i := minRetriesInFlight; i < 10; i++ {
+		if !budget.allowRetry() {
+			t.Fatalf("retry %d not allowed, want it to be allowed", i+1)
+		}
+		budget.retryStarted()
+	}
+	if budget.allowRetry() {
+		t.Errorf("retry 11 allowed, want the budget to be exhausted")
+	}
+
+	budget.retryDone()
+	if !budget.allowRetry() {
+		t.Errorf("retry not allowed after a retry is done, want it to be allowed")
+	}
+}
@@ -0,0 +1,137 @@
+package middlewares
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/containous/traefik/log"
+)
+
+type deadlineHeaderKey struct{}
+
+// Timeout limits the total duration of the requests of a frontend, retries included.
+// The context of a request taking longer is cancelled, which cancels the request to the server,
+// and the request is answered with a 504 status code.
+// If deadlineHeader is set, the remaining time of the request is sent to the server in this header,
+// and the same header of the incoming request, e.g. set by another proxy, shortens the timeout.
+type Timeout struct {
+	timeout        time.Duration
+	deadlineHeader string
+	next           http.Handler
+}
+
+// NewTimeout creates a new Timeout.
+func NewTimeout(timeout time.Duration, deadlineHeader string, next http.Handler) *Timeout {
+	return &Timeout{
+		timeout:        timeout,
+		deadlineHeader: deadlineHeader,
+		next:           next,
+	}
+}
+
+func (t *Timeout) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	timeout := t.timeout
+	if len(t.deadlineHeader) > 0 {
+		if remaining, err := strconv.ParseInt(req.Header.Get(t.deadlineHeader), 10, 64); err == nil && remaining >= 0 && time.Duration(remaining)*time.Millisecond < timeout {
+			timeout = time.Duration(remaining) * time.Millisecond
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), timeout)
+	defer cancel()
+	if len(t.deadlineHeader) > 0 {
+		ctx = context.WithValue(ctx, deadlineHeaderKey{}, t.deadlineHeader)
+	}
+
+	timeoutWriter := &timeoutResponseWriter{responseWriter: rw, ctx: ctx}
+	t.next.ServeHTTP(timeoutWriter, req.WithContext(ctx))
+
+	if ctx.Err() == context.DeadlineExceeded && !timeoutWriter.written {
+		log.Debugf("Request %v timed out after %s", req.URL, timeout)
+		http.Error(rw, http.StatusText(http.StatusGatewayTimeout), http.StatusGatewayTimeout)
+	}
+}
+
+// SetDeadlineHeader sets the deadline header of a request forwarded to a server to the remaining time of the request,
+// in milliseconds, if its frontend has a timeout with a deadline header.
+func SetDeadlineHeader(req *http.Request) {
+	header, ok := req.Context().Value(deadlineHeaderKey{}).(string)
+	if !ok {
+		return
+	}
+	deadline, ok := req.Context().Deadline()
+	if !ok {
+		return
+	}
+
+	remaining := time.Until(deadline) / time.Millisecond
+	if remaining < 0 {
+		remaining = 0
+	}
+	req.Header.Set(header, strconv.FormatInt(int64(remaining), 10))
+}
+
+// Compile time validation that the response writer implements http interfaces correctly.
+var _ Stateful = &timeoutResponseWriter{}
+
+// timeoutResponseWriter discards the error response of a request which timed out,
+// e.g. the 502 or 504 response of the forwarder whose request to the server has been cancelled,
+// so that the request is answered with a 504 status code.
+type timeoutResponseWriter struct {
+	responseWriter http.ResponseWriter
+	ctx            context.Context
+	written        bool
+	discarded      bool
+}
+
+func (tw *timeoutResponseWriter) Header() http.Header {
+	return tw.responseWriter.Header()
+}
+
+func (tw *timeoutResponseWriter) Write(buf []byte) (int, error) {
+	if !tw.written && !tw.discarded {
+		tw.WriteHeader(http.StatusOK)
+	}
+	if tw.discarded {
+		return len(buf), nil
+	}
+	return tw.responseWriter.Write(buf)
+}
+
+func (tw *timeoutResponseWriter) WriteHeader(code int) {
+	if tw.written || tw.discarded {
+		return
+	}
+	if code >= http.StatusInternalServerError && tw.ctx.Err() == context.DeadlineExceeded {
+		tw.discarded = true
+		return
+	}
+	tw.written = true
+	tw.responseWriter.WriteHeader(code)
+}
+
+func (tw *timeoutResponseWriter) Flush() {
+	if tw.discarded {
+		return
+	}
+	if flusher, ok := tw.responseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (tw *timeoutResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return tw.responseWriter.(http.Hijacker).Hijack()
+}
+
+// CloseNotify returns a channel that receives at most a
+// single value (true) when the client connection has gone away.
+func (tw *timeoutResponseWriter) CloseNotify() <-chan bool {
+	if closeNotifier, ok := tw.responseWriter.(http.CloseNotifier); ok {
+		return closeNotifier.CloseNotify()
+	}
+	return make(chan bool)
+}
@@ -0,0 +1,133 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeout(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		handler      http.HandlerFunc
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			desc: "fast response",
+			handler: func(rw http.ResponseWriter, req *http.Request) {
+				rw.Write([]byte("backend"))
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: "backend",
+		},
+		{
+			desc: "slow response",
+			handler: func(rw http.ResponseWriter, req *http.Request) {
+				<-req.Context().Done()
+			},
+			expectedCode: http.StatusGatewayTimeout,
+			expectedBody: http.StatusText(http.StatusGatewayTimeout) + "\n",
+		},
+		{
+			desc: "error response of the cancelled request",
+			handler: func(rw http.ResponseWriter, req *http.Request) {
+				<-req.Context().Done()
+				http.Error(rw, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+			},
+			expectedCode: http.StatusGatewayTimeout,
+			expectedBody: http.StatusText(http.StatusGatewayTimeout) + "\n",
+		},
+		{
+			desc: "response written before the timeout",
+			handler: func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusAccepted)
+				<-req.Context().Done()
+			},
+			expectedCode: http.StatusAccepted,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			timeout := NewTimeout(20*time.Millisecond, "", test.handler)
+
+			recorder := httptest.NewRecorder()
+			timeout.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "http://localhost/", nil))
+
+			assert.Equal(t, test.expectedCode, recorder.Code)
+			assert.Equal(t, test.expectedBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestTimeoutDeadlineHeader(t *testing.T) {
+	testCases := []struct {
+		desc           string
+		incomingHeader string
+		expectedMax    int64
+		expectedMin    int64
+	}{
+		{
+			desc:        "without incoming deadline",
+			expectedMax: 10000,
+			expectedMin: 9000,
+		},
+		{
+			desc:           "shorter incoming deadline",
+			incomingHeader: "2000",
+			expectedMax:    2000,
+			expectedMin:    1000,
+		},
+		{
+			desc:           "longer incoming deadline",
+			incomingHeader: "60000",
+			expectedMax:    10000,
+			expectedMin:    9000,
+		},
+		{
+			desc:           "invalid incoming deadline",
+			incomingHeader: "foo",
+			expectedMax:    10000,
+			expectedMin:    9000,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var remaining int64
+			timeout := NewTimeout(10*time.Second, "X-Request-Timeout", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				SetDeadlineHeader(req)
+				var err error
+				remaining, err = strconv.ParseInt(req.Header.Get("X-Request-Timeout"), 10, 64)
+				require.NoError(t, err)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "http://localhost/", nil)
+			if len(test.incomingHeader) > 0 {
+				req.Header.Set("X-Request-Timeout", test.incomingHeader)
+			}
+			timeout.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.True(t, remaining <= test.expectedMax && remaining >= test.expectedMin, "remaining time %d, expected between %d and %d", remaining, test.expectedMin, test.expectedMax)
+		})
+	}
+}
+
+func TestSetDeadlineHeaderWithoutTimeout(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "http://localhost/", nil)
+	SetDeadlineHeader(req)
+
+	assert.Empty(t, req.Header)
+}
@@ -0,0 +1,107 @@
+package middlewares
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/containous/traefik/server/cookie"
+)
+
+// TrafficSplitter is a handler forwarding the requests of a frontend to one of its backends,
+// in proportion to the weights of the backends (e.g. for canary releases).
+// When sticky, a cookie holding an opaque value keeps a client on the backend it was first forwarded to.
+type TrafficSplitter struct {
+	backends      []*splitBackend
+	cookieName    string
+	cookieOptions cookie.Options
+	lock          sync.Mutex
+}
+
+type splitBackend struct {
+	name    string
+	weight  int
+	handler http.Handler
+	// value of the sticky cookie
+	cookieValue string
+	// current weight of the smooth weighted round robin
+	current int
+}
+
+// NewTrafficSplitter creates a new TrafficSplitter without backends.
+// The cookie name is empty when the splitter isn't sticky.
+func NewTrafficSplitter(cookieName string, cookieOptions cookie.Options) *TrafficSplitter {
+	return &TrafficSplitter{cookieName: cookieName, cookieOptions: cookieOptions}
+}
+
+// AddBackend adds a backend receiving a share of the requests proportional to its weight.
+// A backend with a null weight doesn't receive any request.
+func (t *TrafficSplitter) AddBackend(name string, weight int, handler http.Handler) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.backends = append(t.backends, &splitBackend{
+		name:        name,
+		weight:      weight,
+		handler:     handler,
+		cookieValue: t.cookieOptions.Value(name),
+	})
+}
+
+// ServeHTTP forwards the request to the backend from the cookie if any, and otherwise to the next backend
+// of a smooth weighted round robin, which spreads the requests of the backends evenly over time.
+func (t *TrafficSplitter) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if t.cookieName != "" {
+		if cookie, err := req.Cookie(t.cookieName); err == nil {
+			if backend := t.find(cookie.Value); backend != nil {
+				backend.handler.ServeHTTP(rw, req)
+				return
+			}
+		}
+	}
+
+	backend := t.next()
+	if backend == nil {
+		rw.WriteHeader(http.StatusServiceUnavailable)
+		rw.Write([]byte(http.StatusText(http.StatusServiceUnavailable)))
+		return
+	}
+
+	if t.cookieName != "" {
+		t.cookieOptions.Set(rw, t.cookieName, backend.cookieValue)
+	}
+	backend.handler.ServeHTTP(rw, req)
+}
+
+func (t *TrafficSplitter) find(cookieValue string) *splitBackend {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for _, backend := range t.backends {
+		if backend.cookieValue == cookieValue && backend.weight > 0 {
+			return backend
+		}
+	}
+	return nil
+}
+
+func (t *TrafficSplitter) next() *splitBackend {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	var selected *splitBackend
+	total := 0
+	for _, backend := range t.backends {
+		if backend.weight <= 0 {
+			continue
+		}
+		backend.current += backend.weight
+		total += backend.weight
+		if selected == nil || backend.current > selected.current {
+			selected = backend
+		}
+	}
+	if selected != nil {
+		selected.current -= total
+	}
+	return selected
+}
@@ -0,0 +1,107 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/containous/traefik/server/cookie"
+	"github.com/containous/traefik/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func backendNameHandler(name string) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte(name))
+	})
+}
+
+func TestTrafficSplitterWeights(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		weights  map[string]int
+		expected map[string]int
+	}{
+		{
+			desc:     "canary",
+			weights:  map[string]int{"v1": 9, "v2": 1},
+			expected: map[string]int{"v1": 90, "v2": 10},
+		},
+		{
+			desc:     "even",
+			weights:  map[string]int{"blue": 1, "green": 1},
+			expected: map[string]int{"blue": 50, "green": 50},
+		},
+		{
+			desc:     "null weight",
+			weights:  map[string]int{"blue": 0, "green": 1},
+			expected: map[string]int{"green": 100},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			splitter := NewTrafficSplitter("", cookie.Options{})
+			for name, weight := range test.weights {
+				splitter.AddBackend(name, weight, backendNameHandler(name))
+			}
+
+			counts := map[string]int{}
+			for i := 0; i < 100; i++ {
+				recorder := httptest.NewRecorder()
+				splitter.ServeHTTP(recorder, testhelpers.MustNewRequest(http.MethodGet, "http://frontend", nil))
+				counts[recorder.Body.String()]++
+				assert.Empty(t, recorder.Header().Get("Set-Cookie"))
+			}
+			assert.Equal(t, test.expected, counts)
+		})
+	}
+}
+
+func TestTrafficSplitterSticky(t *testing.T) {
+	options := cookie.Options{Secret: "secret", HTTPOnly: true}
+	splitter := NewTrafficSplitter("canary", options)
+	splitter.AddBackend("v1", 1, backendNameHandler("v1"))
+	splitter.AddBackend("v2", 1, backendNameHandler("v2"))
+	splitter.AddBackend("v3", 0, backendNameHandler("v3"))
+
+	recorder := httptest.NewRecorder()
+	splitter.ServeHTTP(recorder, testhelpers.MustNewRequest(http.MethodGet, "http://frontend", nil))
+	cookies := recorder.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, "canary", cookies[0].Name)
+	assert.Equal(t, options.Value(recorder.Body.String()), cookies[0].Value)
+	assert.True(t, cookies[0].HttpOnly)
+
+	for i := 0; i < 5; i++ {
+		recorder := httptest.NewRecorder()
+		req := testhelpers.MustNewRequest(http.MethodGet, "http://frontend", nil)
+		req.AddCookie(cookies[0])
+		splitter.ServeHTTP(recorder, req)
+		assert.Equal(t, cookies[0].Value, options.Value(recorder.Body.String()))
+		assert.Empty(t, recorder.Header().Get("Set-Cookie"))
+	}
+
+	// the cookies of unknown backends, of backends without weight, or holding a plain backend name, are replaced
+	for _, value := range []string{options.Value("v3"), options.Value("unknown"), "v1"} {
+		recorder := httptest.NewRecorder()
+		req := testhelpers.MustNewRequest(http.MethodGet, "http://frontend", nil)
+		req.AddCookie(&http.Cookie{Name: "canary", Value: value})
+		splitter.ServeHTTP(recorder, req)
+		assert.NotEqual(t, value, options.Value(recorder.Body.String()))
+		assert.Contains(t, recorder.Header().Get("Set-Cookie"), "canary="+options.Value(recorder.Body.String()))
+	}
+}
+
+func TestTrafficSplitterNoBackend(t *testing.T) {
+	splitter := NewTrafficSplitter("", cookie.Options{})
+	splitter.AddBackend("v1", 0, backendNameHandler("v1"))
+
+	recorder := httptest.NewRecorder()
+	splitter.ServeHTTP(recorder, testhelpers.MustNewRequest(http.MethodGet, "http://frontend", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+}
@@ -40,6 +40,9 @@ func (p *Provider) buildConfiguration(catalog []catalogUpdate) *types.Configurat
 		"getLoadBalancer":         p.getLoadBalancer,
 		"getMaxConn":              p.getMaxConn,
 		"getHealthCheck":          p.getHealthCheck,
+		"getTransport":            p.getTransport,
+		"getRetry":                p.getRetry,
+		"getHedging":              p.getHedging,
 		"getBuffering":            p.getBuffering,
 
 		// Frontend functions
@@ -260,6 +263,20 @@ func (p *Provider) getLoadBalancer(tags []string) *types.LoadBalancer {
 	if p.getBoolAttribute(label.SuffixBackendLoadBalancerStickiness, tags, false) {
 		lb.Stickiness = &types.Stickiness{
 			CookieName: p.getAttribute(label.SuffixBackendLoadBalancerStickinessCookieName, tags, ""),
+			Secret:     p.getAttribute(label.SuffixBackendLoadBalancerStickinessSecret, tags, ""),
+			Secure:     p.getBoolAttribute(label.SuffixBackendLoadBalancerStickinessSecure, tags, false),
+			HTTPOnly:   p.getBoolAttribute(label.SuffixBackendLoadBalancerStickinessHTTPOnly, tags, false),
+			SameSite:   p.getAttribute(label.SuffixBackendLoadBalancerStickinessSameSite, tags, ""),
+			MaxAge:     p.getIntAttribute(label.SuffixBackendLoadBalancerStickinessMaxAge, tags, 0),
+			Domain:     p.getAttribute(label.SuffixBackendLoadBalancerStickinessDomain, tags, ""),
+			Path:       p.getAttribute(label.SuffixBackendLoadBalancerStickinessPath, tags, ""),
+		}
+	}
+
+	if p.hasAttributePrefix(label.SuffixBackendLoadBalancerHash, tags) {
+		lb.Hash = &types.ConsistentHash{
+			Key:        p.getAttribute(label.SuffixBackendLoadBalancerHashKey, tags, ""),
+			LoadFactor: p.getFloat64Attribute(label.SuffixBackendLoadBalancerHashLoadFactor, tags, 0),
 		}
 	}
 
@@ -282,8 +299,9 @@ func (p *Provider) getMaxConn(tags []string) *types.MaxConn {
 
 func (p *Provider) getHealthCheck(tags []string) *types.HealthCheck {
 	path := p.getAttribute(label.SuffixBackendHealthCheckPath, tags, "")
+	hcType := p.getAttribute(label.SuffixBackendHealthCheckType, tags, "")
 
-	if len(path) == 0 {
+	if len(path) == 0 && len(hcType) == 0 {
 		return nil
 	}
 
@@ -291,9 +309,20 @@ func (p *Provider) getHealthCheck(tags []string) *types.HealthCheck {
 	interval := p.getAttribute(label.SuffixBackendHealthCheckInterval, tags, "")
 
 	return &types.HealthCheck{
-		Path:     path,
-		Port:     port,
-		Interval: interval,
+		Type:        hcType,
+		Path:        path,
+		Port:        port,
+		Interval:    interval,
+		Timeout:     p.getAttribute(label.SuffixBackendHealthCheckTimeout, tags, ""),
+		Scheme:      p.getAttribute(label.SuffixBackendHealthCheckScheme, tags, ""),
+		Method:      p.getAttribute(label.SuffixBackendHealthCheckMethod, tags, ""),
+		Hostname:    p.getAttribute(label.SuffixBackendHealthCheckHostname, tags, ""),
+		Headers:     p.getMapAttribute(label.SuffixBackendHealthCheckHeaders, tags),
+		StatusCodes: p.getSliceAttribute(label.SuffixBackendHealthCheckStatusCodes, tags),
+		Body:        p.getAttribute(label.SuffixBackendHealthCheckBody, tags, ""),
+		Rise:        p.getIntAttribute(label.SuffixBackendHealthCheckRise, tags, 0),
+		Fall:        p.getIntAttribute(label.SuffixBackendHealthCheckFall, tags, 0),
+		GRPCService: p.getAttribute(label.SuffixBackendHealthCheckGRPCService, tags, ""),
 	}
 }
 
@@ -311,6 +340,60 @@ func (p *Provider) getBuffering(tags []string) *types.Buffering {
 	}
 }
 
+func (p *Provider) getTransport(tags []string) *types.Transport {
+	if !p.hasAttributePrefix(label.SuffixBackendTransport, tags) {
+		return nil
+	}
+
+	transport := &types.Transport{
+		DialTimeout:           p.getAttribute(label.SuffixBackendTransportDialTimeout, tags, ""),
+		ResponseHeaderTimeout: p.getAttribute(label.SuffixBackendTransportResponseHeaderTimeout, tags, ""),
+		IdleConnTimeout:       p.getAttribute(label.SuffixBackendTransportIdleConnTimeout, tags, ""),
+		MaxIdleConnsPerHost:   p.getIntAttribute(label.SuffixBackendTransportMaxIdleConnsPerHost, tags, 0),
+		DisableKeepAlives:     p.getBoolAttribute(label.SuffixBackendTransportDisableKeepAlives, tags, false),
+		DisableHTTP2:          p.getBoolAttribute(label.SuffixBackendTransportDisableHTTP2, tags, false),
+	}
+
+	if p.hasAttributePrefix(label.SuffixBackendTransportTLS, tags) {
+		transport.TLS = &types.TransportTLS{
+			CA:                 p.getAttribute(label.SuffixBackendTransportTLSCA, tags, ""),
+			ServerName:         p.getAttribute(label.SuffixBackendTransportTLSServerName, tags, ""),
+			Cert:               p.getAttribute(label.SuffixBackendTransportTLSCert, tags, ""),
+			Key:                p.getAttribute(label.SuffixBackendTransportTLSKey, tags, ""),
+			InsecureSkipVerify: p.getBoolAttribute(label.SuffixBackendTransportTLSInsecureSkipVerify, tags, false),
+		}
+	}
+
+	return transport
+}
+
+func (p *Provider) getRetry(tags []string) *types.Retry {
+	if !p.hasAttributePrefix(label.SuffixBackendRetry, tags) {
+		return nil
+	}
+
+	return &types.Retry{
+		Attempts:       p.getIntAttribute(label.SuffixBackendRetryAttempts, tags, 0),
+		StatusCodes:    p.getSliceAttribute(label.SuffixBackendRetryStatusCodes, tags),
+		IdempotentOnly: p.getBoolAttribute(label.SuffixBackendRetryIdempotentOnly, tags, false),
+		PerTryTimeout:  p.getAttribute(label.SuffixBackendRetryPerTryTimeout, tags, ""),
+		Backoff:        p.getAttribute(label.SuffixBackendRetryBackoff, tags, ""),
+		MaxBackoff:     p.getAttribute(label.SuffixBackendRetryMaxBackoff, tags, ""),
+		BudgetPercent:  p.getIntAttribute(label.SuffixBackendRetryBudgetPercent, tags, 0),
+	}
+}
+
+func (p *Provider) getHedging(tags []string) *types.Hedging {
+	if !p.hasAttributePrefix(label.SuffixBackendHedging, tags) {
+		return nil
+	}
+
+	return &types.Hedging{
+		Delay:      p.getAttribute(label.SuffixBackendHedgingDelay, tags, ""),
+		Percentile: p.getIntAttribute(label.SuffixBackendHedgingPercentile, tags, 0),
+	}
+}
+
 func (p *Provider) getRedirect(tags []string) *types.Redirect {
 	permanent := p.getBoolAttribute(label.SuffixFrontendRedirectPermanent, tags, false)
 
@@ -481,6 +564,21 @@ func (p *Provider) getIntAttribute(name string, tags []string, defaultValue int)
 	return value
 }
 
+func (p *Provider) getFloat64Attribute(name string, tags []string, defaultValue float64) float64 {
+	rawValue := getTag(p.getPrefixedName(name), tags, "")
+
+	if len(rawValue) == 0 {
+		return defaultValue
+	}
+
+	value, err := strconv.ParseFloat(rawValue, 64)
+	if err != nil {
+		log.Errorf("Invalid value for %s: %s", name, rawValue)
+		return defaultValue
+	}
+	return value
+}
+
 func (p *Provider) getSliceAttribute(name string, tags []string) []string {
 	rawValue := getTag(p.getPrefixedName(name), tags, "")
 
@@ -863,6 +863,31 @@ func TestProviderGetLoadBalancer(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "should return a struct with the cookie attributes when has Stickiness tags",
+			tags: []string{
+				label.Prefix + label.SuffixBackendLoadBalancerStickiness + "=true",
+				label.Prefix + label.SuffixBackendLoadBalancerStickinessSecret + "=s3cr3t",
+				label.Prefix + label.SuffixBackendLoadBalancerStickinessSecure + "=true",
+				label.Prefix + label.SuffixBackendLoadBalancerStickinessHTTPOnly + "=true",
+				label.Prefix + label.SuffixBackendLoadBalancerStickinessSameSite + "=strict",
+				label.Prefix + label.SuffixBackendLoadBalancerStickinessMaxAge + "=3600",
+				label.Prefix + label.SuffixBackendLoadBalancerStickinessDomain + "=example.com",
+				label.Prefix + label.SuffixBackendLoadBalancerStickinessPath + "=/app",
+			},
+			expected: &types.LoadBalancer{
+				Method: "wrr",
+				Stickiness: &types.Stickiness{
+					Secret:   "s3cr3t",
+					Secure:   true,
+					HTTPOnly: true,
+					SameSite: "strict",
+					MaxAge:   3600,
+					Domain:   "example.com",
+					Path:     "/app",
+				},
+			},
+		},
 		{
 			desc: "should skip Stickiness when Stickiness tag has false as value",
 			tags: []string{
@@ -873,6 +898,21 @@ func TestProviderGetLoadBalancer(t *testing.T) {
 				Method: "wrr",
 			},
 		},
+		{
+			desc: "should return a struct when has Hash tags",
+			tags: []string{
+				label.Prefix + label.SuffixBackendLoadBalancerMethod + "=hash",
+				label.Prefix + label.SuffixBackendLoadBalancerHashKey + "=request.path",
+				label.Prefix + label.SuffixBackendLoadBalancerHashLoadFactor + "=1.5",
+			},
+			expected: &types.LoadBalancer{
+				Method: "hash",
+				Hash: &types.ConsistentHash{
+					Key:        "request.path",
+					LoadFactor: 1.5,
+				},
+			},
+		},
 	}
 
 	for _, test := range testCases {
@@ -988,6 +1028,52 @@ func TestProviderGetHealthCheck(t *testing.T) {
 				Interval: "7",
 			},
 		},
+		{
+			desc: "should return a struct when only the health check type is set",
+			tags: []string{
+				label.TraefikBackendHealthCheckType + "=grpc",
+				label.TraefikBackendHealthCheckGRPCService + "=foo.Bar",
+				label.TraefikBackendHealthCheckPort + "=80",
+				label.TraefikBackendHealthCheckInterval + "=7",
+			},
+			expected: &types.HealthCheck{
+				Type:        "grpc",
+				GRPCService: "foo.Bar",
+				Port:        80,
+				Interval:    "7",
+			},
+		},
+		{
+			desc: "should return a struct with the request and response settings",
+			tags: []string{
+				label.TraefikBackendHealthCheckPath + "=/health",
+				label.TraefikBackendHealthCheckPort + "=80",
+				label.TraefikBackendHealthCheckInterval + "=7",
+				label.TraefikBackendHealthCheckTimeout + "=3s",
+				label.TraefikBackendHealthCheckScheme + "=https",
+				label.TraefikBackendHealthCheckMethod + "=HEAD",
+				label.TraefikBackendHealthCheckHostname + "=foo.localhost",
+				label.TraefikBackendHealthCheckHeaders + "=X-Health:1",
+				label.TraefikBackendHealthCheckStatusCodes + "=200-299,304",
+				label.TraefikBackendHealthCheckBody + "=ok",
+				label.TraefikBackendHealthCheckRise + "=3",
+				label.TraefikBackendHealthCheckFall + "=2",
+			},
+			expected: &types.HealthCheck{
+				Path:        "/health",
+				Port:        80,
+				Interval:    "7",
+				Timeout:     "3s",
+				Scheme:      "https",
+				Method:      "HEAD",
+				Hostname:    "foo.localhost",
+				Headers:     map[string]string{"X-Health": "1"},
+				StatusCodes: []string{"200-299", "304"},
+				Body:        "ok",
+				Rise:        3,
+				Fall:        2,
+			},
+		},
 	}
 
 	for _, test := range testCases {
@@ -1048,6 +1134,140 @@ func TestProviderGetBuffering(t *testing.T) {
 	}
 }
 
+func TestProviderGetTransport(t *testing.T) {
+	p := &Provider{
+		Prefix: "traefik",
+	}
+
+	testCases := []struct {
+		desc     string
+		tags     []string
+		expected *types.Transport
+	}{
+		{
+			desc:     "should return nil when no tags",
+			tags:     []string{},
+			expected: nil,
+		},
+		{
+			desc: "should return a struct when has proper tags",
+			tags: []string{
+				label.TraefikBackendTransportDialTimeout + "=5s",
+				label.TraefikBackendTransportMaxIdleConnsPerHost + "=50",
+				label.TraefikBackendTransportDisableHTTP2 + "=true",
+				label.TraefikBackendTransportTLSCA + "=/certs/ca.pem",
+				label.TraefikBackendTransportTLSInsecureSkipVerify + "=true",
+			},
+			expected: &types.Transport{
+				DialTimeout:         "5s",
+				MaxIdleConnsPerHost: 50,
+				DisableHTTP2:        true,
+				TLS: &types.TransportTLS{
+					CA:                 "/certs/ca.pem",
+					InsecureSkipVerify: true,
+				},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			result := p.getTransport(test.tags)
+
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestProviderGetRetry(t *testing.T) {
+	p := &Provider{
+		Prefix: "traefik",
+	}
+
+	testCases := []struct {
+		desc     string
+		tags     []string
+		expected *types.Retry
+	}{
+		{
+			desc:     "should return nil when no tags",
+			tags:     []string{},
+			expected: nil,
+		},
+		{
+			desc: "should return a struct when has proper tags",
+			tags: []string{
+				label.TraefikBackendRetryAttempts + "=3",
+				label.TraefikBackendRetryStatusCodes + "=502,503-504",
+				label.TraefikBackendRetryIdempotentOnly + "=true",
+				label.TraefikBackendRetryPerTryTimeout + "=2s",
+				label.TraefikBackendRetryBudgetPercent + "=20",
+			},
+			expected: &types.Retry{
+				Attempts:       3,
+				StatusCodes:    []string{"502", "503-504"},
+				IdempotentOnly: true,
+				PerTryTimeout:  "2s",
+				BudgetPercent:  20,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			result := p.getRetry(test.tags)
+
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestProviderGetHedging(t *testing.T) {
+	p := &Provider{
+		Prefix: "traefik",
+	}
+
+	testCases := []struct {
+		desc     string
+		tags     []string
+		expected *types.Hedging
+	}{
+		{
+			desc:     "should return nil when no tags",
+			tags:     []string{},
+			expected: nil,
+		},
+		{
+			desc: "should return a struct when has proper tags",
+			tags: []string{
+				label.TraefikBackendHedgingDelay + "=50ms",
+				label.TraefikBackendHedgingPercentile + "=95",
+			},
+			expected: &types.Hedging{
+				Delay:      "50ms",
+				Percentile: 95,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			result := p.getHedging(test.tags)
+
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
 func TestProviderGetRedirect(t *testing.T) {
 	p := &Provider{
 		Prefix: "traefik",
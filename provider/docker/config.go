@@ -18,15 +18,19 @@ func (p *Provider) buildConfiguration(containersInspected []dockerData) *types.C
 		"isBackendLBSwarm": isBackendLBSwarm, // FIXME dead ?
 
 		// Backend functions
-		"getIPAddress":      p.getIPAddress,
-		"getPort":           getPort,
-		"getWeight":         getFuncIntLabel(label.TraefikWeight, label.DefaultWeightInt),
-		"getProtocol":       getFuncStringLabel(label.TraefikProtocol, label.DefaultProtocol),
-		"getMaxConn":        getMaxConn,
-		"getHealthCheck":    getHealthCheck,
-		"getBuffering":      getBuffering,
-		"getCircuitBreaker": getCircuitBreaker,
-		"getLoadBalancer":   getLoadBalancer,
+		"getIPAddress":       p.getIPAddress,
+		"getPort":            getPort,
+		"getWeight":          getFuncIntLabel(label.TraefikWeight, label.DefaultWeightInt),
+		"getProtocol":        getFuncStringLabel(label.TraefikProtocol, label.DefaultProtocol),
+		"getMaxConn":         getMaxConn,
+		"getHealthCheck":     getHealthCheck,
+		"getBuffering":       getBuffering,
+		"getTransport":       getTransport,
+		"getRetry":           getRetry,
+		"getHedging":         getHedging,
+		"getBackendProtocol": getFuncStringLabel(label.TraefikBackendProtocol, ""),
+		"getCircuitBreaker":  getCircuitBreaker,
+		"getLoadBalancer":    getLoadBalancer,
 
 		// TODO Deprecated [breaking]
 		"hasCircuitBreakerLabel": hasFunc(label.TraefikBackendCircuitBreakerExpression),
@@ -64,6 +68,7 @@ func (p *Provider) buildConfiguration(containersInspected []dockerData) *types.C
 		"getErrorPages": getErrorPages,
 		"getRateLimit":  getRateLimit,
 		"getHeaders":    getHeaders,
+		"getSplit":      getSplit,
 
 		// Services
 		"hasServices":           hasServices,
@@ -172,8 +172,23 @@ func getLoadBalancer(container dockerData) *types.LoadBalancer {
 	}
 
 	if label.GetBoolValue(container.Labels, label.TraefikBackendLoadBalancerStickiness, false) {
-		cookieName := label.GetStringValue(container.Labels, label.TraefikBackendLoadBalancerStickinessCookieName, label.DefaultBackendLoadbalancerStickinessCookieName)
-		lb.Stickiness = &types.Stickiness{CookieName: cookieName}
+		lb.Stickiness = &types.Stickiness{
+			CookieName: label.GetStringValue(container.Labels, label.TraefikBackendLoadBalancerStickinessCookieName, label.DefaultBackendLoadbalancerStickinessCookieName),
+			Secret:     label.GetStringValue(container.Labels, label.TraefikBackendLoadBalancerStickinessSecret, ""),
+			Secure:     label.GetBoolValue(container.Labels, label.TraefikBackendLoadBalancerStickinessSecure, false),
+			HTTPOnly:   label.GetBoolValue(container.Labels, label.TraefikBackendLoadBalancerStickinessHTTPOnly, false),
+			SameSite:   label.GetStringValue(container.Labels, label.TraefikBackendLoadBalancerStickinessSameSite, ""),
+			MaxAge:     label.GetIntValue(container.Labels, label.TraefikBackendLoadBalancerStickinessMaxAge, 0),
+			Domain:     label.GetStringValue(container.Labels, label.TraefikBackendLoadBalancerStickinessDomain, ""),
+			Path:       label.GetStringValue(container.Labels, label.TraefikBackendLoadBalancerStickinessPath, ""),
+		}
+	}
+
+	if label.HasPrefix(container.Labels, label.TraefikBackendLoadBalancerHash) {
+		lb.Hash = &types.ConsistentHash{
+			Key:        label.GetStringValue(container.Labels, label.TraefikBackendLoadBalancerHashKey, ""),
+			LoadFactor: label.GetFloat64Value(container.Labels, label.TraefikBackendLoadBalancerHashLoadFactor, 0),
+		}
 	}
 
 	return lb
@@ -200,7 +215,8 @@ func getCircuitBreaker(container dockerData) *types.CircuitBreaker {
 
 func getHealthCheck(container dockerData) *types.HealthCheck {
 	path := label.GetStringValue(container.Labels, label.TraefikBackendHealthCheckPath, "")
-	if len(path) == 0 {
+	hcType := label.GetStringValue(container.Labels, label.TraefikBackendHealthCheckType, "")
+	if len(path) == 0 && len(hcType) == 0 {
 		return nil
 	}
 
@@ -208,9 +224,20 @@ func getHealthCheck(container dockerData) *types.HealthCheck {
 	interval := label.GetStringValue(container.Labels, label.TraefikBackendHealthCheckInterval, "")
 
 	return &types.HealthCheck{
-		Path:     path,
-		Port:     port,
-		Interval: interval,
+		Type:        hcType,
+		Path:        path,
+		Port:        port,
+		Interval:    interval,
+		Timeout:     label.GetStringValue(container.Labels, label.TraefikBackendHealthCheckTimeout, ""),
+		Scheme:      label.GetStringValue(container.Labels, label.TraefikBackendHealthCheckScheme, ""),
+		Method:      label.GetStringValue(container.Labels, label.TraefikBackendHealthCheckMethod, ""),
+		Hostname:    label.GetStringValue(container.Labels, label.TraefikBackendHealthCheckHostname, ""),
+		Headers:     label.GetMapValue(container.Labels, label.TraefikBackendHealthCheckHeaders),
+		StatusCodes: label.GetSliceStringValue(container.Labels, label.TraefikBackendHealthCheckStatusCodes),
+		Body:        label.GetStringValue(container.Labels, label.TraefikBackendHealthCheckBody, ""),
+		Rise:        label.GetIntValue(container.Labels, label.TraefikBackendHealthCheckRise, 0),
+		Fall:        label.GetIntValue(container.Labels, label.TraefikBackendHealthCheckFall, 0),
+		GRPCService: label.GetStringValue(container.Labels, label.TraefikBackendHealthCheckGRPCService, ""),
 	}
 }
 
@@ -228,6 +255,60 @@ func getBuffering(container dockerData) *types.Buffering {
 	}
 }
 
+func getTransport(container dockerData) *types.Transport {
+	if !label.HasPrefix(container.Labels, label.TraefikBackendTransport) {
+		return nil
+	}
+
+	transport := &types.Transport{
+		DialTimeout:           label.GetStringValue(container.Labels, label.TraefikBackendTransportDialTimeout, ""),
+		ResponseHeaderTimeout: label.GetStringValue(container.Labels, label.TraefikBackendTransportResponseHeaderTimeout, ""),
+		IdleConnTimeout:       label.GetStringValue(container.Labels, label.TraefikBackendTransportIdleConnTimeout, ""),
+		MaxIdleConnsPerHost:   label.GetIntValue(container.Labels, label.TraefikBackendTransportMaxIdleConnsPerHost, 0),
+		DisableKeepAlives:     label.GetBoolValue(container.Labels, label.TraefikBackendTransportDisableKeepAlives, false),
+		DisableHTTP2:          label.GetBoolValue(container.Labels, label.TraefikBackendTransportDisableHTTP2, false),
+	}
+
+	if label.HasPrefix(container.Labels, label.TraefikBackendTransportTLS) {
+		transport.TLS = &types.TransportTLS{
+			CA:                 label.GetStringValue(container.Labels, label.TraefikBackendTransportTLSCA, ""),
+			ServerName:         label.GetStringValue(container.Labels, label.TraefikBackendTransportTLSServerName, ""),
+			Cert:               label.GetStringValue(container.Labels, label.TraefikBackendTransportTLSCert, ""),
+			Key:                label.GetStringValue(container.Labels, label.TraefikBackendTransportTLSKey, ""),
+			InsecureSkipVerify: label.GetBoolValue(container.Labels, label.TraefikBackendTransportTLSInsecureSkipVerify, false),
+		}
+	}
+
+	return transport
+}
+
+func getRetry(container dockerData) *types.Retry {
+	if !label.HasPrefix(container.Labels, label.TraefikBackendRetry) {
+		return nil
+	}
+
+	return &types.Retry{
+		Attempts:       label.GetIntValue(container.Labels, label.TraefikBackendRetryAttempts, 0),
+		StatusCodes:    label.GetSliceStringValue(container.Labels, label.TraefikBackendRetryStatusCodes),
+		IdempotentOnly: label.GetBoolValue(container.Labels, label.TraefikBackendRetryIdempotentOnly, false),
+		PerTryTimeout:  label.GetStringValue(container.Labels, label.TraefikBackendRetryPerTryTimeout, ""),
+		Backoff:        label.GetStringValue(container.Labels, label.TraefikBackendRetryBackoff, ""),
+		MaxBackoff:     label.GetStringValue(container.Labels, label.TraefikBackendRetryMaxBackoff, ""),
+		BudgetPercent:  label.GetIntValue(container.Labels, label.TraefikBackendRetryBudgetPercent, 0),
+	}
+}
+
+func getHedging(container dockerData) *types.Hedging {
+	if !label.HasPrefix(container.Labels, label.TraefikBackendHedging) {
+		return nil
+	}
+
+	return &types.Hedging{
+		Delay:      label.GetStringValue(container.Labels, label.TraefikBackendHedgingDelay, ""),
+		Percentile: label.GetIntValue(container.Labels, label.TraefikBackendHedgingPercentile, 0),
+	}
+}
+
 func getRedirect(container dockerData) *types.Redirect {
 	permanent := label.GetBoolValue(container.Labels, label.TraefikFrontendRedirectPermanent, false)
 
@@ -270,6 +351,25 @@ func getRateLimit(container dockerData) *types.RateLimit {
 	}
 }
 
+func getSplit(container dockerData) *types.TrafficSplit {
+	backends := label.GetWeightedBackends(container.Labels, label.TraefikFrontendSplitBackends)
+	if len(backends) == 0 {
+		return nil
+	}
+
+	for i := range backends {
+		backends[i].Backend = provider.Normalize(backends[i].Backend)
+	}
+	split := &types.TrafficSplit{Backends: backends}
+
+	if label.GetBoolValue(container.Labels, label.TraefikFrontendSplitStickiness, false) {
+		cookieName := label.GetStringValue(container.Labels, label.TraefikFrontendSplitStickinessCookieName, "")
+		split.Stickiness = &types.Stickiness{CookieName: cookieName}
+	}
+
+	return split
+}
+
 func getHeaders(container dockerData) *types.Headers {
 	headers := &types.Headers{
 		CustomRequestHeaders:    label.GetMapValue(container.Labels, label.TraefikFrontendRequestHeaders),
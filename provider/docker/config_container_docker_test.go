@@ -106,6 +106,15 @@ func TestDockerBuildConfiguration(t *testing.T) {
 						label.TraefikBackendLoadBalancerSticky:               "true",
 						label.TraefikBackendLoadBalancerStickiness:           "true",
 						label.TraefikBackendLoadBalancerStickinessCookieName: "chocolate",
+						label.TraefikBackendLoadBalancerStickinessSecret:     "s3cr3t",
+						label.TraefikBackendLoadBalancerStickinessSecure:     "true",
+						label.TraefikBackendLoadBalancerStickinessHTTPOnly:   "true",
+						label.TraefikBackendLoadBalancerStickinessSameSite:   "lax",
+						label.TraefikBackendLoadBalancerStickinessMaxAge:     "3600",
+						label.TraefikBackendLoadBalancerStickinessDomain:     "example.com",
+						label.TraefikBackendLoadBalancerStickinessPath:       "/app",
+						label.TraefikBackendLoadBalancerHashKey:              "request.header.X-User",
+						label.TraefikBackendLoadBalancerHashLoadFactor:       "1.5",
 						label.TraefikBackendMaxConnAmount:                    "666",
 						label.TraefikBackendMaxConnExtractorFunc:             "client.ip",
 						label.TraefikBackendBufferingMaxResponseBodyBytes:    "10485760",
@@ -113,6 +122,15 @@ func TestDockerBuildConfiguration(t *testing.T) {
 						label.TraefikBackendBufferingMaxRequestBodyBytes:     "10485760",
 						label.TraefikBackendBufferingMemRequestBodyBytes:     "2097152",
 						label.TraefikBackendBufferingRetryExpression:         "IsNetworkError() && Attempts() <= 2",
+						label.TraefikBackendProtocol:                         "h2c",
+						label.TraefikBackendTransportDialTimeout:             "5s",
+						label.TraefikBackendTransportMaxIdleConnsPerHost:     "50",
+						label.TraefikBackendTransportDisableHTTP2:            "true",
+						label.TraefikBackendTransportTLSCA:                   "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----",
+						label.TraefikBackendTransportTLSServerName:           "backend.example.com",
+						label.TraefikBackendRetryAttempts:                    "3",
+						label.TraefikBackendRetryStatusCodes:                 "502,503-504",
+						label.TraefikBackendRetryBackoff:                     "100ms",
 
 						label.TraefikFrontendAuthBasic:            "test:$apr1$H6uskkkW$IgXLP6ewTrSuBkTrqE8wj/,test2:$apr1$d9hr9HBB$4HxwgUir3HP4EsggP/QNo0",
 						label.TraefikFrontendEntryPoints:          "http,https",
@@ -161,6 +179,10 @@ func TestDockerBuildConfiguration(t *testing.T) {
 						label.Prefix + label.BaseFrontendRateLimit + "bar." + label.SuffixRateLimitPeriod:  "3",
 						label.Prefix + label.BaseFrontendRateLimit + "bar." + label.SuffixRateLimitAverage: "6",
 						label.Prefix + label.BaseFrontendRateLimit + "bar." + label.SuffixRateLimitBurst:   "9",
+
+						label.TraefikFrontendSplitBackends:             "foobar:9, canary:1",
+						label.TraefikFrontendSplitStickiness:           "true",
+						label.TraefikFrontendSplitStickinessCookieName: "canary",
 					}),
 					ports(nat.PortMap{
 						"80/tcp": {},
@@ -256,6 +278,13 @@ func TestDockerBuildConfiguration(t *testing.T) {
 							},
 						},
 					},
+					Split: &types.TrafficSplit{
+						Backends: []types.WeightedBackend{
+							{Backend: "backend-foobar", Weight: 9},
+							{Backend: "backend-canary", Weight: 1},
+						},
+						Stickiness: &types.Stickiness{CookieName: "canary"},
+					},
 					Redirect: &types.Redirect{
 						EntryPoint:  "https",
 						Regex:       "",
@@ -280,6 +309,17 @@ func TestDockerBuildConfiguration(t *testing.T) {
 						Sticky: true,
 						Stickiness: &types.Stickiness{
 							CookieName: "chocolate",
+							Secret:     "s3cr3t",
+							Secure:     true,
+							HTTPOnly:   true,
+							SameSite:   "lax",
+							MaxAge:     3600,
+							Domain:     "example.com",
+							Path:       "/app",
+						},
+						Hash: &types.ConsistentHash{
+							Key:        "request.header.X-User",
+							LoadFactor: 1.5,
 						},
 					},
 					MaxConn: &types.MaxConn{
@@ -298,6 +338,21 @@ func TestDockerBuildConfiguration(t *testing.T) {
 						MemRequestBodyBytes:  2097152,
 						RetryExpression:      "IsNetworkError() && Attempts() <= 2",
 					},
+					Protocol: "h2c",
+					Transport: &types.Transport{
+						DialTimeout:         "5s",
+						MaxIdleConnsPerHost: 50,
+						DisableHTTP2:        true,
+						TLS: &types.TransportTLS{
+							CA:         "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----",
+							ServerName: "backend.example.com",
+						},
+					},
+					Retry: &types.Retry{
+						Attempts:    3,
+						StatusCodes: []string{"502", "503-504"},
+						Backoff:     "100ms",
+					},
 				},
 			},
 		},
@@ -1355,6 +1410,74 @@ func TestDockerGetRateLimit(t *testing.T) {
 	}
 }
 
+func TestDockerGetSplit(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		container docker.ContainerJSON
+		expected  *types.TrafficSplit
+	}{
+		{
+			desc: "should return nil when no split labels",
+			container: containerJSON(
+				name("test1"),
+				labels(map[string]string{})),
+			expected: nil,
+		},
+		{
+			desc: "should return a struct when split labels are defined",
+			container: containerJSON(
+				name("test1"),
+				labels(map[string]string{
+					label.TraefikFrontendSplitBackends: "v1:90,v2.canary:10",
+				})),
+			expected: &types.TrafficSplit{
+				Backends: []types.WeightedBackend{
+					{Backend: "v1", Weight: 90},
+					{Backend: "v2-canary", Weight: 10},
+				},
+			},
+		},
+		{
+			desc: "should return a sticky struct when stickiness labels are defined",
+			container: containerJSON(
+				name("test1"),
+				labels(map[string]string{
+					label.TraefikFrontendSplitBackends:   "v1:1,v2:1",
+					label.TraefikFrontendSplitStickiness: "true",
+				})),
+			expected: &types.TrafficSplit{
+				Backends: []types.WeightedBackend{
+					{Backend: "v1", Weight: 1},
+					{Backend: "v2", Weight: 1},
+				},
+				Stickiness: &types.Stickiness{},
+			},
+		},
+		{
+			desc: "should return nil when only stickiness labels are defined",
+			container: containerJSON(
+				name("test1"),
+				labels(map[string]string{
+					label.TraefikFrontendSplitStickiness: "true",
+				})),
+			expected: nil,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			dData := parseContainer(test.container)
+
+			actual := getSplit(dData)
+
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
 func TestGetErrorPages(t *testing.T) {
 	testCases := []struct {
 		desc     string
@@ -1449,6 +1572,56 @@ func TestDockerGetHealthCheck(t *testing.T) {
 				Interval: "6",
 			},
 		},
+		{
+			desc: "should return a struct when only the health check type is set",
+			container: containerJSON(
+				name("test1"),
+				labels(map[string]string{
+					label.TraefikBackendHealthCheckType:        "grpc",
+					label.TraefikBackendHealthCheckGRPCService: "foo.Bar",
+					label.TraefikBackendHealthCheckPort:        "80",
+					label.TraefikBackendHealthCheckInterval:    "6",
+				})),
+			expected: &types.HealthCheck{
+				Type:        "grpc",
+				GRPCService: "foo.Bar",
+				Port:        80,
+				Interval:    "6",
+			},
+		},
+		{
+			desc: "should return a struct with the request and response settings",
+			container: containerJSON(
+				name("test1"),
+				labels(map[string]string{
+					label.TraefikBackendHealthCheckPath:        "/health",
+					label.TraefikBackendHealthCheckPort:        "80",
+					label.TraefikBackendHealthCheckInterval:    "6",
+					label.TraefikBackendHealthCheckTimeout:     "3s",
+					label.TraefikBackendHealthCheckScheme:      "https",
+					label.TraefikBackendHealthCheckMethod:      "HEAD",
+					label.TraefikBackendHealthCheckHostname:    "foo.localhost",
+					label.TraefikBackendHealthCheckHeaders:     "X-Health:1",
+					label.TraefikBackendHealthCheckStatusCodes: "200-299,304",
+					label.TraefikBackendHealthCheckBody:        "ok",
+					label.TraefikBackendHealthCheckRise:        "3",
+					label.TraefikBackendHealthCheckFall:        "2",
+				})),
+			expected: &types.HealthCheck{
+				Path:        "/health",
+				Port:        80,
+				Interval:    "6",
+				Timeout:     "3s",
+				Scheme:      "https",
+				Method:      "HEAD",
+				Hostname:    "foo.localhost",
+				Headers:     map[string]string{"X-Health": "1"},
+				StatusCodes: []string{"200-299", "304"},
+				Body:        "ok",
+				Rise:        3,
+				Fall:        2,
+			},
+		},
 	}
 
 	for _, test := range testCases {
@@ -1513,6 +1686,171 @@ func TestDockerGetBuffering(t *testing.T) {
 	}
 }
 
+func TestDockerGetTransport(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		container docker.ContainerJSON
+		expected  *types.Transport
+	}{
+		{
+			desc: "should return nil when no transport labels",
+			container: containerJSON(
+				name("test1"),
+				labels(map[string]string{})),
+			expected: nil,
+		},
+		{
+			desc: "should return a struct when transport labels are set",
+			container: containerJSON(
+				name("test1"),
+				labels(map[string]string{
+					label.TraefikBackendTransportDialTimeout:           "5s",
+					label.TraefikBackendTransportResponseHeaderTimeout: "30s",
+					label.TraefikBackendTransportIdleConnTimeout:       "90s",
+					label.TraefikBackendTransportMaxIdleConnsPerHost:   "50",
+					label.TraefikBackendTransportDisableKeepAlives:     "true",
+					label.TraefikBackendTransportDisableHTTP2:          "true",
+				})),
+			expected: &types.Transport{
+				DialTimeout:           "5s",
+				ResponseHeaderTimeout: "30s",
+				IdleConnTimeout:       "90s",
+				MaxIdleConnsPerHost:   50,
+				DisableKeepAlives:     true,
+				DisableHTTP2:          true,
+			},
+		},
+		{
+			desc: "should return the TLS settings when transport TLS labels are set",
+			container: containerJSON(
+				name("test1"),
+				labels(map[string]string{
+					label.TraefikBackendTransportTLSCA:                 "/certs/ca.pem",
+					label.TraefikBackendTransportTLSServerName:         "backend.example.com",
+					label.TraefikBackendTransportTLSCert:               "/certs/client.pem",
+					label.TraefikBackendTransportTLSKey:                "/certs/client.key",
+					label.TraefikBackendTransportTLSInsecureSkipVerify: "true",
+				})),
+			expected: &types.Transport{
+				TLS: &types.TransportTLS{
+					CA:                 "/certs/ca.pem",
+					ServerName:         "backend.example.com",
+					Cert:               "/certs/client.pem",
+					Key:                "/certs/client.key",
+					InsecureSkipVerify: true,
+				},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			dData := parseContainer(test.container)
+
+			actual := getTransport(dData)
+
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestDockerGetRetry(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		container docker.ContainerJSON
+		expected  *types.Retry
+	}{
+		{
+			desc: "should return nil when no retry labels",
+			container: containerJSON(
+				name("test1"),
+				labels(map[string]string{})),
+			expected: nil,
+		},
+		{
+			desc: "should return a struct when retry labels are set",
+			container: containerJSON(
+				name("test1"),
+				labels(map[string]string{
+					label.TraefikBackendRetryAttempts:       "3",
+					label.TraefikBackendRetryStatusCodes:    "502,503-504",
+					label.TraefikBackendRetryIdempotentOnly: "true",
+					label.TraefikBackendRetryPerTryTimeout:  "2s",
+					label.TraefikBackendRetryBackoff:        "100ms",
+					label.TraefikBackendRetryMaxBackoff:     "1s",
+					label.TraefikBackendRetryBudgetPercent:  "20",
+				})),
+			expected: &types.Retry{
+				Attempts:       3,
+				StatusCodes:    []string{"502", "503-504"},
+				IdempotentOnly: true,
+				PerTryTimeout:  "2s",
+				Backoff:        "100ms",
+				MaxBackoff:     "1s",
+				BudgetPercent:  20,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			dData := parseContainer(test.container)
+
+			actual := getRetry(dData)
+
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestDockerGetHedging(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		container docker.ContainerJSON
+		expected  *types.Hedging
+	}{
+		{
+			desc: "should return nil when no hedging labels",
+			container: containerJSON(
+				name("test1"),
+				labels(map[string]string{})),
+			expected: nil,
+		},
+		{
+			desc: "should return a struct when hedging labels are set",
+			container: containerJSON(
+				name("test1"),
+				labels(map[string]string{
+					label.TraefikBackendHedgingDelay:      "50ms",
+					label.TraefikBackendHedgingPercentile: "95",
+				})),
+			expected: &types.Hedging{
+				Delay:      "50ms",
+				Percentile: 95,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			dData := parseContainer(test.container)
+
+			actual := getHedging(dData)
+
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
 func TestDockerGetHeaders(t *testing.T) {
 	testCases := []struct {
 		desc      string
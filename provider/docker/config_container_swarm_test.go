@@ -115,6 +115,15 @@ func TestSwarmBuildConfiguration(t *testing.T) {
 						label.TraefikBackendLoadBalancerSticky:               "true",
 						label.TraefikBackendLoadBalancerStickiness:           "true",
 						label.TraefikBackendLoadBalancerStickinessCookieName: "chocolate",
+						label.TraefikBackendLoadBalancerStickinessSecret:     "s3cr3t",
+						label.TraefikBackendLoadBalancerStickinessSecure:     "true",
+						label.TraefikBackendLoadBalancerStickinessHTTPOnly:   "true",
+						label.TraefikBackendLoadBalancerStickinessSameSite:   "lax",
+						label.TraefikBackendLoadBalancerStickinessMaxAge:     "3600",
+						label.TraefikBackendLoadBalancerStickinessDomain:     "example.com",
+						label.TraefikBackendLoadBalancerStickinessPath:       "/app",
+						label.TraefikBackendLoadBalancerHashKey:              "request.header.X-User",
+						label.TraefikBackendLoadBalancerHashLoadFactor:       "1.5",
 						label.TraefikBackendMaxConnAmount:                    "666",
 						label.TraefikBackendMaxConnExtractorFunc:             "client.ip",
 						label.TraefikBackendBufferingMaxResponseBodyBytes:    "10485760",
@@ -286,6 +295,17 @@ func TestSwarmBuildConfiguration(t *testing.T) {
 						Sticky: true,
 						Stickiness: &types.Stickiness{
 							CookieName: "chocolate",
+							Secret:     "s3cr3t",
+							Secure:     true,
+							HTTPOnly:   true,
+							SameSite:   "lax",
+							MaxAge:     3600,
+							Domain:     "example.com",
+							Path:       "/app",
+						},
+						Hash: &types.ConsistentHash{
+							Key:        "request.header.X-User",
+							LoadFactor: 1.5,
 						},
 					},
 					MaxConn: &types.MaxConn{
@@ -25,6 +25,9 @@ func (p *Provider) buildConfiguration(services map[string][]ecsInstance) (*types
 		"getLoadBalancer":   getLoadBalancer,
 		"getMaxConn":        getMaxConn,
 		"getHealthCheck":    getHealthCheck,
+		"getTransport":      getTransport,
+		"getRetry":          getRetry,
+		"getHedging":        getHedging,
 		"getBuffering":      getBuffering,
 		"getServers":        getServers,
 
@@ -97,6 +100,14 @@ func getHost(i ecsInstance) string {
 	return aws.StringValue(i.machine.PrivateIpAddress)
 }
 
+// getZone returns the availability zone of the EC2 instance running the container.
+func getZone(i ecsInstance) string {
+	if i.machine.Placement == nil {
+		return ""
+	}
+	return aws.StringValue(i.machine.Placement.AvailabilityZone)
+}
+
 func getPort(i ecsInstance) string {
 	if value := getStringValue(i, label.TraefikPort, ""); len(value) > 0 {
 		return value
@@ -138,8 +149,23 @@ func getLoadBalancer(instance ecsInstance) *types.LoadBalancer {
 	}
 
 	if getBoolValue(instance, label.TraefikBackendLoadBalancerStickiness, false) {
-		cookieName := getStringValue(instance, label.TraefikBackendLoadBalancerStickinessCookieName, label.DefaultBackendLoadbalancerStickinessCookieName)
-		lb.Stickiness = &types.Stickiness{CookieName: cookieName}
+		lb.Stickiness = &types.Stickiness{
+			CookieName: getStringValue(instance, label.TraefikBackendLoadBalancerStickinessCookieName, label.DefaultBackendLoadbalancerStickinessCookieName),
+			Secret:     getStringValue(instance, label.TraefikBackendLoadBalancerStickinessSecret, ""),
+			Secure:     getBoolValue(instance, label.TraefikBackendLoadBalancerStickinessSecure, false),
+			HTTPOnly:   getBoolValue(instance, label.TraefikBackendLoadBalancerStickinessHTTPOnly, false),
+			SameSite:   getStringValue(instance, label.TraefikBackendLoadBalancerStickinessSameSite, ""),
+			MaxAge:     getIntValue(instance, label.TraefikBackendLoadBalancerStickinessMaxAge, 0),
+			Domain:     getStringValue(instance, label.TraefikBackendLoadBalancerStickinessDomain, ""),
+			Path:       getStringValue(instance, label.TraefikBackendLoadBalancerStickinessPath, ""),
+		}
+	}
+
+	if hasPrefix(instance, label.TraefikBackendLoadBalancerHash) {
+		lb.Hash = &types.ConsistentHash{
+			Key:        getStringValue(instance, label.TraefikBackendLoadBalancerHashKey, ""),
+			LoadFactor: getFloat64Value(instance, label.TraefikBackendLoadBalancerHashLoadFactor, 0),
+		}
 	}
 
 	return lb
@@ -161,7 +187,8 @@ func getMaxConn(instance ecsInstance) *types.MaxConn {
 
 func getHealthCheck(instance ecsInstance) *types.HealthCheck {
 	path := getStringValue(instance, label.TraefikBackendHealthCheckPath, "")
-	if len(path) == 0 {
+	hcType := getStringValue(instance, label.TraefikBackendHealthCheckType, "")
+	if len(path) == 0 && len(hcType) == 0 {
 		return nil
 	}
 
@@ -169,9 +196,20 @@ func getHealthCheck(instance ecsInstance) *types.HealthCheck {
 	interval := getStringValue(instance, label.TraefikBackendHealthCheckInterval, "")
 
 	return &types.HealthCheck{
-		Path:     path,
-		Port:     port,
-		Interval: interval,
+		Type:        hcType,
+		Path:        path,
+		Port:        port,
+		Interval:    interval,
+		Timeout:     getStringValue(instance, label.TraefikBackendHealthCheckTimeout, ""),
+		Scheme:      getStringValue(instance, label.TraefikBackendHealthCheckScheme, ""),
+		Method:      getStringValue(instance, label.TraefikBackendHealthCheckMethod, ""),
+		Hostname:    getStringValue(instance, label.TraefikBackendHealthCheckHostname, ""),
+		Headers:     getMapString(instance, label.TraefikBackendHealthCheckHeaders),
+		StatusCodes: getSliceString(instance, label.TraefikBackendHealthCheckStatusCodes),
+		Body:        getStringValue(instance, label.TraefikBackendHealthCheckBody, ""),
+		Rise:        getIntValue(instance, label.TraefikBackendHealthCheckRise, 0),
+		Fall:        getIntValue(instance, label.TraefikBackendHealthCheckFall, 0),
+		GRPCService: getStringValue(instance, label.TraefikBackendHealthCheckGRPCService, ""),
 	}
 }
 
@@ -189,6 +227,60 @@ func getBuffering(instance ecsInstance) *types.Buffering {
 	}
 }
 
+func getTransport(instance ecsInstance) *types.Transport {
+	if !hasPrefix(instance, label.TraefikBackendTransport) {
+		return nil
+	}
+
+	transport := &types.Transport{
+		DialTimeout:           getStringValue(instance, label.TraefikBackendTransportDialTimeout, ""),
+		ResponseHeaderTimeout: getStringValue(instance, label.TraefikBackendTransportResponseHeaderTimeout, ""),
+		IdleConnTimeout:       getStringValue(instance, label.TraefikBackendTransportIdleConnTimeout, ""),
+		MaxIdleConnsPerHost:   getIntValue(instance, label.TraefikBackendTransportMaxIdleConnsPerHost, 0),
+		DisableKeepAlives:     getBoolValue(instance, label.TraefikBackendTransportDisableKeepAlives, false),
+		DisableHTTP2:          getBoolValue(instance, label.TraefikBackendTransportDisableHTTP2, false),
+	}
+
+	if hasPrefix(instance, label.TraefikBackendTransportTLS) {
+		transport.TLS = &types.TransportTLS{
+			CA:                 getStringValue(instance, label.TraefikBackendTransportTLSCA, ""),
+			ServerName:         getStringValue(instance, label.TraefikBackendTransportTLSServerName, ""),
+			Cert:               getStringValue(instance, label.TraefikBackendTransportTLSCert, ""),
+			Key:                getStringValue(instance, label.TraefikBackendTransportTLSKey, ""),
+			InsecureSkipVerify: getBoolValue(instance, label.TraefikBackendTransportTLSInsecureSkipVerify, false),
+		}
+	}
+
+	return transport
+}
+
+func getRetry(instance ecsInstance) *types.Retry {
+	if !hasPrefix(instance, label.TraefikBackendRetry) {
+		return nil
+	}
+
+	return &types.Retry{
+		Attempts:       getIntValue(instance, label.TraefikBackendRetryAttempts, 0),
+		StatusCodes:    getSliceString(instance, label.TraefikBackendRetryStatusCodes),
+		IdempotentOnly: getBoolValue(instance, label.TraefikBackendRetryIdempotentOnly, false),
+		PerTryTimeout:  getStringValue(instance, label.TraefikBackendRetryPerTryTimeout, ""),
+		Backoff:        getStringValue(instance, label.TraefikBackendRetryBackoff, ""),
+		MaxBackoff:     getStringValue(instance, label.TraefikBackendRetryMaxBackoff, ""),
+		BudgetPercent:  getIntValue(instance, label.TraefikBackendRetryBudgetPercent, 0),
+	}
+}
+
+func getHedging(instance ecsInstance) *types.Hedging {
+	if !hasPrefix(instance, label.TraefikBackendHedging) {
+		return nil
+	}
+
+	return &types.Hedging{
+		Delay:      getStringValue(instance, label.TraefikBackendHedgingDelay, ""),
+		Percentile: getIntValue(instance, label.TraefikBackendHedgingPercentile, 0),
+	}
+}
+
 func getServers(instances []ecsInstance) map[string]types.Server {
 	var servers map[string]types.Server
 
@@ -205,6 +297,7 @@ func getServers(instances []ecsInstance) map[string]types.Server {
 		servers[serverName] = types.Server{
 			URL:    fmt.Sprintf("%s://%s:%s", protocol, host, port),
 			Weight: getIntValue(instance, label.TraefikWeight, 0),
+			Zone:   getZone(instance),
 		}
 	}
 
@@ -406,6 +499,19 @@ func getInt64Value(i ecsInstance, labelName string, defaultValue int64) int64 {
 	return defaultValue
 }
 
+func getFloat64Value(i ecsInstance, labelName string, defaultValue float64) float64 {
+	rawValue, ok := i.containerDefinition.DockerLabels[labelName]
+	if ok {
+		if rawValue != nil {
+			v, err := strconv.ParseFloat(aws.StringValue(rawValue), 64)
+			if err == nil {
+				return v
+			}
+		}
+	}
+	return defaultValue
+}
+
 func getSliceString(i ecsInstance, labelName string) []string {
 	if value, ok := i.containerDefinition.DockerLabels[labelName]; ok {
 		if value == nil {
@@ -134,6 +134,15 @@ func TestBuildConfiguration(t *testing.T) {
 							label.TraefikBackendLoadBalancerSticky:               aws.String("true"),
 							label.TraefikBackendLoadBalancerStickiness:           aws.String("true"),
 							label.TraefikBackendLoadBalancerStickinessCookieName: aws.String("chocolate"),
+							label.TraefikBackendLoadBalancerStickinessSecret:     aws.String("s3cr3t"),
+							label.TraefikBackendLoadBalancerStickinessSecure:     aws.String("true"),
+							label.TraefikBackendLoadBalancerStickinessHTTPOnly:   aws.String("true"),
+							label.TraefikBackendLoadBalancerStickinessSameSite:   aws.String("lax"),
+							label.TraefikBackendLoadBalancerStickinessMaxAge:     aws.String("3600"),
+							label.TraefikBackendLoadBalancerStickinessDomain:     aws.String("example.com"),
+							label.TraefikBackendLoadBalancerStickinessPath:       aws.String("/app"),
+							label.TraefikBackendLoadBalancerHashKey:              aws.String("request.header.X-User"),
+							label.TraefikBackendLoadBalancerHashLoadFactor:       aws.String("1.5"),
 							label.TraefikBackendMaxConnAmount:                    aws.String("666"),
 							label.TraefikBackendMaxConnExtractorFunc:             aws.String("client.ip"),
 							label.TraefikBackendBufferingMaxResponseBodyBytes:    aws.String("10485760"),
@@ -217,6 +226,17 @@ func TestBuildConfiguration(t *testing.T) {
 							Sticky: true,
 							Stickiness: &types.Stickiness{
 								CookieName: "chocolate",
+								Secret:     "s3cr3t",
+								Secure:     true,
+								HTTPOnly:   true,
+								SameSite:   "lax",
+								MaxAge:     3600,
+								Domain:     "example.com",
+								Path:       "/app",
+							},
+							Hash: &types.ConsistentHash{
+								Key:        "request.header.X-User",
+								LoadFactor: 1.5,
 							},
 						},
 						MaxConn: &types.MaxConn{
@@ -948,6 +968,56 @@ func TestGetHealthCheck(t *testing.T) {
 				Interval: "6",
 			},
 		},
+		{
+			desc: "should return a struct when only the health check type is set",
+			instance: ecsInstance{
+				containerDefinition: &ecs.ContainerDefinition{
+					DockerLabels: map[string]*string{
+						label.TraefikBackendHealthCheckType:        aws.String("grpc"),
+						label.TraefikBackendHealthCheckGRPCService: aws.String("foo.Bar"),
+						label.TraefikBackendHealthCheckPort:        aws.String("80"),
+						label.TraefikBackendHealthCheckInterval:    aws.String("6"),
+					}}},
+			expected: &types.HealthCheck{
+				Type:        "grpc",
+				GRPCService: "foo.Bar",
+				Port:        80,
+				Interval:    "6",
+			},
+		},
+		{
+			desc: "should return a struct with the request and response settings",
+			instance: ecsInstance{
+				containerDefinition: &ecs.ContainerDefinition{
+					DockerLabels: map[string]*string{
+						label.TraefikBackendHealthCheckPath:        aws.String("/health"),
+						label.TraefikBackendHealthCheckPort:        aws.String("80"),
+						label.TraefikBackendHealthCheckInterval:    aws.String("6"),
+						label.TraefikBackendHealthCheckTimeout:     aws.String("3s"),
+						label.TraefikBackendHealthCheckScheme:      aws.String("https"),
+						label.TraefikBackendHealthCheckMethod:      aws.String("HEAD"),
+						label.TraefikBackendHealthCheckHostname:    aws.String("foo.localhost"),
+						label.TraefikBackendHealthCheckHeaders:     aws.String("X-Health:1"),
+						label.TraefikBackendHealthCheckStatusCodes: aws.String("200-299,304"),
+						label.TraefikBackendHealthCheckBody:        aws.String("ok"),
+						label.TraefikBackendHealthCheckRise:        aws.String("3"),
+						label.TraefikBackendHealthCheckFall:        aws.String("2"),
+					}}},
+			expected: &types.HealthCheck{
+				Path:        "/health",
+				Port:        80,
+				Interval:    "6",
+				Timeout:     "3s",
+				Scheme:      "https",
+				Method:      "HEAD",
+				Hostname:    "foo.localhost",
+				Headers:     map[string]string{"X-Health": "1"},
+				StatusCodes: []string{"200-299", "304"},
+				Body:        "ok",
+				Rise:        3,
+				Fall:        2,
+			},
+		},
 	}
 
 	for _, test := range testCases {
@@ -1009,6 +1079,143 @@ func TestGetBuffering(t *testing.T) {
 	}
 }
 
+func TestGetTransport(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		instance ecsInstance
+		expected *types.Transport
+	}{
+		{
+			desc: "should return nil when no transport labels",
+			instance: ecsInstance{
+				containerDefinition: &ecs.ContainerDefinition{
+					DockerLabels: map[string]*string{},
+				}},
+			expected: nil,
+		},
+		{
+			desc: "should return a struct when transport labels are set",
+			instance: ecsInstance{
+				containerDefinition: &ecs.ContainerDefinition{
+					DockerLabels: map[string]*string{
+						label.TraefikBackendTransportDialTimeout:           aws.String("5s"),
+						label.TraefikBackendTransportMaxIdleConnsPerHost:   aws.String("50"),
+						label.TraefikBackendTransportDisableHTTP2:          aws.String("true"),
+						label.TraefikBackendTransportTLSCA:                 aws.String("/certs/ca.pem"),
+						label.TraefikBackendTransportTLSInsecureSkipVerify: aws.String("true"),
+					}}},
+			expected: &types.Transport{
+				DialTimeout:         "5s",
+				MaxIdleConnsPerHost: 50,
+				DisableHTTP2:        true,
+				TLS: &types.TransportTLS{
+					CA:                 "/certs/ca.pem",
+					InsecureSkipVerify: true,
+				},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			actual := getTransport(test.instance)
+
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestGetRetry(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		instance ecsInstance
+		expected *types.Retry
+	}{
+		{
+			desc: "should return nil when no retry labels",
+			instance: ecsInstance{
+				containerDefinition: &ecs.ContainerDefinition{
+					DockerLabels: map[string]*string{},
+				}},
+			expected: nil,
+		},
+		{
+			desc: "should return a struct when retry labels are set",
+			instance: ecsInstance{
+				containerDefinition: &ecs.ContainerDefinition{
+					DockerLabels: map[string]*string{
+						label.TraefikBackendRetryAttempts:       aws.String("3"),
+						label.TraefikBackendRetryStatusCodes:    aws.String("502,503-504"),
+						label.TraefikBackendRetryIdempotentOnly: aws.String("true"),
+						label.TraefikBackendRetryPerTryTimeout:  aws.String("2s"),
+						label.TraefikBackendRetryBudgetPercent:  aws.String("20"),
+					}}},
+			expected: &types.Retry{
+				Attempts:       3,
+				StatusCodes:    []string{"502", "503-504"},
+				IdempotentOnly: true,
+				PerTryTimeout:  "2s",
+				BudgetPercent:  20,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			actual := getRetry(test.instance)
+
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestGetHedging(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		instance ecsInstance
+		expected *types.Hedging
+	}{
+		{
+			desc: "should return nil when no hedging labels",
+			instance: ecsInstance{
+				containerDefinition: &ecs.ContainerDefinition{
+					DockerLabels: map[string]*string{},
+				}},
+			expected: nil,
+		},
+		{
+			desc: "should return a struct when hedging labels are set",
+			instance: ecsInstance{
+				containerDefinition: &ecs.ContainerDefinition{
+					DockerLabels: map[string]*string{
+						label.TraefikBackendHedgingDelay:      aws.String("50ms"),
+						label.TraefikBackendHedgingPercentile: aws.String("95"),
+					}}},
+			expected: &types.Hedging{
+				Delay:      "50ms",
+				Percentile: 95,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			actual := getHedging(test.instance)
+
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
 func TestGetServers(t *testing.T) {
 	testCases := []struct {
 		desc      string
@@ -1099,6 +1306,29 @@ func TestGetServers(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "should set the availability zone of the instance",
+			instances: []ecsInstance{{
+				Name: "test",
+				ID:   "0",
+				containerDefinition: &ecs.ContainerDefinition{
+					DockerLabels: map[string]*string{},
+				},
+				machine: &ec2.Instance{
+					PrivateIpAddress: aws.String("10.10.10.0"),
+					Placement: &ec2.Placement{
+						AvailabilityZone: aws.String("us-east-1a"),
+					},
+				},
+				container: &ecs.Container{
+					NetworkBindings: []*ecs.NetworkBinding{{
+						HostPort: aws.Int64(80),
+					}},
+				}}},
+			expected: map[string]types.Server{
+				"server-test-0": {URL: "http://10.10.10.0:80", Weight: 0, Zone: "us-east-1a"},
+			},
+		},
 	}
 
 	for _, test := range testCases {
@@ -17,8 +17,17 @@ const (
 	annotationKubernetesPriority                 = "ingress.kubernetes.io/priority"
 	annotationKubernetesCircuitBreakerExpression = "ingress.kubernetes.io/circuit-breaker-expression"
 	annotationKubernetesLoadBalancerMethod       = "ingress.kubernetes.io/load-balancer-method"
+	annotationKubernetesLoadBalancerHashKey      = "ingress.kubernetes.io/load-balancer-hash-key"
+	annotationKubernetesLoadBalancerHashFactor   = "ingress.kubernetes.io/load-balancer-hash-load-factor"
 	annotationKubernetesAffinity                 = "ingress.kubernetes.io/affinity"
 	annotationKubernetesSessionCookieName        = "ingress.kubernetes.io/session-cookie-name"
+	annotationKubernetesSessionCookieSecret      = "ingress.kubernetes.io/session-cookie-secret"
+	annotationKubernetesSessionCookieSecure      = "ingress.kubernetes.io/session-cookie-secure"
+	annotationKubernetesSessionCookieHTTPOnly    = "ingress.kubernetes.io/session-cookie-http-only"
+	annotationKubernetesSessionCookieSameSite    = "ingress.kubernetes.io/session-cookie-same-site"
+	annotationKubernetesSessionCookieMaxAge      = "ingress.kubernetes.io/session-cookie-max-age"
+	annotationKubernetesSessionCookieDomain      = "ingress.kubernetes.io/session-cookie-domain"
+	annotationKubernetesSessionCookiePath        = "ingress.kubernetes.io/session-cookie-path"
 	annotationKubernetesRuleType                 = "ingress.kubernetes.io/rule-type"
 	annotationKubernetesRedirectEntryPoint       = "ingress.kubernetes.io/redirect-entry-point"
 	annotationKubernetesRedirectPermanent        = "ingress.kubernetes.io/redirect-permanent"
@@ -29,6 +38,14 @@ const (
 	annotationKubernetesRateLimit                = "ingress.kubernetes.io/rate-limit"
 	annotationKubernetesErrorPages               = "ingress.kubernetes.io/error-pages"
 	annotationKubernetesBuffering                = "ingress.kubernetes.io/buffering"
+	annotationKubernetesProtocol                 = "ingress.kubernetes.io/protocol"
+	annotationKubernetesTransport                = "ingress.kubernetes.io/transport"
+	annotationKubernetesRetry                    = "ingress.kubernetes.io/retry"
+	annotationKubernetesHealthCheck              = "ingress.kubernetes.io/health-check"
+	annotationKubernetesHedging                  = "ingress.kubernetes.io/hedging"
+	annotationKubernetesServiceWeights           = "ingress.kubernetes.io/service-weights"
+	annotationKubernetesServiceWeightsAffinity   = "ingress.kubernetes.io/service-weights-affinity"
+	annotationKubernetesServiceWeightsCookieName = "ingress.kubernetes.io/service-weights-cookie-name"
 
 	annotationKubernetesSSLRedirect             = "ingress.kubernetes.io/ssl-redirect"
 	annotationKubernetesHSTSMaxAge              = "ingress.kubernetes.io/hsts-max-age"
@@ -107,6 +124,11 @@ func getInt64Value(annotations map[string]string, annotation string, defaultValu
 	return label.GetInt64Value(annotations, annotationName, defaultValue)
 }
 
+func getFloat64Value(annotations map[string]string, annotation string, defaultValue float64) float64 {
+	annotationName := getAnnotationName(annotations, annotation)
+	return label.GetFloat64Value(annotations, annotationName, defaultValue)
+}
+
 func getSliceStringValue(annotations map[string]string, annotation string) []string {
 	annotationName := getAnnotationName(annotations, annotation)
 	return label.GetSliceStringValue(annotations, annotationName)
@@ -67,6 +67,12 @@ func weight(value int) func(*types.Server) {
 	}
 }
 
+func zone(value string) func(*types.Server) {
+	return func(s *types.Server) {
+		s.Zone = value
+	}
+}
+
 func lbMethod(method string) func(*types.Backend) {
 	return func(b *types.Backend) {
 		if b.LoadBalancer == nil {
@@ -85,6 +91,24 @@ func lbSticky() func(*types.Backend) {
 	}
 }
 
+func lbStickiness(stickiness *types.Stickiness) func(*types.Backend) {
+	return func(b *types.Backend) {
+		if b.LoadBalancer == nil {
+			b.LoadBalancer = &types.LoadBalancer{}
+		}
+		b.LoadBalancer.Stickiness = stickiness
+	}
+}
+
+func lbHash(key string, loadFactor float64) func(*types.Backend) {
+	return func(b *types.Backend) {
+		if b.LoadBalancer == nil {
+			b.LoadBalancer = &types.LoadBalancer{}
+		}
+		b.LoadBalancer.Hash = &types.ConsistentHash{Key: key, LoadFactor: loadFactor}
+	}
+}
+
 func circuitBreaker(exp string) func(*types.Backend) {
 	return func(b *types.Backend) {
 		b.CircuitBreaker = &types.CircuitBreaker{}
@@ -133,6 +157,30 @@ func retrying(exp string) func(*types.Buffering) {
 	}
 }
 
+func transport(transport *types.Transport) func(*types.Backend) {
+	return func(b *types.Backend) {
+		b.Transport = transport
+	}
+}
+
+func retry(retry *types.Retry) func(*types.Backend) {
+	return func(b *types.Backend) {
+		b.Retry = retry
+	}
+}
+
+func hedging(hedging *types.Hedging) func(*types.Backend) {
+	return func(b *types.Backend) {
+		b.Hedging = hedging
+	}
+}
+
+func healthCheck(healthCheck *types.HealthCheck) func(*types.Backend) {
+	return func(b *types.Backend) {
+		b.HealthCheck = healthCheck
+	}
+}
+
 func maxConnExtractorFunc(exp string) func(*types.Backend) {
 	return func(b *types.Backend) {
 		if b.MaxConn == nil {
@@ -323,6 +371,30 @@ func limitPeriod(period time.Duration) func(*types.Rate) {
 	}
 }
 
+func split(opts ...func(*types.TrafficSplit)) func(*types.Frontend) {
+	return func(f *types.Frontend) {
+		if f.Split == nil {
+			f.Split = &types.TrafficSplit{}
+		}
+
+		for _, opt := range opts {
+			opt(f.Split)
+		}
+	}
+}
+
+func splitBackend(backend string, weight int) func(*types.TrafficSplit) {
+	return func(split *types.TrafficSplit) {
+		split.Backends = append(split.Backends, types.WeightedBackend{Backend: backend, Weight: weight})
+	}
+}
+
+func splitStickiness(cookieName string) func(*types.TrafficSplit) {
+	return func(split *types.TrafficSplit) {
+		split.Stickiness = &types.Stickiness{CookieName: cookieName}
+	}
+}
+
 func passTLSCert() func(*types.Frontend) {
 	return func(f *types.Frontend) {
 		f.PassTLSCert = true
@@ -60,6 +60,12 @@ func eAddress(ip string) func(*v1.EndpointAddress) {
 	}
 }
 
+func eNodeName(name string) func(*v1.EndpointAddress) {
+	return func(address *v1.EndpointAddress) {
+		address.NodeName = &name
+	}
+}
+
 func ePorts(opts ...func(port *v1.EndpointPort)) func(*v1.EndpointSubset) {
 	return func(spec *v1.EndpointSubset) {
 		for _, opt := range opts {
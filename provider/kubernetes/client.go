@@ -27,6 +27,7 @@ const (
 	kindServices  = "services"
 	kindEndpoints = "endpoints"
 	kindSecrets   = "secrets"
+	kindNodes     = "nodes"
 )
 
 type resourceEventHandler struct {
@@ -66,6 +67,7 @@ type Client interface {
 	GetService(namespace, name string) (*v1.Service, bool, error)
 	GetSecret(namespace, name string) (*v1.Secret, bool, error)
 	GetEndpoints(namespace, name string) (*v1.Endpoints, bool, error)
+	GetNode(name string) (*v1.Node, bool, error)
 }
 
 type clientImpl struct {
@@ -74,6 +76,7 @@ type clientImpl struct {
 	svcStores      map[string]cache.Store
 	epStores       map[string]cache.Store
 	secStores      map[string]cache.Store
+	nodeStore      cache.Store
 	isNamespaceAll bool
 }
 
@@ -162,6 +165,9 @@ func (c *clientImpl) WatchAll(namespaces Namespaces, labelSelector string, stopC
 		// situation here in the future.
 		informManager.extend(c.WatchObjects(ns, kindSecrets, &v1.Secret{}, c.secStores, eventCh), false)
 	}
+	// The nodes give the zones of the endpoints. Do not wait for the Nodes store to get synced
+	// since we cannot rely on users having granted RBAC permissions for this object.
+	informManager.extend(c.WatchNodes(), false)
 
 	var wg sync.WaitGroup
 	for _, informer := range informManager.informers {
@@ -213,6 +219,20 @@ func (c *clientImpl) WatchObjects(namespace, kind string, object runtime.Object,
 	return informer
 }
 
+// WatchNodes sets up a watch on Node objects and returns a corresponding shared informer.
+// The Node events are not signalled, since the status of the nodes is updated every few seconds.
+func (c *clientImpl) WatchNodes() cache.SharedInformer {
+	listWatch := cache.NewListWatchFromClient(
+		c.clientset.CoreV1().RESTClient(),
+		kindNodes,
+		api.NamespaceAll,
+		fields.Everything())
+
+	informer := cache.NewSharedInformer(listWatch, &v1.Node{}, resyncPeriod)
+	c.nodeStore = informer.GetStore()
+	return informer
+}
+
 func loadInformer(listWatch cache.ListerWatcher, object runtime.Object, watchCh chan<- interface{}) cache.SharedInformer {
 	informer := cache.NewSharedInformer(
 		listWatch,
@@ -277,6 +297,20 @@ func (c *clientImpl) GetSecret(namespace, name string) (*v1.Secret, bool, error)
 	return secret, exists, err
 }
 
+// GetNode returns the named node.
+func (c *clientImpl) GetNode(name string) (*v1.Node, bool, error) {
+	var node *v1.Node
+	if c.nodeStore == nil {
+		return nil, false, nil
+	}
+	item, exists, err := c.nodeStore.GetByKey(name)
+	if err == nil && item != nil {
+		node = item.(*v1.Node)
+	}
+
+	return node, exists, err
+}
+
 // lookupNamespace returns the lookup namespace key for the given namespace.
 // When listening on all namespaces, it returns the client-go identifier ("")
 // for all-namespaces. Otherwise, it returns the given namespace.
@@ -10,6 +10,7 @@ type clientMock struct {
 	services  []*v1.Service
 	secrets   []*v1.Secret
 	endpoints []*v1.Endpoints
+	nodes     []*v1.Node
 	watchChan chan interface{}
 
 	apiServiceError   error
@@ -61,6 +62,15 @@ func (c clientMock) GetSecret(namespace, name string) (*v1.Secret, bool, error)
 	return nil, false, nil
 }
 
+func (c clientMock) GetNode(name string) (*v1.Node, bool, error) {
+	for _, node := range c.nodes {
+		if node.Name == name {
+			return node, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
 func (c clientMock) WatchAll(namespaces Namespaces, labelString string, stopCh <-chan struct{}) (<-chan interface{}, error) {
 	return c.watchChan, nil
 }
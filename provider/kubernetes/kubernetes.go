@@ -22,6 +22,7 @@ import (
 	"github.com/containous/traefik/tls"
 	"github.com/containous/traefik/types"
 	"gopkg.in/yaml.v2"
+	"k8s.io/client-go/pkg/api/unversioned"
 	"k8s.io/client-go/pkg/api/v1"
 	"k8s.io/client-go/pkg/apis/extensions/v1beta1"
 	"k8s.io/client-go/pkg/util/intstr"
@@ -34,6 +35,7 @@ const (
 	ruleTypeReplacePath        = "ReplacePath"
 	traefikDefaultRealm        = "traefik"
 	traefikDefaultIngressClass = "traefik"
+	labelTopologyZone          = "topology.kubernetes.io/zone"
 )
 
 // Provider holds configurations of the provider.
@@ -165,6 +167,12 @@ func (p *Provider) loadIngresses(k8sClient Client) (*types.Configuration, error)
 		}
 		templateObjects.TLS = append(templateObjects.TLS, tlsSection...)
 
+		serviceWeights, err := getServiceWeights(i)
+		if err != nil {
+			log.Errorf("Error configuring service weights for ingress %s/%s: %v", i.Namespace, i.Name, err)
+			continue
+		}
+
 		for _, r := range i.Spec.Rules {
 			if r.HTTP == nil {
 				log.Warn("Error in ingress: HTTP is nil")
@@ -173,8 +181,18 @@ func (p *Provider) loadIngresses(k8sClient Client) (*types.Configuration, error)
 
 			for _, pa := range r.HTTP.Paths {
 				baseName := r.Host + pa.Path
-				if _, exists := templateObjects.Backends[baseName]; !exists {
-					templateObjects.Backends[baseName] = &types.Backend{
+				backendName := baseName
+				if serviceWeights != nil {
+					if _, ok := serviceWeights[pa.Backend.ServiceName]; !ok {
+						log.Errorf("No weight for service %s in annotation %q on ingress %s/%s, skipping path %s", pa.Backend.ServiceName, annotationKubernetesServiceWeights, i.Namespace, i.Name, baseName)
+						continue
+					}
+					// the traffic of the frontend is split between a backend per service
+					backendName = baseName + "@" + pa.Backend.ServiceName
+				}
+
+				if _, exists := templateObjects.Backends[backendName]; !exists {
+					templateObjects.Backends[backendName] = &types.Backend{
 						Servers: make(map[string]types.Server),
 						LoadBalancer: &types.LoadBalancer{
 							Method: "wrr",
@@ -185,7 +203,7 @@ func (p *Provider) loadIngresses(k8sClient Client) (*types.Configuration, error)
 				annotationAuthRealm := getAnnotationName(i.Annotations, annotationKubernetesAuthRealm)
 				if realm := i.Annotations[annotationAuthRealm]; realm != "" && realm != traefikDefaultRealm {
 					log.Errorf("Value for annotation %q on ingress %s/%s invalid: no realm customization supported", annotationAuthRealm, i.Namespace, i.Name)
-					delete(templateObjects.Backends, baseName)
+					delete(templateObjects.Backends, backendName)
 					continue
 				}
 
@@ -244,10 +262,19 @@ func (p *Provider) loadIngresses(k8sClient Client) (*types.Configuration, error)
 					continue
 				}
 
-				templateObjects.Backends[baseName].CircuitBreaker = getCircuitBreaker(service)
-				templateObjects.Backends[baseName].LoadBalancer = getLoadBalancer(service)
-				templateObjects.Backends[baseName].MaxConn = getMaxConn(service)
-				templateObjects.Backends[baseName].Buffering = getBuffering(service)
+				if weight, ok := serviceWeights[pa.Backend.ServiceName]; ok {
+					addSplitBackend(templateObjects.Frontends[baseName], backendName, weight, i)
+				}
+
+				templateObjects.Backends[backendName].CircuitBreaker = getCircuitBreaker(service)
+				templateObjects.Backends[backendName].LoadBalancer = getLoadBalancer(service)
+				templateObjects.Backends[backendName].MaxConn = getMaxConn(service)
+				templateObjects.Backends[backendName].Buffering = getBuffering(service)
+				templateObjects.Backends[backendName].Protocol = getStringValue(service.Annotations, annotationKubernetesProtocol, "")
+				templateObjects.Backends[backendName].Transport = getTransport(service)
+				templateObjects.Backends[backendName].HealthCheck = getHealthCheck(service)
+				templateObjects.Backends[backendName].Retry = getRetry(service)
+				templateObjects.Backends[backendName].Hedging = getHedging(service)
 
 				protocol := label.DefaultProtocol
 				for _, port := range service.Spec.Ports {
@@ -260,7 +287,7 @@ func (p *Provider) loadIngresses(k8sClient Client) (*types.Configuration, error)
 							url := protocol + "://" + service.Spec.ExternalName
 							name := url
 
-							templateObjects.Backends[baseName].Servers[name] = types.Server{
+							templateObjects.Backends[backendName].Servers[name] = types.Server{
 								URL:    url,
 								Weight: 1,
 							}
@@ -288,9 +315,10 @@ func (p *Provider) loadIngresses(k8sClient Client) (*types.Configuration, error)
 									if address.TargetRef != nil && address.TargetRef.Name != "" {
 										name = address.TargetRef.Name
 									}
-									templateObjects.Backends[baseName].Servers[name] = types.Server{
+									templateObjects.Backends[backendName].Servers[name] = types.Server{
 										URL:    url,
 										Weight: 1,
+										Zone:   getZone(address, k8sClient),
 									}
 								}
 							}
@@ -434,6 +462,29 @@ func getTLS(ingress *v1beta1.Ingress, k8sClient Client) ([]*tls.Configuration, e
 	return tlsConfigs, nil
 }
 
+// getZone returns the zone of the node an endpoint address is on, from the zone labels of the node.
+func getZone(address v1.EndpointAddress, k8sClient Client) string {
+	if address.NodeName == nil {
+		return ""
+	}
+
+	node, exists, err := k8sClient.GetNode(*address.NodeName)
+	if err != nil {
+		log.Errorf("Error retrieving node %s: %v", *address.NodeName, err)
+		return ""
+	}
+	if !exists {
+		return ""
+	}
+
+	for _, zoneLabel := range []string{labelTopologyZone, unversioned.LabelZoneFailureDomain} {
+		if zone, ok := node.Labels[zoneLabel]; ok {
+			return zone
+		}
+	}
+	return ""
+}
+
 func endpointPortNumber(servicePort v1.ServicePort, endpointPorts []v1.EndpointPort) int {
 	if len(endpointPorts) > 0 {
 		//name is optional if there is only one port
@@ -506,13 +557,85 @@ func getBuffering(service *v1.Service) *types.Buffering {
 	return buffering
 }
 
+func getTransport(service *v1.Service) *types.Transport {
+	var transport *types.Transport
+
+	transportRaw := getStringValue(service.Annotations, annotationKubernetesTransport, "")
+
+	if len(transportRaw) > 0 {
+		transport = &types.Transport{}
+		err := yaml.Unmarshal([]byte(transportRaw), transport)
+		if err != nil {
+			log.Error(err)
+			return nil
+		}
+	}
+
+	return transport
+}
+
+func getHealthCheck(service *v1.Service) *types.HealthCheck {
+	var healthCheck *types.HealthCheck
+
+	healthCheckRaw := getStringValue(service.Annotations, annotationKubernetesHealthCheck, "")
+
+	if len(healthCheckRaw) > 0 {
+		healthCheck = &types.HealthCheck{}
+		err := yaml.Unmarshal([]byte(healthCheckRaw), healthCheck)
+		if err != nil {
+			log.Error(err)
+			return nil
+		}
+	}
+
+	return healthCheck
+}
+
+func getRetry(service *v1.Service) *types.Retry {
+	var retry *types.Retry
+
+	retryRaw := getStringValue(service.Annotations, annotationKubernetesRetry, "")
+
+	if len(retryRaw) > 0 {
+		retry = &types.Retry{}
+		err := yaml.Unmarshal([]byte(retryRaw), retry)
+		if err != nil {
+			log.Error(err)
+			return nil
+		}
+	}
+
+	return retry
+}
+
+func getHedging(service *v1.Service) *types.Hedging {
+	var hedging *types.Hedging
+
+	hedgingRaw := getStringValue(service.Annotations, annotationKubernetesHedging, "")
+
+	if len(hedgingRaw) > 0 {
+		hedging = &types.Hedging{}
+		err := yaml.Unmarshal([]byte(hedgingRaw), hedging)
+		if err != nil {
+			log.Error(err)
+			return nil
+		}
+	}
+
+	return hedging
+}
+
 func getLoadBalancer(service *v1.Service) *types.LoadBalancer {
 	loadBalancer := &types.LoadBalancer{
 		Method: "wrr",
 	}
 
-	if getStringValue(service.Annotations, annotationKubernetesLoadBalancerMethod, "") == "drr" {
-		loadBalancer.Method = "drr"
+	if method := getStringValue(service.Annotations, annotationKubernetesLoadBalancerMethod, ""); method != "" {
+		if _, err := types.NewLoadBalancerMethod(&types.LoadBalancer{Method: method}); err == nil {
+			loadBalancer.Method = method
+		} else {
+			log.Warnf("Ignoring annotation %s on service %s/%s: %v", annotationKubernetesLoadBalancerMethod, service.Namespace, service.Name, err)
+		}
 	}
 
 	if sticky := service.Annotations[label.TraefikBackendLoadBalancerSticky]; len(sticky) > 0 {
@@ -524,16 +647,29 @@ func getLoadBalancer(service *v1.Service) *types.LoadBalancer {
 		loadBalancer.Stickiness = stickiness
 	}
 
+	hash := &types.ConsistentHash{
+		Key:        getStringValue(service.Annotations, annotationKubernetesLoadBalancerHashKey, ""),
+		LoadFactor: getFloat64Value(service.Annotations, annotationKubernetesLoadBalancerHashFactor, 0),
+	}
+	if hash.Key != "" || hash.LoadFactor != 0 {
+		loadBalancer.Hash = hash
+	}
+
 	return loadBalancer
 }
 
 func getStickiness(service *v1.Service) *types.Stickiness {
 	if getBoolValue(service.Annotations, annotationKubernetesAffinity, false) {
-		stickiness := &types.Stickiness{}
-		if cookieName := getStringValue(service.Annotations, annotationKubernetesSessionCookieName, ""); len(cookieName) > 0 {
-			stickiness.CookieName = cookieName
+		return &types.Stickiness{
+			CookieName: getStringValue(service.Annotations, annotationKubernetesSessionCookieName, ""),
+			Secret:     getStringValue(service.Annotations, annotationKubernetesSessionCookieSecret, ""),
+			Secure:     getBoolValue(service.Annotations, annotationKubernetesSessionCookieSecure, false),
+			HTTPOnly:   getBoolValue(service.Annotations, annotationKubernetesSessionCookieHTTPOnly, false),
+			SameSite:   getStringValue(service.Annotations, annotationKubernetesSessionCookieSameSite, ""),
+			MaxAge:     getIntValue(service.Annotations, annotationKubernetesSessionCookieMaxAge, 0),
+			Domain:     getStringValue(service.Annotations, annotationKubernetesSessionCookieDomain, ""),
+			Path:       getStringValue(service.Annotations, annotationKubernetesSessionCookiePath, ""),
 		}
-		return stickiness
 	}
 	return nil
 }
@@ -606,6 +742,40 @@ func getErrorPages(i *v1beta1.Ingress) map[string]*types.ErrorPage {
 	return errorPages
 }
 
+func getServiceWeights(i *v1beta1.Ingress) (map[string]int, error) {
+	weightsRaw := getStringValue(i.Annotations, annotationKubernetesServiceWeights, "")
+	if len(weightsRaw) == 0 {
+		return nil, nil
+	}
+
+	var weights map[string]int
+	if err := yaml.Unmarshal([]byte(weightsRaw), &weights); err != nil {
+		return nil, err
+	}
+	if len(weights) == 0 {
+		return nil, fmt.Errorf("no service in annotation %q", annotationKubernetesServiceWeights)
+	}
+	return weights, nil
+}
+
+func addSplitBackend(frontend *types.Frontend, backendName string, weight int, i *v1beta1.Ingress) {
+	if frontend.Split == nil {
+		frontend.Split = &types.TrafficSplit{}
+		if getBoolValue(i.Annotations, annotationKubernetesServiceWeightsAffinity, false) {
+			frontend.Split.Stickiness = &types.Stickiness{
+				CookieName: getStringValue(i.Annotations, annotationKubernetesServiceWeightsCookieName, ""),
+			}
+		}
+	}
+
+	for _, backend := range frontend.Split.Backends {
+		if backend.Backend == backendName {
+			return
+		}
+	}
+	frontend.Split.Backends = append(frontend.Split.Backends, types.WeightedBackend{Backend: backendName, Weight: weight})
+}
+
 func getRateLimit(i *v1beta1.Ingress) *types.RateLimit {
 	var rateLimit *types.RateLimit
 
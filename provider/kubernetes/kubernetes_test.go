@@ -452,6 +452,15 @@ func TestServiceAnnotations(t *testing.T) {
 			sUID("2"),
 			sAnnotation(annotationKubernetesCircuitBreakerExpression, ""),
 			sAnnotation(label.TraefikBackendLoadBalancerSticky, "true"),
+			sAnnotation(annotationKubernetesAffinity, "true"),
+			sAnnotation(annotationKubernetesSessionCookieName, "chocolate"),
+			sAnnotation(annotationKubernetesSessionCookieSecret, "s3cr3t"),
+			sAnnotation(annotationKubernetesSessionCookieSecure, "true"),
+			sAnnotation(annotationKubernetesSessionCookieHTTPOnly, "true"),
+			sAnnotation(annotationKubernetesSessionCookieSameSite, "lax"),
+			sAnnotation(annotationKubernetesSessionCookieMaxAge, "3600"),
+			sAnnotation(annotationKubernetesSessionCookieDomain, "example.com"),
+			sAnnotation(annotationKubernetesSessionCookiePath, "/app"),
 			sSpec(
 				clusterIP("10.0.0.2"),
 				sPorts(sPort(802, ""))),
@@ -460,6 +469,9 @@ func TestServiceAnnotations(t *testing.T) {
 			sName("service3"),
 			sNamespace("testing"),
 			sUID("3"),
+			sAnnotation(annotationKubernetesLoadBalancerMethod, "hash"),
+			sAnnotation(annotationKubernetesLoadBalancerHashKey, "request.cookie.session"),
+			sAnnotation(annotationKubernetesLoadBalancerHashFactor, "1.5"),
 			sAnnotation(annotationKubernetesBuffering, `
 maxrequestbodybytes: 10485760
 memrequestbodybytes: 2097153
@@ -477,6 +489,29 @@ retryexpression: IsNetworkError() && Attempts() <= 2
 			sUID("4"),
 			sAnnotation(annotationKubernetesMaxConnExtractorFunc, "client.ip"),
 			sAnnotation(annotationKubernetesMaxConnAmount, "6"),
+			sAnnotation(annotationKubernetesTransport, `
+dialtimeout: 5s
+maxidleconnsperhost: 50
+tls:
+  ca: /certs/ca.pem
+  servername: backend.example.com
+`),
+			sAnnotation(annotationKubernetesRetry, `
+attempts: 3
+statuscodes: ["502", "503-504"]
+idempotentonly: true
+`),
+			sAnnotation(annotationKubernetesHedging, `
+delay: 50ms
+percentile: 95
+`),
+			sAnnotation(annotationKubernetesHealthCheck, `
+path: /health
+interval: 10s
+method: HEAD
+statuscodes: ["200-299"]
+rise: 2
+`),
 			sSpec(
 				clusterIP("10.0.0.4"),
 				sPorts(sPort(804, ""))),
@@ -556,12 +591,23 @@ retryexpression: IsNetworkError() && Attempts() <= 2
 					server("http://10.15.0.1:8080", weight(1)),
 					server("http://10.15.0.2:8080", weight(1))),
 				lbMethod("wrr"), lbSticky(),
+				lbStickiness(&types.Stickiness{
+					CookieName: "chocolate",
+					Secret:     "s3cr3t",
+					Secure:     true,
+					HTTPOnly:   true,
+					SameSite:   "lax",
+					MaxAge:     3600,
+					Domain:     "example.com",
+					Path:       "/app",
+				}),
 			),
 			backend("baz",
 				servers(
 					server("http://10.14.0.1:8080", weight(1)),
 					server("http://10.12.0.1:8080", weight(1))),
-				lbMethod("wrr"),
+				lbMethod("hash"),
+				lbHash("request.cookie.session", 1.5),
 				buffering(
 					maxRequestBodyBytes(10485760),
 					memRequestBodyBytes(2097153),
@@ -577,6 +623,30 @@ retryexpression: IsNetworkError() && Attempts() <= 2
 				maxConnExtractorFunc("client.ip"),
 				maxConnAmount(6),
 				lbMethod("wrr"),
+				transport(&types.Transport{
+					DialTimeout:         "5s",
+					MaxIdleConnsPerHost: 50,
+					TLS: &types.TransportTLS{
+						CA:         "/certs/ca.pem",
+						ServerName: "backend.example.com",
+					},
+				}),
+				retry(&types.Retry{
+					Attempts:       3,
+					StatusCodes:    []string{"502", "503-504"},
+					IdempotentOnly: true,
+				}),
+				hedging(&types.Hedging{
+					Delay:      "50ms",
+					Percentile: 95,
+				}),
+				healthCheck(&types.HealthCheck{
+					Path:        "/health",
+					Interval:    "10s",
+					Method:      "HEAD",
+					StatusCodes: []string{"200-299"},
+					Rise:        2,
+				}),
 			),
 		),
 		frontends(
@@ -602,6 +672,104 @@ retryexpression: IsNetworkError() && Attempts() <= 2
 	assert.EqualValues(t, expected, actual)
 }
 
+func TestServiceWeights(t *testing.T) {
+	ingresses := []*v1beta1.Ingress{
+		buildIngress(iNamespace("testing"),
+			iAnnotation(annotationKubernetesServiceWeights, `
+service1: 90
+service2: 10
+`),
+			iAnnotation(annotationKubernetesServiceWeightsAffinity, "true"),
+			iAnnotation(annotationKubernetesServiceWeightsCookieName, "canary"),
+			iRules(
+				iRule(
+					iHost("foo"),
+					iPaths(
+						onePath(iPath("/bar"), iBackend("service1", intstr.FromInt(80))),
+						onePath(iPath("/bar"), iBackend("service2", intstr.FromInt(80))),
+						onePath(iPath("/bar"), iBackend("service3", intstr.FromInt(80))))),
+			),
+		),
+	}
+
+	services := []*v1.Service{
+		buildService(
+			sName("service1"),
+			sNamespace("testing"),
+			sUID("1"),
+			sSpec(
+				clusterIP("10.0.0.1"),
+				sPorts(sPort(80, ""))),
+		),
+		buildService(
+			sName("service2"),
+			sNamespace("testing"),
+			sUID("2"),
+			sSpec(
+				clusterIP("10.0.0.2"),
+				sPorts(sPort(80, ""))),
+		),
+	}
+
+	endpoints := []*v1.Endpoints{
+		buildEndpoint(
+			eNamespace("testing"),
+			eName("service1"),
+			eUID("1"),
+			subset(
+				eAddresses(eAddress("10.10.0.1")),
+				ePorts(ePort(8080, ""))),
+		),
+		buildEndpoint(
+			eNamespace("testing"),
+			eName("service2"),
+			eUID("2"),
+			subset(
+				eAddresses(eAddress("10.10.0.2")),
+				ePorts(ePort(8080, ""))),
+		),
+	}
+
+	watchChan := make(chan interface{})
+	client := clientMock{
+		ingresses: ingresses,
+		services:  services,
+		endpoints: endpoints,
+		watchChan: watchChan,
+	}
+	provider := Provider{}
+
+	actual, err := provider.loadIngresses(client)
+	require.NoError(t, err, "error loading ingresses")
+
+	expected := buildConfiguration(
+		backends(
+			backend("foo/bar@service1",
+				servers(server("http://10.10.0.1:8080", weight(1))),
+				lbMethod("wrr"),
+			),
+			backend("foo/bar@service2",
+				servers(server("http://10.10.0.2:8080", weight(1))),
+				lbMethod("wrr"),
+			),
+		),
+		frontends(
+			frontend("foo/bar",
+				passHostHeader(),
+				routes(
+					route("/bar", "PathPrefix:/bar"),
+					route("foo", "Host:foo")),
+				split(
+					splitBackend("foo/bar@service1", 90),
+					splitBackend("foo/bar@service2", 10),
+					splitStickiness("canary")),
+			),
+		),
+	)
+
+	assert.EqualValues(t, expected, actual)
+}
+
 func TestIngressAnnotations(t *testing.T) {
 	ingresses := []*v1beta1.Ingress{
 		buildIngress(
@@ -1206,6 +1374,99 @@ func TestPriorityHeaderValue(t *testing.T) {
 	assert.Equal(t, expected, actual)
 }
 
+func TestServerZones(t *testing.T) {
+	ingresses := []*v1beta1.Ingress{
+		buildIngress(
+			iNamespace("testing"),
+			iRules(
+				iRule(
+					iHost("foo"),
+					iPaths(onePath(iPath("/bar"), iBackend("service1", intstr.FromInt(80))))),
+			),
+		),
+	}
+
+	services := []*v1.Service{
+		buildService(
+			sName("service1"),
+			sNamespace("testing"),
+			sUID("1"),
+			sSpec(
+				clusterIP("10.0.0.1"),
+				sPorts(sPort(80, ""))),
+		),
+	}
+
+	endpoints := []*v1.Endpoints{
+		buildEndpoint(
+			eNamespace("testing"),
+			eName("service1"),
+			eUID("1"),
+			subset(
+				eAddresses(eAddress("10.10.0.1"), eNodeName("node1")),
+				eAddresses(eAddress("10.10.0.2"), eNodeName("node2")),
+				eAddresses(eAddress("10.10.0.3"), eNodeName("unknown")),
+				eAddresses(eAddress("10.10.0.4")),
+				ePorts(ePort(8080, ""))),
+		),
+	}
+
+	nodes := []*v1.Node{
+		{
+			ObjectMeta: v1.ObjectMeta{
+				Name:   "node1",
+				Labels: map[string]string{"failure-domain.beta.kubernetes.io/zone": "zone-a"},
+			},
+		},
+		{
+			ObjectMeta: v1.ObjectMeta{
+				Name: "node2",
+				Labels: map[string]string{
+					"failure-domain.beta.kubernetes.io/zone": "zone-b",
+					"topology.kubernetes.io/zone":            "zone-c",
+				},
+			},
+		},
+	}
+
+	watchChan := make(chan interface{})
+	client := clientMock{
+		ingresses: ingresses,
+		services:  services,
+		endpoints: endpoints,
+		nodes:     nodes,
+		watchChan: watchChan,
+	}
+	provider := Provider{}
+
+	actual, err := provider.loadIngresses(client)
+	require.NoError(t, err, "error loading ingresses")
+
+	expected := buildConfiguration(
+		backends(
+			backend("foo/bar",
+				servers(
+					server("http://10.10.0.1:8080", weight(1), zone("zone-a")),
+					server("http://10.10.0.2:8080", weight(1), zone("zone-c")),
+					server("http://10.10.0.3:8080", weight(1)),
+					server("http://10.10.0.4:8080", weight(1)),
+				),
+				lbMethod("wrr"),
+			),
+		),
+		frontends(
+			frontend("foo/bar",
+				passHostHeader(),
+				routes(
+					route("/bar", "PathPrefix:/bar"),
+					route("foo", "Host:foo")),
+			),
+		),
+	)
+
+	assert.Equal(t, expected, actual)
+}
+
 func TestInvalidPassTLSCertValue(t *testing.T) {
 	ingresses := []*v1beta1.Ingress{
 		buildIngress(
@@ -110,6 +110,12 @@ func withLimit(name string, average, burst, period string) func(map[string]strin
 	}
 }
 
+func withSplitBackend(name string, weight string) func(map[string]string) {
+	return func(pairs map[string]string) {
+		pairs[pathFrontendSplitBackends+name+pathFrontendSplitWeight] = weight
+	}
+}
+
 func TestFiller(t *testing.T) {
 	expected := []*store.KVPair{
 		{Key: "traefik/backends/backend.with.dot.too", Value: []byte("")},
@@ -3,13 +3,26 @@ package kv
 const (
 	pathBackends                                = "/backends/"
 	pathBackendCircuitBreakerExpression         = "/circuitbreaker/expression"
+	pathBackendHealthCheckType                  = "/healthcheck/type"
 	pathBackendHealthCheckPath                  = "/healthcheck/path"
 	pathBackendHealthCheckPort                  = "/healthcheck/port"
 	pathBackendHealthCheckInterval              = "/healthcheck/interval"
+	pathBackendHealthCheckTimeout               = "/healthcheck/timeout"
+	pathBackendHealthCheckScheme                = "/healthcheck/scheme"
+	pathBackendHealthCheckMethod                = "/healthcheck/method"
+	pathBackendHealthCheckHostname              = "/healthcheck/hostname"
+	pathBackendHealthCheckHeaders               = "/healthcheck/headers/"
+	pathBackendHealthCheckStatusCodes           = "/healthcheck/statuscodes"
+	pathBackendHealthCheckBody                  = "/healthcheck/body"
+	pathBackendHealthCheckRise                  = "/healthcheck/rise"
+	pathBackendHealthCheckFall                  = "/healthcheck/fall"
+	pathBackendHealthCheckGRPCService           = "/healthcheck/grpcservice"
 	pathBackendLoadBalancerMethod               = "/loadbalancer/method"
 	pathBackendLoadBalancerSticky               = "/loadbalancer/sticky"
 	pathBackendLoadBalancerStickiness           = "/loadbalancer/stickiness"
 	pathBackendLoadBalancerStickinessCookieName = "/loadbalancer/stickiness/cookiename"
+	pathBackendLoadBalancerHashKey              = "/loadbalancer/hash/key"
+	pathBackendLoadBalancerHashLoadFactor       = "/loadbalancer/hash/loadfactor"
 	pathBackendMaxConnAmount                    = "/maxconn/amount"
 	pathBackendMaxConnExtractorFunc             = "/maxconn/extractorfunc"
 	pathBackendServers                          = "/servers/"
@@ -21,6 +34,31 @@ const (
 	pathBackendBufferingMaxRequestBodyBytes     = pathBackendBuffering + "maxrequestbodybytes"
 	pathBackendBufferingMemRequestBodyBytes     = pathBackendBuffering + "memrequestbodybytes"
 	pathBackendBufferingRetryExpression         = pathBackendBuffering + "retryexpression"
+	pathBackendProtocol                         = "/protocol"
+	pathBackendTransport                        = "/transport/"
+	pathBackendTransportDialTimeout             = pathBackendTransport + "dialtimeout"
+	pathBackendTransportResponseHeaderTimeout   = pathBackendTransport + "responseheadertimeout"
+	pathBackendTransportIdleConnTimeout         = pathBackendTransport + "idleconntimeout"
+	pathBackendTransportMaxIdleConnsPerHost     = pathBackendTransport + "maxidleconnsperhost"
+	pathBackendTransportDisableKeepAlives       = pathBackendTransport + "disablekeepalives"
+	pathBackendTransportDisableHTTP2            = pathBackendTransport + "disablehttp2"
+	pathBackendTransportTLS                     = pathBackendTransport + "tls/"
+	pathBackendTransportTLSCA                   = pathBackendTransportTLS + "ca"
+	pathBackendTransportTLSServerName           = pathBackendTransportTLS + "servername"
+	pathBackendTransportTLSCert                 = pathBackendTransportTLS + "cert"
+	pathBackendTransportTLSKey                  = pathBackendTransportTLS + "key"
+	pathBackendTransportTLSInsecureSkipVerify   = pathBackendTransportTLS + "insecureskipverify"
+	pathBackendRetry                            = "/retry/"
+	pathBackendRetryAttempts                    = pathBackendRetry + "attempts"
+	pathBackendRetryStatusCodes                 = pathBackendRetry + "statuscodes"
+	pathBackendRetryIdempotentOnly              = pathBackendRetry + "idempotentonly"
+	pathBackendRetryPerTryTimeout               = pathBackendRetry + "pertrytimeout"
+	pathBackendRetryBackoff                     = pathBackendRetry + "backoff"
+	pathBackendRetryMaxBackoff                  = pathBackendRetry + "maxbackoff"
+	pathBackendRetryBudgetPercent               = pathBackendRetry + "budgetpercent"
+	pathBackendHedging                          = "/hedging/"
+	pathBackendHedgingDelay                     = pathBackendHedging + "delay"
+	pathBackendHedgingPercentile                = pathBackendHedging + "percentile"
 
 	pathFrontends                      = "/frontends/"
 	pathFrontendBackend                = "/backend"
@@ -44,6 +82,19 @@ const (
 	pathFrontendRateLimitPeriod        = "/period"
 	pathFrontendRateLimitAverage       = "/average"
 	pathFrontendRateLimitBurst         = "/burst"
+	pathFrontendSplitBackends          = "/split/backends/"
+	pathFrontendSplitWeight            = "/weight"
+	pathFrontendSplitStickiness        = "/split/stickiness"
+	pathFrontendSplitCookieName        = "/split/stickiness/cookiename"
+
+	pathStickinessCookieName = "/cookiename"
+	pathStickinessSecret     = "/secret"
+	pathStickinessSecure     = "/secure"
+	pathStickinessHTTPOnly   = "/httponly"
+	pathStickinessSameSite   = "/samesite"
+	pathStickinessMaxAge     = "/maxage"
+	pathStickinessDomain     = "/domain"
+	pathStickinessPath       = "/path"
 
 	pathFrontendCustomRequestHeaders    = "/headers/customrequestheaders/"
 	pathFrontendCustomResponseHeaders   = "/headers/customresponseheaders/"
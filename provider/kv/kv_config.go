@@ -51,6 +51,7 @@ func (p *Provider) buildConfiguration() *types.Configuration {
 		"getRoutes":               p.getRoutes,
 		"getRedirect":             p.getRedirect,
 		"getErrorPages":           p.getErrorPages,
+		"getSplit":                p.getSplit,
 		"getRateLimit":            p.getRateLimit,
 		"getHeaders":              p.getHeaders,
 
@@ -61,6 +62,10 @@ func (p *Provider) buildConfiguration() *types.Configuration {
 		"getMaxConn":              p.getMaxConn,
 		"getHealthCheck":          p.getHealthCheck,
 		"getBuffering":            p.getBuffering,
+		"getTransport":            p.getTransport,
+		"getRetry":                p.getRetry,
+		"getHedging":              p.getHedging,
+		"getBackendProtocol":      p.getBackendProtocol,
 		"getSticky":               p.getSticky,               // Deprecated [breaking]
 		"hasStickinessLabel":      p.hasStickinessLabel,      // Deprecated [breaking]
 		"getStickinessCookieName": p.getStickinessCookieName, // Deprecated [breaking]
@@ -150,6 +155,27 @@ func (p *Provider) getErrorPages(rootPath string) map[string]*types.ErrorPage {
 	return errorPages
 }
 
+func (p *Provider) getSplit(rootPath string) *types.TrafficSplit {
+	var split *types.TrafficSplit
+
+	for _, pathBackend := range p.list(rootPath, pathFrontendSplitBackends) {
+		if split == nil {
+			split = &types.TrafficSplit{}
+		}
+
+		split.Backends = append(split.Backends, types.WeightedBackend{
+			Backend: p.last(pathBackend),
+			Weight:  p.getInt(0, pathBackend, pathFrontendSplitWeight),
+		})
+	}
+
+	if split != nil {
+		split.Stickiness = p.getStickiness(rootPath, pathFrontendSplitStickiness)
+	}
+
+	return split
+}
+
 func (p *Provider) getRateLimit(rootPath string) *types.RateLimit {
 	extractorFunc := p.get("", rootPath, pathFrontendRateLimitExtractorFunc)
 	if len(extractorFunc) == 0 {
@@ -225,15 +251,35 @@ func (p *Provider) getLoadBalancer(rootPath string) *types.LoadBalancer {
 		Sticky: p.getSticky(rootPath),
 	}
 
-	if p.getBool(false, rootPath, pathBackendLoadBalancerStickiness) {
-		lb.Stickiness = &types.Stickiness{
-			CookieName: p.get("", rootPath, pathBackendLoadBalancerStickinessCookieName),
+	lb.Stickiness = p.getStickiness(rootPath, pathBackendLoadBalancerStickiness)
+
+	if p.has(rootPath, pathBackendLoadBalancerHashKey) || p.has(rootPath, pathBackendLoadBalancerHashLoadFactor) {
+		lb.Hash = &types.ConsistentHash{
+			Key:        p.get("", rootPath, pathBackendLoadBalancerHashKey),
+			LoadFactor: p.getFloat64(0, rootPath, pathBackendLoadBalancerHashLoadFactor),
 		}
 	}
 
 	return lb
 }
 
+func (p *Provider) getStickiness(rootPath string, stickinessPath string) *types.Stickiness {
+	if !p.getBool(false, rootPath, stickinessPath) {
+		return nil
+	}
+
+	return &types.Stickiness{
+		CookieName: p.get("", rootPath, stickinessPath, pathStickinessCookieName),
+		Secret:     p.get("", rootPath, stickinessPath, pathStickinessSecret),
+		Secure:     p.getBool(false, rootPath, stickinessPath, pathStickinessSecure),
+		HTTPOnly:   p.getBool(false, rootPath, stickinessPath, pathStickinessHTTPOnly),
+		SameSite:   p.get("", rootPath, stickinessPath, pathStickinessSameSite),
+		MaxAge:     p.getInt(0, rootPath, stickinessPath, pathStickinessMaxAge),
+		Domain:     p.get("", rootPath, stickinessPath, pathStickinessDomain),
+		Path:       p.get("", rootPath, stickinessPath, pathStickinessPath),
+	}
+}
+
 func (p *Provider) getCircuitBreaker(rootPath string) *types.CircuitBreaker {
 	if !p.has(rootPath, pathBackendCircuitBreakerExpression) {
 		return nil
@@ -263,8 +309,9 @@ func (p *Provider) getMaxConn(rootPath string) *types.MaxConn {
 
 func (p *Provider) getHealthCheck(rootPath string) *types.HealthCheck {
 	path := p.get("", rootPath, pathBackendHealthCheckPath)
+	hcType := p.get("", rootPath, pathBackendHealthCheckType)
 
-	if len(path) == 0 {
+	if len(path) == 0 && len(hcType) == 0 {
 		return nil
 	}
 
@@ -272,9 +319,20 @@ func (p *Provider) getHealthCheck(rootPath string) *types.HealthCheck {
 	interval := p.get("30s", rootPath, pathBackendHealthCheckInterval)
 
 	return &types.HealthCheck{
-		Path:     path,
-		Port:     port,
-		Interval: interval,
+		Type:        hcType,
+		Path:        path,
+		Port:        port,
+		Interval:    interval,
+		Timeout:     p.get("", rootPath, pathBackendHealthCheckTimeout),
+		Scheme:      p.get("", rootPath, pathBackendHealthCheckScheme),
+		Method:      p.get("", rootPath, pathBackendHealthCheckMethod),
+		Hostname:    p.get("", rootPath, pathBackendHealthCheckHostname),
+		Headers:     p.getMap(rootPath, pathBackendHealthCheckHeaders),
+		StatusCodes: p.getList(rootPath, pathBackendHealthCheckStatusCodes),
+		Body:        p.get("", rootPath, pathBackendHealthCheckBody),
+		Rise:        p.getInt(0, rootPath, pathBackendHealthCheckRise),
+		Fall:        p.getInt(0, rootPath, pathBackendHealthCheckFall),
+		GRPCService: p.get("", rootPath, pathBackendHealthCheckGRPCService),
 	}
 }
 
@@ -297,6 +355,64 @@ func (p *Provider) getBuffering(rootPath string) *types.Buffering {
 	return buffering
 }
 
+func (p *Provider) getTransport(rootPath string) *types.Transport {
+	if len(p.list(rootPath, pathBackendTransport)) == 0 {
+		return nil
+	}
+
+	transport := &types.Transport{
+		DialTimeout:           p.get("", rootPath, pathBackendTransportDialTimeout),
+		ResponseHeaderTimeout: p.get("", rootPath, pathBackendTransportResponseHeaderTimeout),
+		IdleConnTimeout:       p.get("", rootPath, pathBackendTransportIdleConnTimeout),
+		MaxIdleConnsPerHost:   p.getInt(0, rootPath, pathBackendTransportMaxIdleConnsPerHost),
+		DisableKeepAlives:     p.getBool(false, rootPath, pathBackendTransportDisableKeepAlives),
+		DisableHTTP2:          p.getBool(false, rootPath, pathBackendTransportDisableHTTP2),
+	}
+
+	if len(p.list(rootPath, pathBackendTransportTLS)) > 0 {
+		transport.TLS = &types.TransportTLS{
+			CA:                 p.get("", rootPath, pathBackendTransportTLSCA),
+			ServerName:         p.get("", rootPath, pathBackendTransportTLSServerName),
+			Cert:               p.get("", rootPath, pathBackendTransportTLSCert),
+			Key:                p.get("", rootPath, pathBackendTransportTLSKey),
+			InsecureSkipVerify: p.getBool(false, rootPath, pathBackendTransportTLSInsecureSkipVerify),
+		}
+	}
+
+	return transport
+}
+
+func (p *Provider) getRetry(rootPath string) *types.Retry {
+	if len(p.list(rootPath, pathBackendRetry)) == 0 {
+		return nil
+	}
+
+	return &types.Retry{
+		Attempts:       p.getInt(0, rootPath, pathBackendRetryAttempts),
+		StatusCodes:    p.getList(rootPath, pathBackendRetryStatusCodes),
+		IdempotentOnly: p.getBool(false, rootPath, pathBackendRetryIdempotentOnly),
+		PerTryTimeout:  p.get("", rootPath, pathBackendRetryPerTryTimeout),
+		Backoff:        p.get("", rootPath, pathBackendRetryBackoff),
+		MaxBackoff:     p.get("", rootPath, pathBackendRetryMaxBackoff),
+		BudgetPercent:  p.getInt(0, rootPath, pathBackendRetryBudgetPercent),
+	}
+}
+
+func (p *Provider) getHedging(rootPath string) *types.Hedging {
+	if len(p.list(rootPath, pathBackendHedging)) == 0 {
+		return nil
+	}
+
+	return &types.Hedging{
+		Delay:      p.get("", rootPath, pathBackendHedgingDelay),
+		Percentile: p.getInt(0, rootPath, pathBackendHedgingPercentile),
+	}
+}
+
+func (p *Provider) getBackendProtocol(rootPath string) string {
+	return p.get("", rootPath, pathBackendProtocol)
+}
+
 func (p *Provider) getTLSSection(prefix string) []*tls.Configuration {
 	var tlsSection []*tls.Configuration
 
@@ -506,6 +622,21 @@ func (p *Provider) getInt64(defaultValue int64, keyParts ...string) int64 {
 	return value
 }
 
+func (p *Provider) getFloat64(defaultValue float64, keyParts ...string) float64 {
+	rawValue := p.get("", keyParts...)
+
+	if len(rawValue) == 0 {
+		return defaultValue
+	}
+
+	value, err := strconv.ParseFloat(rawValue, 64)
+	if err != nil {
+		log.Errorf("Invalid value for %v: %s", keyParts, rawValue)
+		return defaultValue
+	}
+	return value
+}
+
 func (p *Provider) list(keyParts ...string) []string {
 	rootKey := strings.Join(keyParts, "")
 
@@ -71,6 +71,15 @@ func TestProviderBuildConfiguration(t *testing.T) {
 					withPair(pathBackendLoadBalancerSticky, "true"),
 					withPair(pathBackendLoadBalancerStickiness, "true"),
 					withPair(pathBackendLoadBalancerStickinessCookieName, "tomate"),
+					withPair(pathBackendLoadBalancerStickiness+pathStickinessSecret, "s3cr3t"),
+					withPair(pathBackendLoadBalancerStickiness+pathStickinessSecure, "true"),
+					withPair(pathBackendLoadBalancerStickiness+pathStickinessHTTPOnly, "true"),
+					withPair(pathBackendLoadBalancerStickiness+pathStickinessSameSite, "lax"),
+					withPair(pathBackendLoadBalancerStickiness+pathStickinessMaxAge, "3600"),
+					withPair(pathBackendLoadBalancerStickiness+pathStickinessDomain, "example.com"),
+					withPair(pathBackendLoadBalancerStickiness+pathStickinessPath, "/app"),
+					withPair(pathBackendLoadBalancerHashKey, "request.header.X-User"),
+					withPair(pathBackendLoadBalancerHashLoadFactor, "1.5"),
 					withPair(pathBackendHealthCheckPath, "/health"),
 					withPair(pathBackendHealthCheckPort, "80"),
 					withPair(pathBackendHealthCheckInterval, "30s"),
@@ -81,6 +90,11 @@ func TestProviderBuildConfiguration(t *testing.T) {
 					withPair(pathBackendBufferingMaxRequestBodyBytes, "10485760"),
 					withPair(pathBackendBufferingMemRequestBodyBytes, "2097152"),
 					withPair(pathBackendBufferingRetryExpression, "IsNetworkError() && Attempts() <= 2"),
+					withPair(pathBackendProtocol, "h2c"),
+					withPair(pathBackendTransportResponseHeaderTimeout, "30s"),
+					withPair(pathBackendTransportDisableKeepAlives, "true"),
+					withPair(pathBackendTransportTLSCert, "/certs/client.pem"),
+					withPair(pathBackendTransportTLSKey, "/certs/client.key"),
 					withPair("servers/server1/url", "http://172.17.0.2:80"),
 					withPair("servers/server1/weight", "0"),
 					withPair("servers/server2/weight", "0")),
@@ -101,6 +115,10 @@ func TestProviderBuildConfiguration(t *testing.T) {
 					withRateLimit("client.ip",
 						withLimit("foo", "6", "12", "18"),
 						withLimit("bar", "3", "6", "9")),
+					withSplitBackend("backend1", "90"),
+					withSplitBackend("backend2", "10"),
+					withPair(pathFrontendSplitStickiness, "true"),
+					withPair(pathFrontendSplitCookieName, "canary"),
 
 					withPair(pathFrontendCustomRequestHeaders+"Access-Control-Allow-Methods", "POST,GET,OPTIONS"),
 					withPair(pathFrontendCustomRequestHeaders+"Content-Type", "application/json; charset=utf-8"),
@@ -157,6 +175,17 @@ func TestProviderBuildConfiguration(t *testing.T) {
 							Sticky: true,
 							Stickiness: &types.Stickiness{
 								CookieName: "tomate",
+								Secret:     "s3cr3t",
+								Secure:     true,
+								HTTPOnly:   true,
+								SameSite:   "lax",
+								MaxAge:     3600,
+								Domain:     "example.com",
+								Path:       "/app",
+							},
+							Hash: &types.ConsistentHash{
+								Key:        "request.header.X-User",
+								LoadFactor: 1.5,
 							},
 						},
 						MaxConn: &types.MaxConn{
@@ -175,6 +204,15 @@ func TestProviderBuildConfiguration(t *testing.T) {
 							MemRequestBodyBytes:  2097152,
 							RetryExpression:      "IsNetworkError() && Attempts() <= 2",
 						},
+						Protocol: "h2c",
+						Transport: &types.Transport{
+							ResponseHeaderTimeout: "30s",
+							DisableKeepAlives:     true,
+							TLS: &types.TransportTLS{
+								Cert: "/certs/client.pem",
+								Key:  "/certs/client.key",
+							},
+						},
 					},
 				},
 				Frontends: map[string]*types.Frontend{
@@ -216,6 +254,13 @@ func TestProviderBuildConfiguration(t *testing.T) {
 								},
 							},
 						},
+						Split: &types.TrafficSplit{
+							Backends: []types.WeightedBackend{
+								{Backend: "backend1", Weight: 90},
+								{Backend: "backend2", Weight: 10},
+							},
+							Stickiness: &types.Stickiness{CookieName: "canary"},
+						},
 						Routes: map[string]types.Route{
 							"route1": {
 								Rule: "Host:test.localhost",
@@ -1118,6 +1163,70 @@ func TestProviderGetRedirect(t *testing.T) {
 	}
 }
 
+func TestProviderGetSplit(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		rootPath string
+		kvPairs  []*store.KVPair
+		expected *types.TrafficSplit
+	}{
+		{
+			desc:     "weighted backends",
+			rootPath: "traefik/frontends/foo",
+			kvPairs: filler("traefik",
+				frontend("foo",
+					withSplitBackend("v1", "90"),
+					withSplitBackend("v2", "10"))),
+			expected: &types.TrafficSplit{
+				Backends: []types.WeightedBackend{
+					{Backend: "v1", Weight: 90},
+					{Backend: "v2", Weight: 10},
+				},
+			},
+		},
+		{
+			desc:     "sticky",
+			rootPath: "traefik/frontends/foo",
+			kvPairs: filler("traefik",
+				frontend("foo",
+					withSplitBackend("v1", "1"),
+					withPair(pathFrontendSplitStickiness, "true"),
+					withPair(pathFrontendSplitCookieName, "canary"))),
+			expected: &types.TrafficSplit{
+				Backends:   []types.WeightedBackend{{Backend: "v1", Weight: 1}},
+				Stickiness: &types.Stickiness{CookieName: "canary"},
+			},
+		},
+		{
+			desc:     "stickiness without backends",
+			rootPath: "traefik/frontends/foo",
+			kvPairs: filler("traefik",
+				frontend("foo",
+					withPair(pathFrontendSplitStickiness, "true"))),
+			expected: nil,
+		},
+		{
+			desc:     "when no split keys",
+			rootPath: "traefik/frontends/foo",
+			kvPairs:  filler("traefik", frontend("foo")),
+			expected: nil,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			p := newProviderMock(test.kvPairs)
+
+			actual := p.getSplit(test.rootPath)
+
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
 func TestProviderGetErrorPages(t *testing.T) {
 	testCases := []struct {
 		desc     string
@@ -1703,6 +1812,54 @@ func TestProviderGetHealthCheck(t *testing.T) {
 				Port:     80,
 			},
 		},
+		{
+			desc:     "when only the type key is defined",
+			rootPath: "traefik/backends/foo",
+			kvPairs: filler("traefik",
+				backend("foo",
+					withPair(pathBackendHealthCheckType, "grpc"),
+					withPair(pathBackendHealthCheckGRPCService, "foo.Bar"),
+					withPair(pathBackendHealthCheckPort, "80"),
+					withPair(pathBackendHealthCheckInterval, "10s"))),
+			expected: &types.HealthCheck{
+				Interval:    "10s",
+				Type:        "grpc",
+				GRPCService: "foo.Bar",
+				Port:        80,
+			},
+		},
+		{
+			desc:     "when the request and response keys are defined",
+			rootPath: "traefik/backends/foo",
+			kvPairs: filler("traefik",
+				backend("foo",
+					withPair(pathBackendHealthCheckPath, "/health"),
+					withPair(pathBackendHealthCheckPort, "80"),
+					withPair(pathBackendHealthCheckInterval, "10s"),
+					withPair(pathBackendHealthCheckTimeout, "3s"),
+					withPair(pathBackendHealthCheckScheme, "https"),
+					withPair(pathBackendHealthCheckMethod, "HEAD"),
+					withPair(pathBackendHealthCheckHostname, "foo.localhost"),
+					withPair(pathBackendHealthCheckHeaders+"X-Health", "1"),
+					withPair(pathBackendHealthCheckStatusCodes, "200-299,304"),
+					withPair(pathBackendHealthCheckBody, "ok"),
+					withPair(pathBackendHealthCheckRise, "3"),
+					withPair(pathBackendHealthCheckFall, "2"))),
+			expected: &types.HealthCheck{
+				Interval:    "10s",
+				Path:        "/health",
+				Port:        80,
+				Timeout:     "3s",
+				Scheme:      "https",
+				Method:      "HEAD",
+				Hostname:    "foo.localhost",
+				Headers:     map[string]string{"X-Health": "1"},
+				StatusCodes: []string{"200-299", "304"},
+				Body:        "ok",
+				Rise:        3,
+				Fall:        2,
+			},
+		},
 		{
 			desc:     "when only path defined",
 			rootPath: "traefik/backends/foo",
@@ -1781,6 +1938,145 @@ func TestProviderGetBufferingReal(t *testing.T) {
 	}
 }
 
+func TestProviderGetTransportReal(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		rootPath string
+		kvPairs  []*store.KVPair
+		expected *types.Transport
+	}{
+		{
+			desc:     "when all configuration keys defined",
+			rootPath: "traefik/backends/foo",
+			kvPairs: filler("traefik",
+				backend("foo",
+					withPair(pathBackendTransportDialTimeout, "5s"),
+					withPair(pathBackendTransportMaxIdleConnsPerHost, "50"),
+					withPair(pathBackendTransportDisableHTTP2, "true"),
+					withPair(pathBackendTransportTLSCA, "/certs/ca.pem"),
+					withPair(pathBackendTransportTLSInsecureSkipVerify, "true"))),
+			expected: &types.Transport{
+				DialTimeout:         "5s",
+				MaxIdleConnsPerHost: 50,
+				DisableHTTP2:        true,
+				TLS: &types.TransportTLS{
+					CA:                 "/certs/ca.pem",
+					InsecureSkipVerify: true,
+				},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			p := newProviderMock(test.kvPairs)
+
+			result := p.getTransport(test.rootPath)
+
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestProviderGetRetryReal(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		rootPath string
+		kvPairs  []*store.KVPair
+		expected *types.Retry
+	}{
+		{
+			desc:     "when no retry keys",
+			rootPath: "traefik/backends/foo",
+			kvPairs: filler("traefik",
+				backend("foo",
+					withPair(pathBackendProtocol, "http"))),
+			expected: nil,
+		},
+		{
+			desc:     "when all configuration keys defined",
+			rootPath: "traefik/backends/foo",
+			kvPairs: filler("traefik",
+				backend("foo",
+					withPair(pathBackendRetryAttempts, "3"),
+					withPair(pathBackendRetryStatusCodes, "502,503-504"),
+					withPair(pathBackendRetryIdempotentOnly, "true"),
+					withPair(pathBackendRetryPerTryTimeout, "2s"),
+					withPair(pathBackendRetryBackoff, "100ms"),
+					withPair(pathBackendRetryMaxBackoff, "1s"),
+					withPair(pathBackendRetryBudgetPercent, "20"))),
+			expected: &types.Retry{
+				Attempts:       3,
+				StatusCodes:    []string{"502", "503-504"},
+				IdempotentOnly: true,
+				PerTryTimeout:  "2s",
+				Backoff:        "100ms",
+				MaxBackoff:     "1s",
+				BudgetPercent:  20,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			p := newProviderMock(test.kvPairs)
+
+			result := p.getRetry(test.rootPath)
+
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestProviderGetHedgingReal(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		rootPath string
+		kvPairs  []*store.KVPair
+		expected *types.Hedging
+	}{
+		{
+			desc:     "when no hedging keys",
+			rootPath: "traefik/backends/foo",
+			kvPairs: filler("traefik",
+				backend("foo",
+					withPair(pathBackendProtocol, "http"))),
+			expected: nil,
+		},
+		{
+			desc:     "when all configuration keys defined",
+			rootPath: "traefik/backends/foo",
+			kvPairs: filler("traefik",
+				backend("foo",
+					withPair(pathBackendHedgingDelay, "50ms"),
+					withPair(pathBackendHedgingPercentile, "95"))),
+			expected: &types.Hedging{
+				Delay:      "50ms",
+				Percentile: 95,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			p := newProviderMock(test.kvPairs)
+
+			result := p.getHedging(test.rootPath)
+
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
 func TestProviderGetTLSes(t *testing.T) {
 	testCases := []struct {
 		desc     string
@@ -141,6 +141,26 @@ func GetInt64ValueP(labels *map[string]string, labelName string, defaultValue in
 	return GetInt64Value(*labels, labelName, defaultValue)
 }
 
+// GetFloat64Value get float64 value associated to a label
+func GetFloat64Value(labels map[string]string, labelName string, defaultValue float64) float64 {
+	if rawValue, ok := labels[labelName]; ok {
+		value, err := strconv.ParseFloat(rawValue, 64)
+		if err == nil {
+			return value
+		}
+		log.Errorf("Unable to parse %q: %q, falling back to %v. %v", labelName, rawValue, defaultValue, err)
+	}
+	return defaultValue
+}
+
+// GetFloat64ValueP get float64 value associated to a label
+func GetFloat64ValueP(labels *map[string]string, labelName string, defaultValue float64) float64 {
+	if labels == nil {
+		return defaultValue
+	}
+	return GetFloat64Value(*labels, labelName, defaultValue)
+}
+
 // GetSliceStringValue get a slice of string associated to a label
 func GetSliceStringValue(labels map[string]string, labelName string) []string {
 	var value []string
@@ -198,6 +218,28 @@ func GetMapValue(labels map[string]string, labelName string) map[string]string {
 	return nil
 }
 
+// GetWeightedBackends get the weighted backends associated to a label, formatted as "backend1:weight1, backend2:weight2"
+func GetWeightedBackends(labels map[string]string, labelName string) []types.WeightedBackend {
+	var backends []types.WeightedBackend
+
+	for _, part := range GetSliceStringValue(labels, labelName) {
+		pair := strings.SplitN(part, mapValueSeparator, 2)
+		if len(pair) != 2 {
+			log.Errorf("Could not load %q: %q, skipping...", labelName, part)
+			continue
+		}
+
+		weight, err := strconv.Atoi(strings.TrimSpace(pair[1]))
+		if err != nil {
+			log.Errorf("Unable to parse the weight of %q: %q. %v", labelName, part, err)
+			continue
+		}
+		backends = append(backends, types.WeightedBackend{Backend: strings.TrimSpace(pair[0]), Weight: weight})
+	}
+
+	return backends
+}
+
 // GetStringMultipleStrict get multiple string values associated to several labels
 // Fail if one label is missing
 func GetStringMultipleStrict(labels map[string]string, labelNames ...string) (map[string]string, error) {
@@ -360,6 +360,101 @@ func TestGetInt64Value(t *testing.T) {
 	}
 }
 
+func TestGetWeightedBackends(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		labels    map[string]string
+		labelName string
+		expected  []types.WeightedBackend
+	}{
+		{
+			desc:      "empty map",
+			labelName: "foo",
+		},
+		{
+			desc:      "weighted backends",
+			labelName: "foo",
+			labels: map[string]string{
+				"foo": "v1:90, v2 : 10",
+			},
+			expected: []types.WeightedBackend{
+				{Backend: "v1", Weight: 90},
+				{Backend: "v2", Weight: 10},
+			},
+		},
+		{
+			desc:      "invalid entries are skipped",
+			labelName: "foo",
+			labels: map[string]string{
+				"foo": "v1,v2:ten,v3:1",
+			},
+			expected: []types.WeightedBackend{
+				{Backend: "v3", Weight: 1},
+			},
+		},
+	}
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			got := GetWeightedBackends(test.labels, test.labelName)
+			assert.Equal(t, test.expected, got)
+		})
+	}
+}
+
+func TestGetFloat64Value(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		labels       map[string]string
+		labelName    string
+		defaultValue float64
+		expected     float64
+	}{
+		{
+			desc:      "empty map",
+			labelName: "foo",
+		},
+		{
+			desc:      "invalid float value",
+			labelName: "foo",
+			labels: map[string]string{
+				"foo": "bar",
+			},
+			defaultValue: 1.5,
+			expected:     1.5,
+		},
+		{
+			desc:      "float value",
+			labelName: "foo",
+			labels: map[string]string{
+				"foo": "1.25",
+			},
+			defaultValue: 1.5,
+			expected:     1.25,
+		},
+		{
+			desc:      "int value",
+			labelName: "foo",
+			labels: map[string]string{
+				"foo": "2",
+			},
+			defaultValue: 1.5,
+			expected:     2,
+		},
+	}
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			got := GetFloat64Value(test.labels, test.labelName, test.defaultValue)
+			assert.Equal(t, test.expected, got)
+		})
+	}
+}
+
 func TestGetInt64ValueP(t *testing.T) {
 	testCases := []struct {
 		desc         string
@@ -14,14 +14,35 @@ const (
 	SuffixBackendID                                = "backend.id"
 	SuffixBackendCircuitBreaker                    = "backend.circuitbreaker"
 	SuffixBackendCircuitBreakerExpression          = "backend.circuitbreaker.expression"
+	SuffixBackendHealthCheckType                   = "backend.healthcheck.type"
 	SuffixBackendHealthCheckPath                   = "backend.healthcheck.path"
 	SuffixBackendHealthCheckPort                   = "backend.healthcheck.port"
 	SuffixBackendHealthCheckInterval               = "backend.healthcheck.interval"
+	SuffixBackendHealthCheckTimeout                = "backend.healthcheck.timeout"
+	SuffixBackendHealthCheckScheme                 = "backend.healthcheck.scheme"
+	SuffixBackendHealthCheckMethod                 = "backend.healthcheck.method"
+	SuffixBackendHealthCheckHostname               = "backend.healthcheck.hostname"
+	SuffixBackendHealthCheckHeaders                = "backend.healthcheck.headers"
+	SuffixBackendHealthCheckStatusCodes            = "backend.healthcheck.statusCodes"
+	SuffixBackendHealthCheckBody                   = "backend.healthcheck.body"
+	SuffixBackendHealthCheckRise                   = "backend.healthcheck.rise"
+	SuffixBackendHealthCheckFall                   = "backend.healthcheck.fall"
+	SuffixBackendHealthCheckGRPCService            = "backend.healthcheck.grpcService"
 	SuffixBackendLoadBalancer                      = "backend.loadbalancer"
 	SuffixBackendLoadBalancerMethod                = SuffixBackendLoadBalancer + ".method"
 	SuffixBackendLoadBalancerSticky                = SuffixBackendLoadBalancer + ".sticky"
 	SuffixBackendLoadBalancerStickiness            = SuffixBackendLoadBalancer + ".stickiness"
 	SuffixBackendLoadBalancerStickinessCookieName  = SuffixBackendLoadBalancer + ".stickiness.cookieName"
+	SuffixBackendLoadBalancerStickinessSecret      = SuffixBackendLoadBalancer + ".stickiness.secret"
+	SuffixBackendLoadBalancerStickinessSecure      = SuffixBackendLoadBalancer + ".stickiness.secure"
+	SuffixBackendLoadBalancerStickinessHTTPOnly    = SuffixBackendLoadBalancer + ".stickiness.httpOnly"
+	SuffixBackendLoadBalancerStickinessSameSite    = SuffixBackendLoadBalancer + ".stickiness.sameSite"
+	SuffixBackendLoadBalancerStickinessMaxAge      = SuffixBackendLoadBalancer + ".stickiness.maxAge"
+	SuffixBackendLoadBalancerStickinessDomain      = SuffixBackendLoadBalancer + ".stickiness.domain"
+	SuffixBackendLoadBalancerStickinessPath        = SuffixBackendLoadBalancer + ".stickiness.path"
+	SuffixBackendLoadBalancerHash                  = SuffixBackendLoadBalancer + ".hash"
+	SuffixBackendLoadBalancerHashKey               = SuffixBackendLoadBalancer + ".hash.key"
+	SuffixBackendLoadBalancerHashLoadFactor        = SuffixBackendLoadBalancer + ".hash.loadFactor"
 	SuffixBackendMaxConnAmount                     = "backend.maxconn.amount"
 	SuffixBackendMaxConnExtractorFunc              = "backend.maxconn.extractorfunc"
 	SuffixBackendBuffering                         = "backend.buffering"
@@ -30,6 +51,31 @@ const (
 	SuffixBackendBufferingMaxResponseBodyBytes     = SuffixBackendBuffering + ".maxResponseBodyBytes"
 	SuffixBackendBufferingMemResponseBodyBytes     = SuffixBackendBuffering + ".memResponseBodyBytes"
 	SuffixBackendBufferingRetryExpression          = SuffixBackendBuffering + ".retryExpression"
+	SuffixBackendProtocol                          = "backend.protocol"
+	SuffixBackendTransport                         = "backend.transport"
+	SuffixBackendTransportDialTimeout              = SuffixBackendTransport + ".dialTimeout"
+	SuffixBackendTransportResponseHeaderTimeout    = SuffixBackendTransport + ".responseHeaderTimeout"
+	SuffixBackendTransportIdleConnTimeout          = SuffixBackendTransport + ".idleConnTimeout"
+	SuffixBackendTransportMaxIdleConnsPerHost      = SuffixBackendTransport + ".maxIdleConnsPerHost"
+	SuffixBackendTransportDisableKeepAlives        = SuffixBackendTransport + ".disableKeepAlives"
+	SuffixBackendTransportDisableHTTP2             = SuffixBackendTransport + ".disableHTTP2"
+	SuffixBackendTransportTLS                      = SuffixBackendTransport + ".tls"
+	SuffixBackendTransportTLSCA                    = SuffixBackendTransportTLS + ".ca"
+	SuffixBackendTransportTLSServerName            = SuffixBackendTransportTLS + ".serverName"
+	SuffixBackendTransportTLSCert                  = SuffixBackendTransportTLS + ".cert"
+	SuffixBackendTransportTLSKey                   = SuffixBackendTransportTLS + ".key"
+	SuffixBackendTransportTLSInsecureSkipVerify    = SuffixBackendTransportTLS + ".insecureSkipVerify"
+	SuffixBackendRetry                             = "backend.retry"
+	SuffixBackendRetryAttempts                     = SuffixBackendRetry + ".attempts"
+	SuffixBackendRetryStatusCodes                  = SuffixBackendRetry + ".statusCodes"
+	SuffixBackendRetryIdempotentOnly               = SuffixBackendRetry + ".idempotentOnly"
+	SuffixBackendRetryPerTryTimeout                = SuffixBackendRetry + ".perTryTimeout"
+	SuffixBackendRetryBackoff                      = SuffixBackendRetry + ".backoff"
+	SuffixBackendRetryMaxBackoff                   = SuffixBackendRetry + ".maxBackoff"
+	SuffixBackendRetryBudgetPercent                = SuffixBackendRetry + ".budgetPercent"
+	SuffixBackendHedging                           = "backend.hedging"
+	SuffixBackendHedgingDelay                      = SuffixBackendHedging + ".delay"
+	SuffixBackendHedgingPercentile                 = SuffixBackendHedging + ".percentile"
 	SuffixFrontend                                 = "frontend"
 	SuffixFrontendAuthBasic                        = "frontend.auth.basic"
 	SuffixFrontendBackend                          = "frontend.backend"
@@ -64,6 +110,10 @@ const (
 	SuffixFrontendRedirectReplacement              = "frontend.redirect.replacement"
 	SuffixFrontendRedirectPermanent                = "frontend.redirect.permanent"
 	SuffixFrontendRule                             = "frontend.rule"
+	SuffixFrontendSplit                            = "frontend.split"
+	SuffixFrontendSplitBackends                    = SuffixFrontendSplit + ".backends"
+	SuffixFrontendSplitStickiness                  = SuffixFrontendSplit + ".stickiness"
+	SuffixFrontendSplitStickinessCookieName        = SuffixFrontendSplit + ".stickiness.cookieName"
 	SuffixFrontendRuleType                         = "frontend.rule.type"
 	SuffixFrontendWhitelistSourceRange             = "frontend.whitelistSourceRange"
 	TraefikDomain                                  = Prefix + SuffixDomain
@@ -77,14 +127,35 @@ const (
 	TraefikBackendID                               = Prefix + SuffixBackendID
 	TraefikBackendCircuitBreaker                   = Prefix + SuffixBackendCircuitBreaker
 	TraefikBackendCircuitBreakerExpression         = Prefix + SuffixBackendCircuitBreakerExpression
+	TraefikBackendHealthCheckType                  = Prefix + SuffixBackendHealthCheckType
 	TraefikBackendHealthCheckPath                  = Prefix + SuffixBackendHealthCheckPath
 	TraefikBackendHealthCheckPort                  = Prefix + SuffixBackendHealthCheckPort
 	TraefikBackendHealthCheckInterval              = Prefix + SuffixBackendHealthCheckInterval
+	TraefikBackendHealthCheckTimeout               = Prefix + SuffixBackendHealthCheckTimeout
+	TraefikBackendHealthCheckScheme                = Prefix + SuffixBackendHealthCheckScheme
+	TraefikBackendHealthCheckMethod                = Prefix + SuffixBackendHealthCheckMethod
+	TraefikBackendHealthCheckHostname              = Prefix + SuffixBackendHealthCheckHostname
+	TraefikBackendHealthCheckHeaders               = Prefix + SuffixBackendHealthCheckHeaders
+	TraefikBackendHealthCheckStatusCodes           = Prefix + SuffixBackendHealthCheckStatusCodes
+	TraefikBackendHealthCheckBody                  = Prefix + SuffixBackendHealthCheckBody
+	TraefikBackendHealthCheckRise                  = Prefix + SuffixBackendHealthCheckRise
+	TraefikBackendHealthCheckFall                  = Prefix + SuffixBackendHealthCheckFall
+	TraefikBackendHealthCheckGRPCService           = Prefix + SuffixBackendHealthCheckGRPCService
 	TraefikBackendLoadBalancer                     = Prefix + SuffixBackendLoadBalancer
 	TraefikBackendLoadBalancerMethod               = Prefix + SuffixBackendLoadBalancerMethod
 	TraefikBackendLoadBalancerSticky               = Prefix + SuffixBackendLoadBalancerSticky
 	TraefikBackendLoadBalancerStickiness           = Prefix + SuffixBackendLoadBalancerStickiness
 	TraefikBackendLoadBalancerStickinessCookieName = Prefix + SuffixBackendLoadBalancerStickinessCookieName
+	TraefikBackendLoadBalancerStickinessSecret     = Prefix + SuffixBackendLoadBalancerStickinessSecret
+	TraefikBackendLoadBalancerStickinessSecure     = Prefix + SuffixBackendLoadBalancerStickinessSecure
+	TraefikBackendLoadBalancerStickinessHTTPOnly   = Prefix + SuffixBackendLoadBalancerStickinessHTTPOnly
+	TraefikBackendLoadBalancerStickinessSameSite   = Prefix + SuffixBackendLoadBalancerStickinessSameSite
+	TraefikBackendLoadBalancerStickinessMaxAge     = Prefix + SuffixBackendLoadBalancerStickinessMaxAge
+	TraefikBackendLoadBalancerStickinessDomain     = Prefix + SuffixBackendLoadBalancerStickinessDomain
+	TraefikBackendLoadBalancerStickinessPath       = Prefix + SuffixBackendLoadBalancerStickinessPath
+	TraefikBackendLoadBalancerHash                 = Prefix + SuffixBackendLoadBalancerHash
+	TraefikBackendLoadBalancerHashKey              = Prefix + SuffixBackendLoadBalancerHashKey
+	TraefikBackendLoadBalancerHashLoadFactor       = Prefix + SuffixBackendLoadBalancerHashLoadFactor
 	TraefikBackendMaxConnAmount                    = Prefix + SuffixBackendMaxConnAmount
 	TraefikBackendMaxConnExtractorFunc             = Prefix + SuffixBackendMaxConnExtractorFunc
 	TraefikBackendBuffering                        = Prefix + SuffixBackendBuffering
@@ -93,6 +164,31 @@ const (
 	TraefikBackendBufferingMaxResponseBodyBytes    = Prefix + SuffixBackendBufferingMaxResponseBodyBytes
 	TraefikBackendBufferingMemResponseBodyBytes    = Prefix + SuffixBackendBufferingMemResponseBodyBytes
 	TraefikBackendBufferingRetryExpression         = Prefix + SuffixBackendBufferingRetryExpression
+	TraefikBackendProtocol                         = Prefix + SuffixBackendProtocol
+	TraefikBackendTransport                        = Prefix + SuffixBackendTransport
+	TraefikBackendTransportDialTimeout             = Prefix + SuffixBackendTransportDialTimeout
+	TraefikBackendTransportResponseHeaderTimeout   = Prefix + SuffixBackendTransportResponseHeaderTimeout
+	TraefikBackendTransportIdleConnTimeout         = Prefix + SuffixBackendTransportIdleConnTimeout
+	TraefikBackendTransportMaxIdleConnsPerHost     = Prefix + SuffixBackendTransportMaxIdleConnsPerHost
+	TraefikBackendTransportDisableKeepAlives       = Prefix + SuffixBackendTransportDisableKeepAlives
+	TraefikBackendTransportDisableHTTP2            = Prefix + SuffixBackendTransportDisableHTTP2
+	TraefikBackendTransportTLS                     = Prefix + SuffixBackendTransportTLS
+	TraefikBackendTransportTLSCA                   = Prefix + SuffixBackendTransportTLSCA
+	TraefikBackendTransportTLSServerName           = Prefix + SuffixBackendTransportTLSServerName
+	TraefikBackendTransportTLSCert                 = Prefix + SuffixBackendTransportTLSCert
+	TraefikBackendTransportTLSKey                  = Prefix + SuffixBackendTransportTLSKey
+	TraefikBackendTransportTLSInsecureSkipVerify   = Prefix + SuffixBackendTransportTLSInsecureSkipVerify
+	TraefikBackendRetry                            = Prefix + SuffixBackendRetry
+	TraefikBackendRetryAttempts                    = Prefix + SuffixBackendRetryAttempts
+	TraefikBackendRetryStatusCodes                 = Prefix + SuffixBackendRetryStatusCodes
+	TraefikBackendRetryIdempotentOnly              = Prefix + SuffixBackendRetryIdempotentOnly
+	TraefikBackendRetryPerTryTimeout               = Prefix + SuffixBackendRetryPerTryTimeout
+	TraefikBackendRetryBackoff                     = Prefix + SuffixBackendRetryBackoff
+	TraefikBackendRetryMaxBackoff                  = Prefix + SuffixBackendRetryMaxBackoff
+	TraefikBackendRetryBudgetPercent               = Prefix + SuffixBackendRetryBudgetPercent
+	TraefikBackendHedging                          = Prefix + SuffixBackendHedging
+	TraefikBackendHedgingDelay                     = Prefix + SuffixBackendHedgingDelay
+	TraefikBackendHedgingPercentile                = Prefix + SuffixBackendHedgingPercentile
 	TraefikFrontend                                = Prefix + SuffixFrontend
 	TraefikFrontendAuthBasic                       = Prefix + SuffixFrontendAuthBasic
 	TraefikFrontendEntryPoints                     = Prefix + SuffixFrontendEntryPoints
@@ -106,6 +202,9 @@ const (
 	TraefikFrontendRedirectPermanent               = Prefix + SuffixFrontendRedirectPermanent
 	TraefikFrontendRule                            = Prefix + SuffixFrontendRule
 	TraefikFrontendRuleType                        = Prefix + SuffixFrontendRuleType // k8s only
+	TraefikFrontendSplitBackends                   = Prefix + SuffixFrontendSplitBackends
+	TraefikFrontendSplitStickiness                 = Prefix + SuffixFrontendSplitStickiness
+	TraefikFrontendSplitStickinessCookieName       = Prefix + SuffixFrontendSplitStickinessCookieName
 	TraefikFrontendWhitelistSourceRange            = Prefix + SuffixFrontendWhitelistSourceRange
 	TraefikFrontendHeaders                         = Prefix + SuffixFrontendHeaders
 	TraefikFrontendRequestHeaders                  = Prefix + SuffixFrontendRequestHeaders
@@ -32,6 +32,9 @@ func (p *Provider) buildConfiguration() *types.Configuration {
 		"getLoadBalancer":   getLoadBalancer,
 		"getMaxConn":        getMaxConn,
 		"getHealthCheck":    getHealthCheck,
+		"getTransport":      getTransport,
+		"getRetry":          getRetry,
+		"getHedging":        getHedging,
 		"getBuffering":      getBuffering,
 		"getServers":        p.getServers,
 
@@ -419,8 +422,23 @@ func getLoadBalancer(application marathon.Application) *types.LoadBalancer {
 	}
 
 	if label.GetBoolValueP(application.Labels, label.TraefikBackendLoadBalancerStickiness, false) {
-		cookieName := label.GetStringValueP(application.Labels, label.TraefikBackendLoadBalancerStickinessCookieName, label.DefaultBackendLoadbalancerStickinessCookieName)
-		lb.Stickiness = &types.Stickiness{CookieName: cookieName}
+		lb.Stickiness = &types.Stickiness{
+			CookieName: label.GetStringValueP(application.Labels, label.TraefikBackendLoadBalancerStickinessCookieName, label.DefaultBackendLoadbalancerStickinessCookieName),
+			Secret:     label.GetStringValueP(application.Labels, label.TraefikBackendLoadBalancerStickinessSecret, ""),
+			Secure:     label.GetBoolValueP(application.Labels, label.TraefikBackendLoadBalancerStickinessSecure, false),
+			HTTPOnly:   label.GetBoolValueP(application.Labels, label.TraefikBackendLoadBalancerStickinessHTTPOnly, false),
+			SameSite:   label.GetStringValueP(application.Labels, label.TraefikBackendLoadBalancerStickinessSameSite, ""),
+			MaxAge:     label.GetIntValueP(application.Labels, label.TraefikBackendLoadBalancerStickinessMaxAge, 0),
+			Domain:     label.GetStringValueP(application.Labels, label.TraefikBackendLoadBalancerStickinessDomain, ""),
+			Path:       label.GetStringValueP(application.Labels, label.TraefikBackendLoadBalancerStickinessPath, ""),
+		}
+	}
+
+	if label.HasPrefixP(application.Labels, label.TraefikBackendLoadBalancerHash) {
+		lb.Hash = &types.ConsistentHash{
+			Key:        label.GetStringValueP(application.Labels, label.TraefikBackendLoadBalancerHashKey, ""),
+			LoadFactor: label.GetFloat64ValueP(application.Labels, label.TraefikBackendLoadBalancerHashLoadFactor, 0),
+		}
 	}
 
 	return lb
@@ -442,7 +460,8 @@ func getMaxConn(application marathon.Application) *types.MaxConn {
 
 func getHealthCheck(application marathon.Application) *types.HealthCheck {
 	path := label.GetStringValueP(application.Labels, label.TraefikBackendHealthCheckPath, "")
-	if len(path) == 0 {
+	hcType := label.GetStringValueP(application.Labels, label.TraefikBackendHealthCheckType, "")
+	if len(path) == 0 && len(hcType) == 0 {
 		return nil
 	}
 
@@ -450,9 +469,20 @@ func getHealthCheck(application marathon.Application) *types.HealthCheck {
 	interval := label.GetStringValueP(application.Labels, label.TraefikBackendHealthCheckInterval, "")
 
 	return &types.HealthCheck{
-		Path:     path,
-		Port:     port,
-		Interval: interval,
+		Type:        hcType,
+		Path:        path,
+		Port:        port,
+		Interval:    interval,
+		Timeout:     label.GetStringValueP(application.Labels, label.TraefikBackendHealthCheckTimeout, ""),
+		Scheme:      label.GetStringValueP(application.Labels, label.TraefikBackendHealthCheckScheme, ""),
+		Method:      label.GetStringValueP(application.Labels, label.TraefikBackendHealthCheckMethod, ""),
+		Hostname:    label.GetStringValueP(application.Labels, label.TraefikBackendHealthCheckHostname, ""),
+		Headers:     label.GetMapValue(getLabels(application, ""), label.TraefikBackendHealthCheckHeaders),
+		StatusCodes: label.GetSliceStringValueP(application.Labels, label.TraefikBackendHealthCheckStatusCodes),
+		Body:        label.GetStringValueP(application.Labels, label.TraefikBackendHealthCheckBody, ""),
+		Rise:        label.GetIntValueP(application.Labels, label.TraefikBackendHealthCheckRise, 0),
+		Fall:        label.GetIntValueP(application.Labels, label.TraefikBackendHealthCheckFall, 0),
+		GRPCService: label.GetStringValueP(application.Labels, label.TraefikBackendHealthCheckGRPCService, ""),
 	}
 }
 
@@ -470,6 +500,60 @@ func getBuffering(application marathon.Application) *types.Buffering {
 	}
 }
 
+func getTransport(application marathon.Application) *types.Transport {
+	if !label.HasPrefixP(application.Labels, label.TraefikBackendTransport) {
+		return nil
+	}
+
+	transport := &types.Transport{
+		DialTimeout:           label.GetStringValueP(application.Labels, label.TraefikBackendTransportDialTimeout, ""),
+		ResponseHeaderTimeout: label.GetStringValueP(application.Labels, label.TraefikBackendTransportResponseHeaderTimeout, ""),
+		IdleConnTimeout:       label.GetStringValueP(application.Labels, label.TraefikBackendTransportIdleConnTimeout, ""),
+		MaxIdleConnsPerHost:   label.GetIntValueP(application.Labels, label.TraefikBackendTransportMaxIdleConnsPerHost, 0),
+		DisableKeepAlives:     label.GetBoolValueP(application.Labels, label.TraefikBackendTransportDisableKeepAlives, false),
+		DisableHTTP2:          label.GetBoolValueP(application.Labels, label.TraefikBackendTransportDisableHTTP2, false),
+	}
+
+	if label.HasPrefixP(application.Labels, label.TraefikBackendTransportTLS) {
+		transport.TLS = &types.TransportTLS{
+			CA:                 label.GetStringValueP(application.Labels, label.TraefikBackendTransportTLSCA, ""),
+			ServerName:         label.GetStringValueP(application.Labels, label.TraefikBackendTransportTLSServerName, ""),
+			Cert:               label.GetStringValueP(application.Labels, label.TraefikBackendTransportTLSCert, ""),
+			Key:                label.GetStringValueP(application.Labels, label.TraefikBackendTransportTLSKey, ""),
+			InsecureSkipVerify: label.GetBoolValueP(application.Labels, label.TraefikBackendTransportTLSInsecureSkipVerify, false),
+		}
+	}
+
+	return transport
+}
+
+func getRetry(application marathon.Application) *types.Retry {
+	if !label.HasPrefixP(application.Labels, label.TraefikBackendRetry) {
+		return nil
+	}
+
+	return &types.Retry{
+		Attempts:       label.GetIntValueP(application.Labels, label.TraefikBackendRetryAttempts, 0),
+		StatusCodes:    label.GetSliceStringValueP(application.Labels, label.TraefikBackendRetryStatusCodes),
+		IdempotentOnly: label.GetBoolValueP(application.Labels, label.TraefikBackendRetryIdempotentOnly, false),
+		PerTryTimeout:  label.GetStringValueP(application.Labels, label.TraefikBackendRetryPerTryTimeout, ""),
+		Backoff:        label.GetStringValueP(application.Labels, label.TraefikBackendRetryBackoff, ""),
+		MaxBackoff:     label.GetStringValueP(application.Labels, label.TraefikBackendRetryMaxBackoff, ""),
+		BudgetPercent:  label.GetIntValueP(application.Labels, label.TraefikBackendRetryBudgetPercent, 0),
+	}
+}
+
+func getHedging(application marathon.Application) *types.Hedging {
+	if !label.HasPrefixP(application.Labels, label.TraefikBackendHedging) {
+		return nil
+	}
+
+	return &types.Hedging{
+		Delay:      label.GetStringValueP(application.Labels, label.TraefikBackendHedgingDelay, ""),
+		Percentile: label.GetIntValueP(application.Labels, label.TraefikBackendHedgingPercentile, 0),
+	}
+}
+
 func (p *Provider) getServers(application marathon.Application, serviceName string) map[string]types.Server {
 	var servers map[string]types.Server
 
@@ -188,6 +188,15 @@ func TestBuildConfigurationNonAPIErrors(t *testing.T) {
 				withLabel(label.TraefikBackendLoadBalancerSticky, "true"),
 				withLabel(label.TraefikBackendLoadBalancerStickiness, "true"),
 				withLabel(label.TraefikBackendLoadBalancerStickinessCookieName, "chocolate"),
+				withLabel(label.TraefikBackendLoadBalancerStickinessSecret, "s3cr3t"),
+				withLabel(label.TraefikBackendLoadBalancerStickinessSecure, "true"),
+				withLabel(label.TraefikBackendLoadBalancerStickinessHTTPOnly, "true"),
+				withLabel(label.TraefikBackendLoadBalancerStickinessSameSite, "lax"),
+				withLabel(label.TraefikBackendLoadBalancerStickinessMaxAge, "3600"),
+				withLabel(label.TraefikBackendLoadBalancerStickinessDomain, "example.com"),
+				withLabel(label.TraefikBackendLoadBalancerStickinessPath, "/app"),
+				withLabel(label.TraefikBackendLoadBalancerHashKey, "request.header.X-User"),
+				withLabel(label.TraefikBackendLoadBalancerHashLoadFactor, "1.5"),
 				withLabel(label.TraefikBackendMaxConnAmount, "666"),
 				withLabel(label.TraefikBackendMaxConnExtractorFunc, "client.ip"),
 				withLabel(label.TraefikBackendBufferingMaxResponseBodyBytes, "10485760"),
@@ -363,6 +372,17 @@ func TestBuildConfigurationNonAPIErrors(t *testing.T) {
 						Sticky: true,
 						Stickiness: &types.Stickiness{
 							CookieName: "chocolate",
+							Secret:     "s3cr3t",
+							Secure:     true,
+							HTTPOnly:   true,
+							SameSite:   "lax",
+							MaxAge:     3600,
+							Domain:     "example.com",
+							Path:       "/app",
+						},
+						Hash: &types.ConsistentHash{
+							Key:        "request.header.X-User",
+							LoadFactor: 1.5,
 						},
 					},
 					MaxConn: &types.MaxConn{
@@ -506,6 +526,15 @@ func TestBuildConfigurationServicesNonAPIErrors(t *testing.T) {
 				withLabel(label.TraefikBackendLoadBalancerSticky, "true"),
 				withLabel(label.TraefikBackendLoadBalancerStickiness, "true"),
 				withLabel(label.TraefikBackendLoadBalancerStickinessCookieName, "chocolate"),
+				withLabel(label.TraefikBackendLoadBalancerStickinessSecret, "s3cr3t"),
+				withLabel(label.TraefikBackendLoadBalancerStickinessSecure, "true"),
+				withLabel(label.TraefikBackendLoadBalancerStickinessHTTPOnly, "true"),
+				withLabel(label.TraefikBackendLoadBalancerStickinessSameSite, "lax"),
+				withLabel(label.TraefikBackendLoadBalancerStickinessMaxAge, "3600"),
+				withLabel(label.TraefikBackendLoadBalancerStickinessDomain, "example.com"),
+				withLabel(label.TraefikBackendLoadBalancerStickinessPath, "/app"),
+				withLabel(label.TraefikBackendLoadBalancerHashKey, "request.header.X-User"),
+				withLabel(label.TraefikBackendLoadBalancerHashLoadFactor, "1.5"),
 				withLabel(label.TraefikBackendMaxConnAmount, "666"),
 				withLabel(label.TraefikBackendMaxConnExtractorFunc, "client.ip"),
 				withLabel(label.TraefikBackendBufferingMaxResponseBodyBytes, "10485760"),
@@ -684,6 +713,17 @@ func TestBuildConfigurationServicesNonAPIErrors(t *testing.T) {
 						Sticky: true,
 						Stickiness: &types.Stickiness{
 							CookieName: "chocolate",
+							Secret:     "s3cr3t",
+							Secure:     true,
+							HTTPOnly:   true,
+							SameSite:   "lax",
+							MaxAge:     3600,
+							Domain:     "example.com",
+							Path:       "/app",
+						},
+						Hash: &types.ConsistentHash{
+							Key:        "request.header.X-User",
+							LoadFactor: 1.5,
 						},
 					},
 					MaxConn: &types.MaxConn{
@@ -1549,6 +1589,56 @@ func TestGetHealthCheck(t *testing.T) {
 				Interval: "6",
 			},
 		},
+		{
+			desc: "should return a struct when only the health check type is set",
+
+			application: application(
+				appPorts(80),
+				withLabel(label.TraefikBackendHealthCheckType, "grpc"),
+				withLabel(label.TraefikBackendHealthCheckGRPCService, "foo.Bar"),
+				withLabel(label.TraefikBackendHealthCheckPort, "80"),
+				withLabel(label.TraefikBackendHealthCheckInterval, "6"),
+			),
+			expected: &types.HealthCheck{
+				Type:        "grpc",
+				GRPCService: "foo.Bar",
+				Port:        80,
+				Interval:    "6",
+			},
+		},
+		{
+			desc: "should return a struct with the request and response settings",
+
+			application: application(
+				appPorts(80),
+				withLabel(label.TraefikBackendHealthCheckPath, "/health"),
+				withLabel(label.TraefikBackendHealthCheckPort, "80"),
+				withLabel(label.TraefikBackendHealthCheckInterval, "6"),
+				withLabel(label.TraefikBackendHealthCheckTimeout, "3s"),
+				withLabel(label.TraefikBackendHealthCheckScheme, "https"),
+				withLabel(label.TraefikBackendHealthCheckMethod, "HEAD"),
+				withLabel(label.TraefikBackendHealthCheckHostname, "foo.localhost"),
+				withLabel(label.TraefikBackendHealthCheckHeaders, "X-Health:1"),
+				withLabel(label.TraefikBackendHealthCheckStatusCodes, "200-299,304"),
+				withLabel(label.TraefikBackendHealthCheckBody, "ok"),
+				withLabel(label.TraefikBackendHealthCheckRise, "3"),
+				withLabel(label.TraefikBackendHealthCheckFall, "2"),
+			),
+			expected: &types.HealthCheck{
+				Path:        "/health",
+				Port:        80,
+				Interval:    "6",
+				Timeout:     "3s",
+				Scheme:      "https",
+				Method:      "HEAD",
+				Hostname:    "foo.localhost",
+				Headers:     map[string]string{"X-Health": "1"},
+				StatusCodes: []string{"200-299", "304"},
+				Body:        "ok",
+				Rise:        3,
+				Fall:        2,
+			},
+		},
 	}
 
 	for _, test := range testCases {
@@ -1606,6 +1696,131 @@ func TestGetBuffering(t *testing.T) {
 	}
 }
 
+func TestGetTransport(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		application marathon.Application
+		expected    *types.Transport
+	}{
+		{
+			desc:        "should return nil when no transport labels",
+			application: application(appPorts(80)),
+			expected:    nil,
+		},
+		{
+			desc: "should return a struct when transport labels are set",
+
+			application: application(
+				withLabel(label.TraefikBackendTransportDialTimeout, "5s"),
+				withLabel(label.TraefikBackendTransportMaxIdleConnsPerHost, "50"),
+				withLabel(label.TraefikBackendTransportDisableHTTP2, "true"),
+				withLabel(label.TraefikBackendTransportTLSCA, "/certs/ca.pem"),
+				withLabel(label.TraefikBackendTransportTLSInsecureSkipVerify, "true"),
+			),
+			expected: &types.Transport{
+				DialTimeout:         "5s",
+				MaxIdleConnsPerHost: 50,
+				DisableHTTP2:        true,
+				TLS: &types.TransportTLS{
+					CA:                 "/certs/ca.pem",
+					InsecureSkipVerify: true,
+				},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			actual := getTransport(test.application)
+
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestGetRetry(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		application marathon.Application
+		expected    *types.Retry
+	}{
+		{
+			desc:        "should return nil when no retry labels",
+			application: application(appPorts(80)),
+			expected:    nil,
+		},
+		{
+			desc: "should return a struct when retry labels are set",
+
+			application: application(
+				withLabel(label.TraefikBackendRetryAttempts, "3"),
+				withLabel(label.TraefikBackendRetryStatusCodes, "502,503-504"),
+				withLabel(label.TraefikBackendRetryIdempotentOnly, "true"),
+				withLabel(label.TraefikBackendRetryPerTryTimeout, "2s"),
+				withLabel(label.TraefikBackendRetryBudgetPercent, "20"),
+			),
+			expected: &types.Retry{
+				Attempts:       3,
+				StatusCodes:    []string{"502", "503-504"},
+				IdempotentOnly: true,
+				PerTryTimeout:  "2s",
+				BudgetPercent:  20,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			actual := getRetry(test.application)
+
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestGetHedging(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		application marathon.Application
+		expected    *types.Hedging
+	}{
+		{
+			desc:        "should return nil when no hedging labels",
+			application: application(appPorts(80)),
+			expected:    nil,
+		},
+		{
+			desc: "should return a struct when hedging labels are set",
+
+			application: application(
+				withLabel(label.TraefikBackendHedgingDelay, "50ms"),
+				withLabel(label.TraefikBackendHedgingPercentile, "95"),
+			),
+			expected: &types.Hedging{
+				Delay:      "50ms",
+				Percentile: 95,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			actual := getHedging(test.application)
+
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
 func TestGetServers(t *testing.T) {
 	testCases := []struct {
 		desc        string
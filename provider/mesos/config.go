@@ -26,6 +26,9 @@ func (p *Provider) buildConfiguration(tasks []state.Task) *types.Configuration {
 		"getLoadBalancer":   getLoadBalancer,
 		"getMaxConn":        getMaxConn,
 		"getHealthCheck":    getHealthCheck,
+		"getTransport":      getTransport,
+		"getRetry":          getRetry,
+		"getHedging":        getHedging,
 		"getBuffering":      getBuffering,
 		"getServers":        p.getServers,
 		"getHost":           p.getHost,
@@ -264,8 +267,23 @@ func getLoadBalancer(task state.Task) *types.LoadBalancer {
 	}
 
 	if getBoolValue(task, label.TraefikBackendLoadBalancerStickiness, false) {
-		cookieName := getStringValue(task, label.TraefikBackendLoadBalancerStickinessCookieName, label.DefaultBackendLoadbalancerStickinessCookieName)
-		lb.Stickiness = &types.Stickiness{CookieName: cookieName}
+		lb.Stickiness = &types.Stickiness{
+			CookieName: getStringValue(task, label.TraefikBackendLoadBalancerStickinessCookieName, label.DefaultBackendLoadbalancerStickinessCookieName),
+			Secret:     getStringValue(task, label.TraefikBackendLoadBalancerStickinessSecret, ""),
+			Secure:     getBoolValue(task, label.TraefikBackendLoadBalancerStickinessSecure, false),
+			HTTPOnly:   getBoolValue(task, label.TraefikBackendLoadBalancerStickinessHTTPOnly, false),
+			SameSite:   getStringValue(task, label.TraefikBackendLoadBalancerStickinessSameSite, ""),
+			MaxAge:     getIntValue(task, label.TraefikBackendLoadBalancerStickinessMaxAge, 0, math.MaxInt32),
+			Domain:     getStringValue(task, label.TraefikBackendLoadBalancerStickinessDomain, ""),
+			Path:       getStringValue(task, label.TraefikBackendLoadBalancerStickinessPath, ""),
+		}
+	}
+
+	if hasPrefix(task, label.TraefikBackendLoadBalancerHash) {
+		lb.Hash = &types.ConsistentHash{
+			Key:        getStringValue(task, label.TraefikBackendLoadBalancerHashKey, ""),
+			LoadFactor: getFloat64Value(task, label.TraefikBackendLoadBalancerHashLoadFactor, 0),
+		}
 	}
 
 	return lb
@@ -287,7 +305,8 @@ func getMaxConn(task state.Task) *types.MaxConn {
 
 func getHealthCheck(task state.Task) *types.HealthCheck {
 	path := getStringValue(task, label.TraefikBackendHealthCheckPath, "")
-	if len(path) == 0 {
+	hcType := getStringValue(task, label.TraefikBackendHealthCheckType, "")
+	if len(path) == 0 && len(hcType) == 0 {
 		return nil
 	}
 
@@ -295,9 +314,20 @@ func getHealthCheck(task state.Task) *types.HealthCheck {
 	interval := getStringValue(task, label.TraefikBackendHealthCheckInterval, "")
 
 	return &types.HealthCheck{
-		Path:     path,
-		Port:     port,
-		Interval: interval,
+		Type:        hcType,
+		Path:        path,
+		Port:        port,
+		Interval:    interval,
+		Timeout:     getStringValue(task, label.TraefikBackendHealthCheckTimeout, ""),
+		Scheme:      getStringValue(task, label.TraefikBackendHealthCheckScheme, ""),
+		Method:      getStringValue(task, label.TraefikBackendHealthCheckMethod, ""),
+		Hostname:    getStringValue(task, label.TraefikBackendHealthCheckHostname, ""),
+		Headers:     label.GetMapValue(taskLabelsToMap(task), label.TraefikBackendHealthCheckHeaders),
+		StatusCodes: getSliceStringValue(task, label.TraefikBackendHealthCheckStatusCodes),
+		Body:        getStringValue(task, label.TraefikBackendHealthCheckBody, ""),
+		Rise:        getIntValue(task, label.TraefikBackendHealthCheckRise, 0, math.MaxInt32),
+		Fall:        getIntValue(task, label.TraefikBackendHealthCheckFall, 0, math.MaxInt32),
+		GRPCService: getStringValue(task, label.TraefikBackendHealthCheckGRPCService, ""),
 	}
 }
 
@@ -315,6 +345,60 @@ func getBuffering(task state.Task) *types.Buffering {
 	}
 }
 
+func getTransport(task state.Task) *types.Transport {
+	if !hasPrefix(task, label.TraefikBackendTransport) {
+		return nil
+	}
+
+	transport := &types.Transport{
+		DialTimeout:           getStringValue(task, label.TraefikBackendTransportDialTimeout, ""),
+		ResponseHeaderTimeout: getStringValue(task, label.TraefikBackendTransportResponseHeaderTimeout, ""),
+		IdleConnTimeout:       getStringValue(task, label.TraefikBackendTransportIdleConnTimeout, ""),
+		MaxIdleConnsPerHost:   getIntValue(task, label.TraefikBackendTransportMaxIdleConnsPerHost, 0, math.MaxInt32),
+		DisableKeepAlives:     getBoolValue(task, label.TraefikBackendTransportDisableKeepAlives, false),
+		DisableHTTP2:          getBoolValue(task, label.TraefikBackendTransportDisableHTTP2, false),
+	}
+
+	if hasPrefix(task, label.TraefikBackendTransportTLS) {
+		transport.TLS = &types.TransportTLS{
+			CA:                 getStringValue(task, label.TraefikBackendTransportTLSCA, ""),
+			ServerName:         getStringValue(task, label.TraefikBackendTransportTLSServerName, ""),
+			Cert:               getStringValue(task, label.TraefikBackendTransportTLSCert, ""),
+			Key:                getStringValue(task, label.TraefikBackendTransportTLSKey, ""),
+			InsecureSkipVerify: getBoolValue(task, label.TraefikBackendTransportTLSInsecureSkipVerify, false),
+		}
+	}
+
+	return transport
+}
+
+func getRetry(task state.Task) *types.Retry {
+	if !hasPrefix(task, label.TraefikBackendRetry) {
+		return nil
+	}
+
+	return &types.Retry{
+		Attempts:       getIntValue(task, label.TraefikBackendRetryAttempts, 0, math.MaxInt32),
+		StatusCodes:    getSliceStringValue(task, label.TraefikBackendRetryStatusCodes),
+		IdempotentOnly: getBoolValue(task, label.TraefikBackendRetryIdempotentOnly, false),
+		PerTryTimeout:  getStringValue(task, label.TraefikBackendRetryPerTryTimeout, ""),
+		Backoff:        getStringValue(task, label.TraefikBackendRetryBackoff, ""),
+		MaxBackoff:     getStringValue(task, label.TraefikBackendRetryMaxBackoff, ""),
+		BudgetPercent:  getIntValue(task, label.TraefikBackendRetryBudgetPercent, 0, math.MaxInt32),
+	}
+}
+
+func getHedging(task state.Task) *types.Hedging {
+	if !hasPrefix(task, label.TraefikBackendHedging) {
+		return nil
+	}
+
+	return &types.Hedging{
+		Delay:      getStringValue(task, label.TraefikBackendHedgingDelay, ""),
+		Percentile: getIntValue(task, label.TraefikBackendHedgingPercentile, 0, math.MaxInt32),
+	}
+}
+
 func (p *Provider) getServers(tasks []state.Task) map[string]types.Server {
 	var servers map[string]types.Server
 
@@ -530,6 +614,20 @@ func getInt64Value(task state.Task, labelName string, defaultValue int64) int64
 	return defaultValue
 }
 
+func getFloat64Value(task state.Task, labelName string, defaultValue float64) float64 {
+	for _, lbl := range task.Labels {
+		if lbl.Key == labelName {
+			value, err := strconv.ParseFloat(lbl.Value, 64)
+			if err != nil {
+				log.Warnf("Unable to parse %q: %q, falling back to %v. %v", labelName, lbl.Value, defaultValue, err)
+				return defaultValue
+			}
+			return value
+		}
+	}
+	return defaultValue
+}
+
 func hasLabel(task state.Task, label string) bool {
 	for _, lbl := range task.Labels {
 		if lbl.Key == label {
@@ -24,6 +24,9 @@ func (p *Provider) buildConfiguration(services []rancherData) *types.Configurati
 		"getLoadBalancer":   getLoadBalancer,
 		"getMaxConn":        getMaxConn,
 		"getHealthCheck":    getHealthCheck,
+		"getTransport":      getTransport,
+		"getRetry":          getRetry,
+		"getHedging":        getHedging,
 		"getBuffering":      getBuffering,
 		"getServers":        getServers,
 
@@ -199,8 +202,23 @@ func getLoadBalancer(service rancherData) *types.LoadBalancer {
 	}
 
 	if label.GetBoolValue(service.Labels, label.TraefikBackendLoadBalancerStickiness, false) {
-		cookieName := label.GetStringValue(service.Labels, label.TraefikBackendLoadBalancerStickinessCookieName, label.DefaultBackendLoadbalancerStickinessCookieName)
-		lb.Stickiness = &types.Stickiness{CookieName: cookieName}
+		lb.Stickiness = &types.Stickiness{
+			CookieName: label.GetStringValue(service.Labels, label.TraefikBackendLoadBalancerStickinessCookieName, label.DefaultBackendLoadbalancerStickinessCookieName),
+			Secret:     label.GetStringValue(service.Labels, label.TraefikBackendLoadBalancerStickinessSecret, ""),
+			Secure:     label.GetBoolValue(service.Labels, label.TraefikBackendLoadBalancerStickinessSecure, false),
+			HTTPOnly:   label.GetBoolValue(service.Labels, label.TraefikBackendLoadBalancerStickinessHTTPOnly, false),
+			SameSite:   label.GetStringValue(service.Labels, label.TraefikBackendLoadBalancerStickinessSameSite, ""),
+			MaxAge:     label.GetIntValue(service.Labels, label.TraefikBackendLoadBalancerStickinessMaxAge, 0),
+			Domain:     label.GetStringValue(service.Labels, label.TraefikBackendLoadBalancerStickinessDomain, ""),
+			Path:       label.GetStringValue(service.Labels, label.TraefikBackendLoadBalancerStickinessPath, ""),
+		}
+	}
+
+	if label.HasPrefix(service.Labels, label.TraefikBackendLoadBalancerHash) {
+		lb.Hash = &types.ConsistentHash{
+			Key:        label.GetStringValue(service.Labels, label.TraefikBackendLoadBalancerHashKey, ""),
+			LoadFactor: label.GetFloat64Value(service.Labels, label.TraefikBackendLoadBalancerHashLoadFactor, 0),
+		}
 	}
 
 	return lb
@@ -222,7 +240,8 @@ func getMaxConn(service rancherData) *types.MaxConn {
 
 func getHealthCheck(service rancherData) *types.HealthCheck {
 	path := label.GetStringValue(service.Labels, label.TraefikBackendHealthCheckPath, "")
-	if len(path) == 0 {
+	hcType := label.GetStringValue(service.Labels, label.TraefikBackendHealthCheckType, "")
+	if len(path) == 0 && len(hcType) == 0 {
 		return nil
 	}
 
@@ -230,9 +249,20 @@ func getHealthCheck(service rancherData) *types.HealthCheck {
 	interval := label.GetStringValue(service.Labels, label.TraefikBackendHealthCheckInterval, "")
 
 	return &types.HealthCheck{
-		Path:     path,
-		Port:     port,
-		Interval: interval,
+		Type:        hcType,
+		Path:        path,
+		Port:        port,
+		Interval:    interval,
+		Timeout:     label.GetStringValue(service.Labels, label.TraefikBackendHealthCheckTimeout, ""),
+		Scheme:      label.GetStringValue(service.Labels, label.TraefikBackendHealthCheckScheme, ""),
+		Method:      label.GetStringValue(service.Labels, label.TraefikBackendHealthCheckMethod, ""),
+		Hostname:    label.GetStringValue(service.Labels, label.TraefikBackendHealthCheckHostname, ""),
+		Headers:     label.GetMapValue(service.Labels, label.TraefikBackendHealthCheckHeaders),
+		StatusCodes: label.GetSliceStringValue(service.Labels, label.TraefikBackendHealthCheckStatusCodes),
+		Body:        label.GetStringValue(service.Labels, label.TraefikBackendHealthCheckBody, ""),
+		Rise:        label.GetIntValue(service.Labels, label.TraefikBackendHealthCheckRise, 0),
+		Fall:        label.GetIntValue(service.Labels, label.TraefikBackendHealthCheckFall, 0),
+		GRPCService: label.GetStringValue(service.Labels, label.TraefikBackendHealthCheckGRPCService, ""),
 	}
 }
 
@@ -250,6 +280,60 @@ func getBuffering(service rancherData) *types.Buffering {
 	}
 }
 
+func getTransport(service rancherData) *types.Transport {
+	if !label.HasPrefix(service.Labels, label.TraefikBackendTransport) {
+		return nil
+	}
+
+	transport := &types.Transport{
+		DialTimeout:           label.GetStringValue(service.Labels, label.TraefikBackendTransportDialTimeout, ""),
+		ResponseHeaderTimeout: label.GetStringValue(service.Labels, label.TraefikBackendTransportResponseHeaderTimeout, ""),
+		IdleConnTimeout:       label.GetStringValue(service.Labels, label.TraefikBackendTransportIdleConnTimeout, ""),
+		MaxIdleConnsPerHost:   label.GetIntValue(service.Labels, label.TraefikBackendTransportMaxIdleConnsPerHost, 0),
+		DisableKeepAlives:     label.GetBoolValue(service.Labels, label.TraefikBackendTransportDisableKeepAlives, false),
+		DisableHTTP2:          label.GetBoolValue(service.Labels, label.TraefikBackendTransportDisableHTTP2, false),
+	}
+
+	if label.HasPrefix(service.Labels, label.TraefikBackendTransportTLS) {
+		transport.TLS = &types.TransportTLS{
+			CA:                 label.GetStringValue(service.Labels, label.TraefikBackendTransportTLSCA, ""),
+			ServerName:         label.GetStringValue(service.Labels, label.TraefikBackendTransportTLSServerName, ""),
+			Cert:               label.GetStringValue(service.Labels, label.TraefikBackendTransportTLSCert, ""),
+			Key:                label.GetStringValue(service.Labels, label.TraefikBackendTransportTLSKey, ""),
+			InsecureSkipVerify: label.GetBoolValue(service.Labels, label.TraefikBackendTransportTLSInsecureSkipVerify, false),
+		}
+	}
+
+	return transport
+}
+
+func getRetry(service rancherData) *types.Retry {
+	if !label.HasPrefix(service.Labels, label.TraefikBackendRetry) {
+		return nil
+	}
+
+	return &types.Retry{
+		Attempts:       label.GetIntValue(service.Labels, label.TraefikBackendRetryAttempts, 0),
+		StatusCodes:    label.GetSliceStringValue(service.Labels, label.TraefikBackendRetryStatusCodes),
+		IdempotentOnly: label.GetBoolValue(service.Labels, label.TraefikBackendRetryIdempotentOnly, false),
+		PerTryTimeout:  label.GetStringValue(service.Labels, label.TraefikBackendRetryPerTryTimeout, ""),
+		Backoff:        label.GetStringValue(service.Labels, label.TraefikBackendRetryBackoff, ""),
+		MaxBackoff:     label.GetStringValue(service.Labels, label.TraefikBackendRetryMaxBackoff, ""),
+		BudgetPercent:  label.GetIntValue(service.Labels, label.TraefikBackendRetryBudgetPercent, 0),
+	}
+}
+
+func getHedging(service rancherData) *types.Hedging {
+	if !label.HasPrefix(service.Labels, label.TraefikBackendHedging) {
+		return nil
+	}
+
+	return &types.Hedging{
+		Delay:      label.GetStringValue(service.Labels, label.TraefikBackendHedgingDelay, ""),
+		Percentile: label.GetIntValue(service.Labels, label.TraefikBackendHedgingPercentile, 0),
+	}
+}
+
 func getServers(service rancherData) map[string]types.Server {
 	var servers map[string]types.Server
 
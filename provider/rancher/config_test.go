@@ -48,6 +48,15 @@ func TestProviderBuildConfiguration(t *testing.T) {
 						label.TraefikBackendLoadBalancerSticky:               "true",
 						label.TraefikBackendLoadBalancerStickiness:           "true",
 						label.TraefikBackendLoadBalancerStickinessCookieName: "chocolate",
+						label.TraefikBackendLoadBalancerStickinessSecret:     "s3cr3t",
+						label.TraefikBackendLoadBalancerStickinessSecure:     "true",
+						label.TraefikBackendLoadBalancerStickinessHTTPOnly:   "true",
+						label.TraefikBackendLoadBalancerStickinessSameSite:   "lax",
+						label.TraefikBackendLoadBalancerStickinessMaxAge:     "3600",
+						label.TraefikBackendLoadBalancerStickinessDomain:     "example.com",
+						label.TraefikBackendLoadBalancerStickinessPath:       "/app",
+						label.TraefikBackendLoadBalancerHashKey:              "request.header.X-User",
+						label.TraefikBackendLoadBalancerHashLoadFactor:       "1.5",
 						label.TraefikBackendMaxConnAmount:                    "666",
 						label.TraefikBackendMaxConnExtractorFunc:             "client.ip",
 						label.TraefikBackendBufferingMaxResponseBodyBytes:    "10485760",
@@ -224,6 +233,17 @@ func TestProviderBuildConfiguration(t *testing.T) {
 						Sticky: true,
 						Stickiness: &types.Stickiness{
 							CookieName: "chocolate",
+							Secret:     "s3cr3t",
+							Secure:     true,
+							HTTPOnly:   true,
+							SameSite:   "lax",
+							MaxAge:     3600,
+							Domain:     "example.com",
+							Path:       "/app",
+						},
+						Hash: &types.ConsistentHash{
+							Key:        "request.header.X-User",
+							LoadFactor: 1.5,
 						},
 					},
 					MaxConn: &types.MaxConn{
@@ -856,6 +876,60 @@ func TestGetHealthCheck(t *testing.T) {
 				Interval: "6",
 			},
 		},
+		{
+			desc: "should return a struct when only the health check type is set",
+			service: rancherData{
+				Labels: map[string]string{
+					label.TraefikBackendHealthCheckType:        "grpc",
+					label.TraefikBackendHealthCheckGRPCService: "foo.Bar",
+					label.TraefikBackendHealthCheckPort:        "80",
+					label.TraefikBackendHealthCheckInterval:    "6",
+				},
+				Health: "healthy",
+				State:  "active",
+			},
+			expected: &types.HealthCheck{
+				Type:        "grpc",
+				GRPCService: "foo.Bar",
+				Port:        80,
+				Interval:    "6",
+			},
+		},
+		{
+			desc: "should return a struct with the request and response settings",
+			service: rancherData{
+				Labels: map[string]string{
+					label.TraefikBackendHealthCheckPath:        "/health",
+					label.TraefikBackendHealthCheckPort:        "80",
+					label.TraefikBackendHealthCheckInterval:    "6",
+					label.TraefikBackendHealthCheckTimeout:     "3s",
+					label.TraefikBackendHealthCheckScheme:      "https",
+					label.TraefikBackendHealthCheckMethod:      "HEAD",
+					label.TraefikBackendHealthCheckHostname:    "foo.localhost",
+					label.TraefikBackendHealthCheckHeaders:     "X-Health:1",
+					label.TraefikBackendHealthCheckStatusCodes: "200-299,304",
+					label.TraefikBackendHealthCheckBody:        "ok",
+					label.TraefikBackendHealthCheckRise:        "3",
+					label.TraefikBackendHealthCheckFall:        "2",
+				},
+				Health: "healthy",
+				State:  "active",
+			},
+			expected: &types.HealthCheck{
+				Path:        "/health",
+				Port:        80,
+				Interval:    "6",
+				Timeout:     "3s",
+				Scheme:      "https",
+				Method:      "HEAD",
+				Hostname:    "foo.localhost",
+				Headers:     map[string]string{"X-Health": "1"},
+				StatusCodes: []string{"200-299", "304"},
+				Body:        "ok",
+				Rise:        3,
+				Fall:        2,
+			},
+		},
 	}
 
 	for _, test := range testCases {
@@ -920,6 +994,152 @@ func TestGetBuffering(t *testing.T) {
 	}
 }
 
+func TestGetTransport(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		service  rancherData
+		expected *types.Transport
+	}{
+		{
+			desc: "should return nil when no transport labels",
+			service: rancherData{
+				Labels: map[string]string{},
+				Health: "healthy",
+				State:  "active",
+			},
+			expected: nil,
+		},
+		{
+			desc: "should return a struct when transport labels are set",
+			service: rancherData{
+				Labels: map[string]string{
+					label.TraefikBackendTransportDialTimeout:           "5s",
+					label.TraefikBackendTransportMaxIdleConnsPerHost:   "50",
+					label.TraefikBackendTransportDisableHTTP2:          "true",
+					label.TraefikBackendTransportTLSCA:                 "/certs/ca.pem",
+					label.TraefikBackendTransportTLSInsecureSkipVerify: "true",
+				},
+				Health: "healthy",
+				State:  "active",
+			},
+			expected: &types.Transport{
+				DialTimeout:         "5s",
+				MaxIdleConnsPerHost: 50,
+				DisableHTTP2:        true,
+				TLS: &types.TransportTLS{
+					CA:                 "/certs/ca.pem",
+					InsecureSkipVerify: true,
+				},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			actual := getTransport(test.service)
+
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestGetRetry(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		service  rancherData
+		expected *types.Retry
+	}{
+		{
+			desc: "should return nil when no retry labels",
+			service: rancherData{
+				Labels: map[string]string{},
+				Health: "healthy",
+				State:  "active",
+			},
+			expected: nil,
+		},
+		{
+			desc: "should return a struct when retry labels are set",
+			service: rancherData{
+				Labels: map[string]string{
+					label.TraefikBackendRetryAttempts:       "3",
+					label.TraefikBackendRetryStatusCodes:    "502,503-504",
+					label.TraefikBackendRetryIdempotentOnly: "true",
+					label.TraefikBackendRetryPerTryTimeout:  "2s",
+					label.TraefikBackendRetryBudgetPercent:  "20",
+				},
+				Health: "healthy",
+				State:  "active",
+			},
+			expected: &types.Retry{
+				Attempts:       3,
+				StatusCodes:    []string{"502", "503-504"},
+				IdempotentOnly: true,
+				PerTryTimeout:  "2s",
+				BudgetPercent:  20,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			actual := getRetry(test.service)
+
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestGetHedging(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		service  rancherData
+		expected *types.Hedging
+	}{
+		{
+			desc: "should return nil when no hedging labels",
+			service: rancherData{
+				Labels: map[string]string{},
+				Health: "healthy",
+				State:  "active",
+			},
+			expected: nil,
+		},
+		{
+			desc: "should return a struct when hedging labels are set",
+			service: rancherData{
+				Labels: map[string]string{
+					label.TraefikBackendHedgingDelay:      "50ms",
+					label.TraefikBackendHedgingPercentile: "95",
+				},
+				Health: "healthy",
+				State:  "active",
+			},
+			expected: &types.Hedging{
+				Delay:      "50ms",
+				Percentile: 95,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			actual := getHedging(test.service)
+
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
 func TestGetServers(t *testing.T) {
 	testCases := []struct {
 		desc     string
@@ -0,0 +1,268 @@
+package server
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/containous/traefik/api"
+	"github.com/containous/traefik/types"
+)
+
+// Kinds of conflicts between frontends.
+const (
+	conflictIdentical = "identical"
+	conflictOverlap   = "overlap"
+	conflictShadowed  = "shadowed"
+)
+
+// ruleSummary is a static summary of the rules of a frontend, used to detect conflicts.
+// Frontends using regular expressions, ||, ! or parentheses are not summarized.
+type ruleSummary struct {
+	frontend string
+	priority int
+	hosts    []string
+	paths    []string
+	prefixes []string
+	others   []string
+}
+
+func newRuleSummary(frontendName string, routing *frontendRouting) (*ruleSummary, bool) {
+	summary := &ruleSummary{frontend: frontendName, priority: routing.priority}
+	seen := make(map[string]bool)
+	rules := &Rules{}
+
+	for _, rule := range routing.rules {
+		tree, err := parseRuleExpression(rule)
+		if err != nil {
+			return nil, false
+		}
+
+		for _, node := range tree.conjuncts() {
+			if node.operator != ruleLeaf {
+				return nil, false
+			}
+
+			functionName, _, arguments, err := rules.parseRule(node.rule)
+			if err != nil {
+				return nil, false
+			}
+
+			switch functionName {
+			case "AddPrefix", "ReplacePath", "ReplacePathRegex":
+				continue
+			case "HostRegexp", "PathStripRegex", "PathPrefixStripRegex":
+				return nil, false
+			case "Host":
+				if seen["Host"] {
+					return nil, false
+				}
+				seen["Host"] = true
+				for _, host := range arguments {
+					summary.hosts = append(summary.hosts, types.CanonicalDomain(host))
+				}
+			case "Path", "PathStrip", "PathPrefix", "PathPrefixStrip":
+				if seen["Path"] || hasRegexp(arguments) {
+					return nil, false
+				}
+				seen["Path"] = true
+				if strings.HasPrefix(functionName, "PathPrefix") {
+					summary.prefixes = arguments
+				} else {
+					summary.paths = arguments
+				}
+			default:
+				summary.others = append(summary.others, functionName+":"+strings.Join(arguments, ","))
+			}
+		}
+	}
+
+	sort.Strings(summary.hosts)
+	sort.Strings(summary.paths)
+	sort.Strings(summary.prefixes)
+	sort.Strings(summary.others)
+	return summary, true
+}
+
+func hasRegexp(values []string) bool {
+	for _, value := range values {
+		if strings.Contains(value, "{") {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *ruleSummary) equals(other *ruleSummary) bool {
+	return equalStrings(s.hosts, other.hosts) &&
+		equalStrings(s.paths, other.paths) &&
+		equalStrings(s.prefixes, other.prefixes) &&
+		equalStrings(s.others, other.others)
+}
+
+// covers tells whether every request matched by other is also matched by s.
+func (s *ruleSummary) covers(other *ruleSummary) bool {
+	if !containsStrings(other.others, s.others) {
+		return false
+	}
+	if len(s.hosts) > 0 && (len(other.hosts) == 0 || !containsStrings(s.hosts, other.hosts)) {
+		return false
+	}
+
+	switch {
+	case len(s.prefixes) > 0:
+		if len(other.prefixes) == 0 && len(other.paths) == 0 {
+			return false
+		}
+		for _, path := range append(other.paths, other.prefixes...) {
+			if !hasAnyPrefix(path, s.prefixes) {
+				return false
+			}
+		}
+		return true
+	case len(s.paths) > 0:
+		return len(other.prefixes) == 0 && len(other.paths) > 0 && containsStrings(s.paths, other.paths)
+	default:
+		return true
+	}
+}
+
+// overlaps tells whether a request can be matched by both s and other.
+func (s *ruleSummary) overlaps(other *ruleSummary) bool {
+	if !equalStrings(s.others, other.others) {
+		return false
+	}
+	if len(s.hosts) > 0 && len(other.hosts) > 0 && !intersectStrings(s.hosts, other.hosts) {
+		return false
+	}
+	return pathsOverlap(s, other) || pathsOverlap(other, s)
+}
+
+func pathsOverlap(s, other *ruleSummary) bool {
+	if len(s.paths) == 0 && len(s.prefixes) == 0 {
+		return true
+	}
+	if intersectStrings(s.paths, other.paths) {
+		return true
+	}
+	for _, path := range append(other.paths, other.prefixes...) {
+		if hasAnyPrefix(path, s.prefixes) {
+			return true
+		}
+	}
+	return false
+}
+
+// detectConflicts finds frontends of an entry point whose rules can match the same requests,
+// either at the same priority, in which case the frontend handling them is undefined,
+// or because a frontend with a higher priority matches every request of another one.
+func detectConflicts(entryPointName string, table *routingTable) []api.FrontendConflict {
+	var summaries []*ruleSummary
+	for frontendName, routing := range table.frontends {
+		if summary, ok := newRuleSummary(frontendName, routing); ok {
+			summaries = append(summaries, summary)
+		}
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].priority != summaries[j].priority {
+			return summaries[i].priority > summaries[j].priority
+		}
+		return summaries[i].frontend < summaries[j].frontend
+	})
+
+	var conflicts []api.FrontendConflict
+	for i, first := range summaries {
+		for _, second := range summaries[i+1:] {
+			conflict := api.FrontendConflict{
+				EntryPoint: entryPointName,
+				Frontends:  []string{first.frontend, second.frontend},
+				Priorities: []int{first.priority, second.priority},
+			}
+
+			switch {
+			case first.priority == second.priority && first.equals(second):
+				conflict.Kind = conflictIdentical
+				conflict.Description = fmt.Sprintf("frontends %s and %s have identical rules and priority %d, the frontend handling their requests is undefined",
+					first.frontend, second.frontend, first.priority)
+			case first.priority == second.priority && first.overlaps(second):
+				conflict.Kind = conflictOverlap
+				conflict.Description = fmt.Sprintf("frontends %s and %s can match the same requests with priority %d, the frontend handling them is undefined",
+					first.frontend, second.frontend, first.priority)
+			case first.priority > second.priority && first.covers(second):
+				conflict.Kind = conflictShadowed
+				conflict.Description = fmt.Sprintf("frontend %s is shadowed by frontend %s (priority %d > %d) and never receives requests",
+					second.frontend, first.frontend, first.priority, second.priority)
+			default:
+				continue
+			}
+			conflicts = append(conflicts, conflict)
+		}
+	}
+	return conflicts
+}
+
+// collectConflicts returns the conflicts detected on every entry point, sorted by entry point.
+func (s *Server) collectConflicts() []api.FrontendConflict {
+	var entryPointNames []string
+	for entryPointName := range s.serverEntryPoints {
+		entryPointNames = append(entryPointNames, entryPointName)
+	}
+	sort.Strings(entryPointNames)
+
+	conflicts := []api.FrontendConflict{}
+	for _, entryPointName := range entryPointNames {
+		if table, ok := s.serverEntryPoints[entryPointName].routingTable.Get().(*routingTable); ok && table != nil {
+			conflicts = append(conflicts, table.conflicts...)
+		}
+	}
+	return conflicts
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// containsStrings tells whether all values of b are in a.
+func containsStrings(a, b []string) bool {
+	for _, value := range b {
+		if !containsString(a, value) {
+			return false
+		}
+	}
+	return true
+}
+
+func intersectStrings(a, b []string) bool {
+	for _, value := range b {
+		if containsString(a, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAnyPrefix(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
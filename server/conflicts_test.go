@@ -0,0 +1,169 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/containous/traefik/api"
+	"github.com/containous/traefik/configuration"
+	"github.com/containous/traefik/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectConflicts(t *testing.T) {
+	type frontend struct {
+		priority int
+		rule     string
+	}
+
+	testCases := []struct {
+		desc      string
+		frontends map[string]frontend
+		expected  []api.FrontendConflict
+	}{
+		{
+			desc: "distinct hosts",
+			frontends: map[string]frontend{
+				"a": {priority: 10, rule: "Host:a.com"},
+				"b": {priority: 10, rule: "Host:b.com"},
+			},
+		},
+		{
+			desc: "identical rules at the same priority",
+			frontends: map[string]frontend{
+				"a": {priority: 20, rule: "Host:foo.com,bar.com;Path:/api"},
+				"b": {priority: 20, rule: "Path:/api;Host:Bar.com,foo.com"},
+			},
+			expected: []api.FrontendConflict{
+				{EntryPoint: "http", Kind: conflictIdentical, Frontends: []string{"a", "b"}, Priorities: []int{20, 20}},
+			},
+		},
+		{
+			desc: "overlapping prefixes at the same priority",
+			frontends: map[string]frontend{
+				"a": {priority: 15, rule: "Host:foo.com;PathPrefix:/api"},
+				"b": {priority: 15, rule: "PathPrefixStrip:/api/v2"},
+			},
+			expected: []api.FrontendConflict{
+				{EntryPoint: "http", Kind: conflictOverlap, Frontends: []string{"a", "b"}, Priorities: []int{15, 15}},
+			},
+		},
+		{
+			desc: "disjoint paths at the same priority",
+			frontends: map[string]frontend{
+				"a": {priority: 15, rule: "Host:foo.com;Path:/a"},
+				"b": {priority: 15, rule: "Host:foo.com;PathPrefix:/b"},
+			},
+		},
+		{
+			desc: "shadowed by a shorter prefix with a higher priority",
+			frontends: map[string]frontend{
+				"a": {priority: 100, rule: "Host:foo.com;PathPrefix:/api"},
+				"b": {priority: 30, rule: "Host:foo.com;PathPrefix:/api/v2;Method:GET"},
+			},
+			expected: []api.FrontendConflict{
+				{EntryPoint: "http", Kind: conflictShadowed, Frontends: []string{"a", "b"}, Priorities: []int{100, 30}},
+			},
+		},
+		{
+			desc: "longer prefix evaluated first",
+			frontends: map[string]frontend{
+				"a": {priority: 30, rule: "Host:foo.com;PathPrefix:/api"},
+				"b": {priority: 40, rule: "Host:foo.com;PathPrefix:/api/v2"},
+			},
+		},
+		{
+			desc: "higher priority restricted to some hosts",
+			frontends: map[string]frontend{
+				"a": {priority: 100, rule: "Host:foo.com;PathPrefix:/"},
+				"b": {priority: 30, rule: "PathPrefix:/api"},
+			},
+		},
+		{
+			desc: "different constraints",
+			frontends: map[string]frontend{
+				"a": {priority: 20, rule: "Host:foo.com;Method:GET"},
+				"b": {priority: 20, rule: "Host:foo.com;Method:POST"},
+			},
+		},
+		{
+			desc: "boolean expressions are ignored",
+			frontends: map[string]frontend{
+				"a": {priority: 20, rule: "Host:foo.com || Host:bar.com"},
+				"b": {priority: 20, rule: "Host:foo.com || Host:bar.com"},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			table := &routingTable{frontends: make(map[string]*frontendRouting)}
+			for name, f := range test.frontends {
+				table.frontends[name] = &frontendRouting{
+					priority: f.priority,
+					rules:    map[string]string{"route": f.rule},
+				}
+			}
+
+			conflicts := detectConflicts("http", table)
+			require.Len(t, conflicts, len(test.expected))
+			for i, conflict := range conflicts {
+				assert.NotEmpty(t, conflict.Description)
+				conflict.Description = ""
+				assert.Equal(t, test.expected[i], conflict)
+			}
+		})
+	}
+}
+
+func TestServerFrontendConflicts(t *testing.T) {
+	globalConfig := configuration.GlobalConfiguration{
+		EntryPoints: configuration.EntryPoints{
+			"http": &configuration.EntryPoint{ForwardedHeaders: &configuration.ForwardedHeaders{Insecure: true}},
+		},
+	}
+
+	dynamicConfigs := types.Configurations{
+		"config": &types.Configuration{
+			Frontends: map[string]*types.Frontend{
+				"frontend-1": {
+					EntryPoints: []string{"http"},
+					Backend:     "backend",
+					Routes: map[string]types.Route{
+						"route": {Rule: "Host:foo.bar"},
+					},
+				},
+				"frontend-2": {
+					EntryPoints: []string{"http"},
+					Backend:     "backend",
+					Routes: map[string]types.Route{
+						"route": {Rule: "Host:foo.bar"},
+					},
+				},
+			},
+			Backends: map[string]*types.Backend{
+				"backend": {
+					Servers: map[string]types.Server{
+						"server": {URL: "http://localhost"},
+					},
+					LoadBalancer: &types.LoadBalancer{Method: "wrr"},
+				},
+			},
+		},
+	}
+
+	srv := NewServer(globalConfig, nil)
+	entryPoints, err := srv.loadConfig(dynamicConfigs, globalConfig)
+	require.NoError(t, err)
+	srv.serverEntryPoints = entryPoints
+
+	conflicts := srv.collectConflicts()
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, "http", conflicts[0].EntryPoint)
+	assert.Equal(t, conflictIdentical, conflicts[0].Kind)
+	assert.Equal(t, []string{"frontend-1", "frontend-2"}, conflicts[0].Frontends)
+	assert.Equal(t, []int{12, 12}, conflicts[0].Priorities)
+}
@@ -0,0 +1,78 @@
+package cookie
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"net/http"
+	"strings"
+)
+
+var sameSiteValues = map[string]string{
+	"lax":    "Lax",
+	"strict": "Strict",
+	"none":   "None",
+}
+
+// Options holds the attributes of a sticky session cookie,
+// and the secret its values are signed with.
+type Options struct {
+	Secret   string
+	Secure   bool
+	HTTPOnly bool
+	SameSite string
+	MaxAge   int
+	Domain   string
+	Path     string
+}
+
+// ValidSameSite returns whether sameSite is empty, or a valid SameSite attribute (lax, strict or none).
+func ValidSameSite(sameSite string) bool {
+	_, ok := sameSiteValues[strings.ToLower(sameSite)]
+	return len(sameSite) == 0 || ok
+}
+
+// Value returns the opaque value of a sticky session cookie for target (e.g. the URL of a server),
+// which doesn't leak it: the HMAC-SHA256 of target with the secret, or its SHA-256 hash when there is no secret.
+func (o Options) Value(target string) string {
+	var h hash.Hash
+	if len(o.Secret) > 0 {
+		h = hmac.New(sha256.New, []byte(o.Secret))
+	} else {
+		h = sha256.New()
+	}
+	h.Write([]byte(target))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// String returns the serialization of the cookie with the given name and value, and the attributes of the options,
+// for use in a Set-Cookie response header. The path defaults to "/".
+func (o Options) String(name string, value string) string {
+	c := &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     o.Path,
+		Domain:   o.Domain,
+		Secure:   o.Secure,
+		HttpOnly: o.HTTPOnly,
+	}
+	if len(c.Path) == 0 {
+		c.Path = "/"
+	}
+	if o.MaxAge > 0 {
+		c.MaxAge = o.MaxAge
+	}
+
+	// the SameSite attribute is not supported by http.Cookie
+	serialized := c.String()
+	if sameSite, ok := sameSiteValues[strings.ToLower(o.SameSite)]; ok {
+		serialized += "; SameSite=" + sameSite
+	}
+	return serialized
+}
+
+// Set adds the cookie with the given name and value, and the attributes of the options, to the response.
+func (o Options) Set(rw http.ResponseWriter, name string, value string) {
+	rw.Header().Add("Set-Cookie", o.String(name, value))
+}
@@ -0,0 +1,77 @@
+package cookie
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptionsValue(t *testing.T) {
+	unsigned := Options{}
+	signed := Options{Secret: "secret"}
+	otherSigned := Options{Secret: "other"}
+
+	assert.Equal(t, unsigned.Value("http://10.0.0.1:80"), unsigned.Value("http://10.0.0.1:80"))
+	assert.NotEqual(t, unsigned.Value("http://10.0.0.1:80"), unsigned.Value("http://10.0.0.2:80"))
+	assert.NotContains(t, unsigned.Value("http://10.0.0.1:80"), "10.0.0.1")
+
+	assert.Equal(t, signed.Value("http://10.0.0.1:80"), signed.Value("http://10.0.0.1:80"))
+	assert.NotEqual(t, unsigned.Value("http://10.0.0.1:80"), signed.Value("http://10.0.0.1:80"))
+	assert.NotEqual(t, otherSigned.Value("http://10.0.0.1:80"), signed.Value("http://10.0.0.1:80"))
+}
+
+func TestOptionsString(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		options  Options
+		expected string
+	}{
+		{
+			desc:     "default attributes",
+			expected: "name=value; Path=/",
+		},
+		{
+			desc: "all attributes",
+			options: Options{
+				Secure:   true,
+				HTTPOnly: true,
+				SameSite: "lax",
+				MaxAge:   3600,
+				Domain:   "example.com",
+				Path:     "/app",
+			},
+			expected: "name=value; Path=/app; Domain=example.com; Max-Age=3600; HttpOnly; Secure; SameSite=Lax",
+		},
+		{
+			desc:     "SameSite none",
+			options:  Options{Secure: true, SameSite: "None"},
+			expected: "name=value; Path=/; Secure; SameSite=None",
+		},
+		{
+			desc:     "invalid SameSite",
+			options:  Options{SameSite: "foo"},
+			expected: "name=value; Path=/",
+		},
+		{
+			desc:     "negative max age",
+			options:  Options{MaxAge: -1},
+			expected: "name=value; Path=/",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, test.options.String("name", "value"))
+		})
+	}
+}
+
+func TestValidSameSite(t *testing.T) {
+	for _, sameSite := range []string{"", "lax", "Strict", "NONE"} {
+		assert.True(t, ValidSameSite(sameSite), sameSite)
+	}
+	assert.False(t, ValidSameSite("foo"))
+}
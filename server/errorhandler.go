@@ -5,36 +5,51 @@ import (
 	"net"
 	"net/http"
 
+	"github.com/containous/traefik/healthcheck"
 	"github.com/containous/traefik/middlewares"
 )
 
 // RecordingErrorHandler is an error handler, implementing the vulcand/oxy
 // error handler interface, which is recording network errors by using the netErrorRecorder.
 // In addition it sets a proper HTTP status code and body, depending on the type of error occurred.
+// The network errors are also reported to the outlier detector of the backend, if any.
 type RecordingErrorHandler struct {
 	netErrorRecorder middlewares.NetErrorRecorder
+	outlierDetector  *healthcheck.OutlierDetector
 }
 
 // NewRecordingErrorHandler creates and returns a new instance of RecordingErrorHandler.
 func NewRecordingErrorHandler(recorder middlewares.NetErrorRecorder) *RecordingErrorHandler {
-	return &RecordingErrorHandler{recorder}
+	return &RecordingErrorHandler{netErrorRecorder: recorder}
+}
+
+// WithOutlierDetector returns a copy of the error handler, reporting the network errors to the given outlier detector.
+func (eh *RecordingErrorHandler) WithOutlierDetector(detector *healthcheck.OutlierDetector) *RecordingErrorHandler {
+	return &RecordingErrorHandler{netErrorRecorder: eh.netErrorRecorder, outlierDetector: detector}
 }
 
 func (eh *RecordingErrorHandler) ServeHTTP(w http.ResponseWriter, req *http.Request, err error) {
 	statusCode := http.StatusInternalServerError
 
 	if e, ok := err.(net.Error); ok {
-		eh.netErrorRecorder.Record(req.Context())
+		eh.recordNetError(req)
 		if e.Timeout() {
 			statusCode = http.StatusGatewayTimeout
 		} else {
 			statusCode = http.StatusBadGateway
 		}
 	} else if err == io.EOF {
-		eh.netErrorRecorder.Record(req.Context())
+		eh.recordNetError(req)
 		statusCode = http.StatusBadGateway
 	}
 
 	w.WriteHeader(statusCode)
 	w.Write([]byte(http.StatusText(statusCode)))
 }
+
+func (eh *RecordingErrorHandler) recordNetError(req *http.Request) {
+	eh.netErrorRecorder.Record(req.Context())
+	if eh.outlierDetector != nil {
+		eh.outlierDetector.Record(req.URL, true)
+	}
+}
@@ -8,6 +8,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
+
+	"github.com/containous/traefik/healthcheck"
+	"github.com/containous/traefik/testhelpers"
+	"github.com/vulcand/oxy/roundrobin"
 )
 
 type timeoutError struct{}
@@ -79,6 +84,34 @@ func TestServeHTTP(t *testing.T) {
 	}
 }
 
+func TestServeHTTPRecordsOutliers(t *testing.T) {
+	lb, err := roundrobin.New(nil)
+	if err != nil {
+		t.Fatalf("failed to create load-balancer: %s", err)
+	}
+	if err := lb.UpsertServer(testhelpers.MustParseURL("http://localhost:3000")); err != nil {
+		t.Fatalf("failed to add server: %s", err)
+	}
+	detector := healthcheck.NewOutlierDetector(healthcheck.OutlierOptions{
+		ConsecutiveErrors:  2,
+		BaseEjectionTime:   time.Hour,
+		MaxEjectionTime:    time.Hour,
+		MaxEjectionPercent: 100,
+		LB:                 lb,
+	}, "backend")
+	recordingErrorHandler := NewRecordingErrorHandler(&netErrorRecorder{}).WithOutlierDetector(detector)
+
+	// only the network errors count
+	for _, err := range []error{io.EOF, errors.New("any error"), &timeoutError{}} {
+		req := httptest.NewRequest(http.MethodGet, "http://localhost:3000/any", nil)
+		recordingErrorHandler.ServeHTTP(httptest.NewRecorder(), req, err)
+	}
+
+	if len(lb.Servers()) != 0 {
+		t.Errorf("got %d servers, wanted the server to be ejected", len(lb.Servers()))
+	}
+}
+
 type netErrorRecorder struct {
 	netErrorWasRecorded bool
 }
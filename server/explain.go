@@ -0,0 +1,124 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/containous/mux"
+	"github.com/containous/traefik/api"
+	"github.com/containous/traefik/configuration"
+)
+
+// routingTable describes the frontends wired to the router of an entry point.
+type routingTable struct {
+	router           *mux.Router
+	forwardedHeaders *configuration.ForwardedHeaders
+	frontends        map[string]*frontendRouting
+	conflicts        []api.FrontendConflict
+}
+
+// frontendRouting holds what loadConfig assembled for a frontend on an entry point.
+type frontendRouting struct {
+	backend     string
+	priority    int
+	rules       map[string]string
+	middlewares []string
+}
+
+// modifiers returns the request modifiers of the route, in the order they are applied.
+func (r *serverRoute) modifiers() []string {
+	var modifiers []string
+	if len(r.stripPrefixesRegex) > 0 {
+		modifiers = append(modifiers, "strip prefix regex")
+	}
+	if len(r.stripPrefixes) > 0 {
+		modifiers = append(modifiers, "strip prefix")
+	}
+	if len(r.addPrefix) > 0 {
+		modifiers = append(modifiers, "add prefix")
+	}
+	if len(r.replacePathRegex) > 0 {
+		modifiers = append(modifiers, "replace path regex")
+	}
+	if len(r.replacePath) > 0 {
+		modifiers = append(modifiers, "replace path")
+	}
+	return modifiers
+}
+
+func newRoutingTable(router *mux.Router, entryPoint *configuration.EntryPoint) *routingTable {
+	table := &routingTable{
+		router:    router,
+		frontends: make(map[string]*frontendRouting),
+	}
+	if entryPoint != nil {
+		table.forwardedHeaders = entryPoint.ForwardedHeaders
+	}
+	return table
+}
+
+// ExplainRoute reports which frontend of the entry point handles the request and why.
+func (s *Server) ExplainRoute(entryPointName string, req *http.Request) (*api.RouteExplanation, error) {
+	serverEntryPoint, ok := s.serverEntryPoints[entryPointName]
+	if !ok {
+		return nil, fmt.Errorf("unknown entry point %q", entryPointName)
+	}
+
+	explanation := &api.RouteExplanation{
+		EntryPoint: entryPointName,
+		Frontends:  []api.FrontendExplanation{},
+	}
+
+	table, ok := serverEntryPoint.routingTable.Get().(*routingTable)
+	if !ok || table == nil {
+		return explanation, nil
+	}
+
+	// Routes are evaluated in the order of the router, which is sorted by priority.
+	var routes []*mux.Route
+	table.router.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		if len(ancestors) == 0 {
+			routes = append(routes, route)
+		}
+		return nil
+	})
+
+	for _, route := range routes {
+		frontend := api.FrontendExplanation{
+			Name:     route.GetName(),
+			Priority: route.GetPriority(),
+			Matched:  route.Match(req, &mux.RouteMatch{}),
+		}
+
+		if routing, ok := table.frontends[frontend.Name]; ok {
+			frontend.Backend = routing.backend
+			frontend.Middlewares = routing.middlewares
+			frontend.Routes = explainFrontendRules(routing.rules, table.forwardedHeaders, req)
+		}
+
+		if frontend.Matched && explanation.Frontend == "" {
+			explanation.Frontend = frontend.Name
+			explanation.Backend = frontend.Backend
+		}
+		explanation.Frontends = append(explanation.Frontends, frontend)
+	}
+
+	return explanation, nil
+}
+
+func explainFrontendRules(rules map[string]string, forwardedHeaders *configuration.ForwardedHeaders, req *http.Request) map[string]api.RouteRulesExplanation {
+	explanations := make(map[string]api.RouteRulesExplanation)
+	for routeName, rule := range rules {
+		explanation := api.RouteRulesExplanation{Rule: rule}
+
+		r := &Rules{route: &serverRoute{route: &mux.Route{}}, forwardedHeaders: forwardedHeaders}
+		evaluations, err := r.Explain(explanation.Rule, req)
+		if err != nil {
+			explanation.Error = err.Error()
+		} else {
+			explanation.Rules = evaluations
+		}
+		explanations[routeName] = explanation
+	}
+	return explanations
+}
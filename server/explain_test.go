@@ -0,0 +1,86 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/containous/traefik/api"
+	"github.com/containous/traefik/configuration"
+	"github.com/containous/traefik/testhelpers"
+	"github.com/containous/traefik/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerExplainRoute(t *testing.T) {
+	globalConfig := configuration.GlobalConfiguration{
+		EntryPoints: configuration.EntryPoints{
+			"http": &configuration.EntryPoint{ForwardedHeaders: &configuration.ForwardedHeaders{Insecure: true}},
+		},
+	}
+
+	dynamicConfigs := types.Configurations{
+		"config": &types.Configuration{
+			Frontends: map[string]*types.Frontend{
+				"frontend-api": {
+					EntryPoints: []string{"http"},
+					Backend:     "backend",
+					Routes: map[string]types.Route{
+						"route": {Rule: "Host:foo.bar;PathPrefixStrip:/api"},
+					},
+				},
+				"frontend-web": {
+					EntryPoints: []string{"http"},
+					Backend:     "backend",
+					Routes: map[string]types.Route{
+						"route": {Rule: "Host:foo.bar && !Method:POST"},
+					},
+					Headers: &types.Headers{
+						CustomRequestHeaders: map[string]string{"X-Foo": "bar"},
+					},
+				},
+			},
+			Backends: map[string]*types.Backend{
+				"backend": {
+					Servers: map[string]types.Server{
+						"server": {URL: "http://localhost"},
+					},
+					LoadBalancer: &types.LoadBalancer{Method: "wrr"},
+				},
+			},
+		},
+	}
+
+	srv := NewServer(globalConfig, nil)
+	entryPoints, err := srv.loadConfig(dynamicConfigs, globalConfig)
+	require.NoError(t, err)
+	srv.serverEntryPoints = entryPoints
+
+	_, err = srv.ExplainRoute("https", testhelpers.MustNewRequest(http.MethodGet, "http://foo.bar/", nil))
+	assert.Error(t, err)
+
+	explanation, err := srv.ExplainRoute("http", testhelpers.MustNewRequest(http.MethodPost, "http://foo.bar/api/users", nil))
+	require.NoError(t, err)
+
+	assert.Equal(t, "frontend-api", explanation.Frontend)
+	assert.Equal(t, "backend", explanation.Backend)
+	require.Len(t, explanation.Frontends, 2)
+
+	apiFrontend := explanation.Frontends[0]
+	assert.Equal(t, "frontend-api", apiFrontend.Name)
+	assert.True(t, apiFrontend.Matched)
+	assert.Equal(t, []string{"strip prefix", "empty backend handler", "load-balancer wrr"}, apiFrontend.Middlewares)
+	assert.Equal(t, []api.RuleEvaluation{
+		{Rule: "Host:foo.bar", Matched: true},
+		{Rule: "PathPrefixStrip:/api", Matched: true},
+	}, apiFrontend.Routes["route"].Rules)
+
+	webFrontend := explanation.Frontends[1]
+	assert.Equal(t, "frontend-web", webFrontend.Name)
+	assert.False(t, webFrontend.Matched)
+	assert.True(t, apiFrontend.Priority > webFrontend.Priority)
+	assert.Equal(t, []api.RuleEvaluation{
+		{Rule: "Host:foo.bar", Matched: true},
+		{Rule: "Method:POST", Matched: true, Negated: true},
+	}, webFrontend.Routes["route"].Rules)
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 
 	"github.com/containous/traefik/log"
+	"github.com/containous/traefik/middlewares"
 	"github.com/containous/traefik/whitelist"
 	"github.com/vulcand/oxy/forward"
 )
@@ -38,6 +39,8 @@ type headerRewriter struct {
 }
 
 func (h *headerRewriter) Rewrite(req *http.Request) {
+	middlewares.SetDeadlineHeader(req)
+
 	clientIP, _, err := net.SplitHostPort(req.RemoteAddr)
 	if err != nil {
 		log.Error(err)
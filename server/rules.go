@@ -1,23 +1,28 @@
 package server
 
 import (
-	"errors"
 	"fmt"
 	"net"
 	"net/http"
 	"reflect"
+	"regexp"
 	"sort"
 	"strings"
 
 	"github.com/BurntSushi/ty/fun"
 	"github.com/containous/mux"
+	"github.com/containous/traefik/api"
+	"github.com/containous/traefik/configuration"
+	"github.com/containous/traefik/middlewares"
 	"github.com/containous/traefik/types"
+	"github.com/containous/traefik/whitelist"
 )
 
 // Rules holds rule parsing and configuration
 type Rules struct {
-	route *serverRoute
-	err   error
+	route            *serverRoute
+	forwardedHeaders *configuration.ForwardedHeaders
+	err              error
 }
 
 func (r *Rules) host(hosts ...string) *mux.Route {
@@ -159,6 +164,103 @@ func (r *Rules) headersRegexp(headers ...string) *mux.Route {
 	return r.route.route.HeadersRegexp(headers...)
 }
 
+func (r *Rules) cookie(cookies ...string) *mux.Route {
+	m := &cookieMatcher{}
+	for _, elem := range cookies {
+		name, value := splitCookieRule(elem)
+		m.cookies = append(m.cookies, cookieValueMatcher{name: name, match: func(v string) bool {
+			return value == "" || v == value
+		}})
+	}
+	return r.route.route.MatcherFunc(m.Match)
+}
+
+func (r *Rules) cookieRegexp(cookies ...string) *mux.Route {
+	m := &cookieMatcher{}
+	for _, elem := range cookies {
+		name, value := splitCookieRule(elem)
+		exp, err := regexp.Compile(value)
+		if err != nil {
+			r.err = fmt.Errorf("invalid regular expression for cookie %s: %v", name, err)
+			return r.route.route
+		}
+		m.cookies = append(m.cookies, cookieValueMatcher{name: name, match: exp.MatchString})
+	}
+	return r.route.route.MatcherFunc(m.Match)
+}
+
+func splitCookieRule(elem string) (string, string) {
+	parts := strings.SplitN(elem, "=", 2)
+	if len(parts) == 1 {
+		return strings.TrimSpace(parts[0]), ""
+	}
+	return strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+}
+
+// cookieMatcher matches if the request carries any of the configured cookies with a matching value.
+type cookieMatcher struct {
+	cookies []cookieValueMatcher
+}
+
+type cookieValueMatcher struct {
+	name  string
+	match func(value string) bool
+}
+
+func (m *cookieMatcher) Match(r *http.Request, _ *mux.RouteMatch) bool {
+	for _, c := range m.cookies {
+		cookie, err := r.Cookie(c.name)
+		if err == nil && c.match(cookie.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Rules) grpcService(services ...string) *mux.Route {
+	m := &grpcMatcher{}
+	for _, service := range services {
+		m.prefixes = append(m.prefixes, "/"+strings.Trim(service, "/")+"/")
+	}
+	return r.route.route.MatcherFunc(m.Match)
+}
+
+func (r *Rules) grpcMethod(methods ...string) *mux.Route {
+	m := &grpcMatcher{}
+	for _, method := range methods {
+		method = strings.Trim(method, "/")
+		if !strings.Contains(method, "/") {
+			r.err = fmt.Errorf("invalid gRPC method %q: expected <package>.<service>/<method>", method)
+			return r.route.route
+		}
+		m.paths = append(m.paths, "/"+method)
+	}
+	return r.route.route.MatcherFunc(m.Match)
+}
+
+// grpcMatcher matches gRPC calls to any of the configured services or methods.
+type grpcMatcher struct {
+	prefixes []string
+	paths    []string
+}
+
+func (m *grpcMatcher) Match(r *http.Request, _ *mux.RouteMatch) bool {
+	if !middlewares.IsGRPCRequest(r) {
+		return false
+	}
+	for _, prefix := range m.prefixes {
+		if strings.HasPrefix(r.URL.Path, prefix) {
+			return true
+		}
+	}
+	for _, path := range m.paths {
+		if r.URL.Path == path {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *Rules) query(query ...string) *mux.Route {
 	var queries []string
 	for _, elem := range query {
@@ -168,8 +270,41 @@ func (r *Rules) query(query ...string) *mux.Route {
 	return r.route.route.Queries(queries...)
 }
 
-func (r *Rules) parseRules(expression string, onRule func(functionName string, function interface{}, arguments []string) error) error {
-	functions := map[string]interface{}{
+func (r *Rules) clientIP(sourceRanges ...string) *mux.Route {
+	sourceRange, err := whitelist.NewIP(sourceRanges, false)
+	if err != nil {
+		r.err = err
+		return r.route.route
+	}
+
+	m := &clientIPMatcher{sourceRange: sourceRange, clientIP: &whitelist.ClientIP{}}
+	if r.forwardedHeaders != nil {
+		m.clientIP, err = whitelist.NewClientIP(r.forwardedHeaders.TrustedIPs, r.forwardedHeaders.Insecure)
+		if err != nil {
+			r.err = err
+			return r.route.route
+		}
+	}
+	return r.route.route.MatcherFunc(m.Match)
+}
+
+// clientIPMatcher matches the address of the client against a list of IPs and CIDRs.
+type clientIPMatcher struct {
+	sourceRange *whitelist.IP
+	clientIP    *whitelist.ClientIP
+}
+
+func (m *clientIPMatcher) Match(r *http.Request, _ *mux.RouteMatch) bool {
+	ip := m.clientIP.IP(r)
+	if ip == nil {
+		return false
+	}
+	contains, err := m.sourceRange.ContainsIP(ip)
+	return err == nil && contains
+}
+
+func (r *Rules) functions() map[string]interface{} {
+	return map[string]interface{}{
 		"Host":                 r.host,
 		"HostRegexp":           r.hostRegexp,
 		"Path":                 r.path,
@@ -181,87 +316,269 @@ func (r *Rules) parseRules(expression string, onRule func(functionName string, f
 		"Method":               r.methods,
 		"Headers":              r.headers,
 		"HeadersRegexp":        r.headersRegexp,
+		"Cookie":               r.cookie,
+		"CookieRegexp":         r.cookieRegexp,
+		"GRPCService":          r.grpcService,
+		"GRPCMethod":           r.grpcMethod,
 		"AddPrefix":            r.addPrefix,
 		"ReplacePath":          r.replacePath,
 		"ReplacePathRegex":     r.replacePathRegex,
 		"Query":                r.query,
+		"ClientIP":             r.clientIP,
+		"SourceRange":          r.clientIP,
 	}
+}
 
-	if len(expression) == 0 {
-		return errors.New("Empty rule")
-	}
+// modifierFunctions are the rules which modify the request, and can only be combined with '&&' or ';':
+// in a sub-expression, the request would be modified whatever the branch matching it.
+var modifierFunctions = map[string]bool{
+	"AddPrefix":            true,
+	"ReplacePath":          true,
+	"ReplacePathRegex":     true,
+	"PathStrip":            true,
+	"PathStripRegex":       true,
+	"PathPrefixStrip":      true,
+	"PathPrefixStripRegex": true,
+}
+
+// matchingModifierFunctions are the modifier rules which match on the request as well.
+var matchingModifierFunctions = map[string]bool{
+	"PathStrip":            true,
+	"PathStripRegex":       true,
+	"PathPrefixStrip":      true,
+	"PathPrefixStripRegex": true,
+}
 
+func (r *Rules) parseRule(rule string) (string, interface{}, []string, error) {
 	f := func(c rune) bool {
 		return c == ':'
 	}
 
-	// Allow multiple rules separated by ;
-	splitRule := func(c rune) bool {
-		return c == ';'
+	// get function
+	parsedFunctions := strings.FieldsFunc(rule, f)
+	if len(parsedFunctions) == 0 {
+		return "", nil, nil, fmt.Errorf("error parsing rule: '%s'", rule)
+	}
+	functionName := strings.TrimSpace(parsedFunctions[0])
+	parsedFunction, ok := r.functions()[functionName]
+	if !ok {
+		return "", nil, nil, fmt.Errorf("error parsing rule: '%s'. Unknown function: '%s'", rule, parsedFunctions[0])
+	}
+	parsedFunctions = append(parsedFunctions[:0], parsedFunctions[1:]...)
+	fargs := func(c rune) bool {
+		return c == ','
+	}
+	// get function
+	parsedArgs := strings.FieldsFunc(strings.Join(parsedFunctions, ":"), fargs)
+	if len(parsedArgs) == 0 {
+		return "", nil, nil, fmt.Errorf("error parsing args from rule: '%s'", rule)
+	}
+
+	for i := range parsedArgs {
+		parsedArgs[i] = strings.TrimSpace(parsedArgs[i])
 	}
 
-	parsedRules := strings.FieldsFunc(expression, splitRule)
+	return functionName, parsedFunction, parsedArgs, nil
+}
 
-	for _, rule := range parsedRules {
-		// get function
-		parsedFunctions := strings.FieldsFunc(rule, f)
-		if len(parsedFunctions) == 0 {
-			return fmt.Errorf("error parsing rule: '%s'", rule)
-		}
-		functionName := strings.TrimSpace(parsedFunctions[0])
-		parsedFunction, ok := functions[functionName]
-		if !ok {
-			return fmt.Errorf("error parsing rule: '%s'. Unknown function: '%s'", rule, parsedFunctions[0])
+// parseRules calls onRule for every rule of the expression which is not negated.
+func (r *Rules) parseRules(expression string, onRule func(functionName string, function interface{}, arguments []string) error) error {
+	tree, err := parseRuleExpression(expression)
+	if err != nil {
+		return err
+	}
+
+	return tree.walk(false, func(leaf *ruleNode, negated bool) error {
+		functionName, function, arguments, err := r.parseRule(leaf.rule)
+		if err != nil {
+			return err
 		}
-		parsedFunctions = append(parsedFunctions[:0], parsedFunctions[1:]...)
-		fargs := func(c rune) bool {
-			return c == ','
+		if negated {
+			return nil
 		}
-		// get function
-		parsedArgs := strings.FieldsFunc(strings.Join(parsedFunctions, ":"), fargs)
-		if len(parsedArgs) == 0 {
-			return fmt.Errorf("error parsing args from rule: '%s'", rule)
+
+		err = onRule(functionName, function, arguments)
+		if err != nil {
+			return fmt.Errorf("Parsing error on rule: %v", err)
 		}
+		return nil
+	})
+}
+
+// applyRule adds the matchers and modifiers of a single rule to the current route.
+func (r *Rules) applyRule(rule string) (*mux.Route, error) {
+	functionName, function, arguments, err := r.parseRule(rule)
+	if err != nil {
+		return nil, err
+	}
+
+	inputs := make([]reflect.Value, len(arguments))
+	for i := range arguments {
+		inputs[i] = reflect.ValueOf(arguments[i])
+	}
+	method := reflect.ValueOf(function)
+	if !method.IsValid() {
+		return nil, fmt.Errorf("Method not found: '%s'", functionName)
+	}
 
-		for i := range parsedArgs {
-			parsedArgs[i] = strings.TrimSpace(parsedArgs[i])
+	resultRoute := method.Call(inputs)[0].Interface().(*mux.Route)
+	if r.err != nil {
+		return nil, fmt.Errorf("Parsing error on rule: %v", r.err)
+	}
+	if resultRoute.GetError() != nil {
+		return nil, fmt.Errorf("Parsing error on rule: %v", resultRoute.GetError())
+	}
+	return resultRoute, nil
+}
+
+// ruleMatcher applies a single rule to its own route, which is then used as a matcher.
+func (r *Rules) ruleMatcher(rule string) (mux.MatcherFunc, error) {
+	parentRoute := r.route.route
+	r.route.route = &mux.Route{}
+	defer func() { r.route.route = parentRoute }()
+
+	route, err := r.applyRule(rule)
+	if err != nil {
+		return nil, err
+	}
+	return func(req *http.Request, match *mux.RouteMatch) bool {
+		ruleMatch := &mux.RouteMatch{}
+		if !route.Match(req, ruleMatch) {
+			return false
 		}
+		mergeRouteVars(match, ruleMatch)
+		return true
+	}, nil
+}
 
-		err := onRule(functionName, parsedFunction, parsedArgs)
+// buildMatcher builds a matcher for a sub-expression using ||, ! or parentheses.
+// Every rule of the sub-expression is applied to its own route, which is then used as a matcher.
+func (r *Rules) buildMatcher(node *ruleNode) (mux.MatcherFunc, error) {
+	switch node.operator {
+	case ruleLeaf:
+		functionName, _, _, err := r.parseRule(node.rule)
 		if err != nil {
-			return fmt.Errorf("Parsing error on rule: %v", err)
+			return nil, err
+		}
+		if modifierFunctions[functionName] {
+			return nil, fmt.Errorf("modifier rule '%s' can only be combined with '&&' or ';'", node.rule)
+		}
+		return r.ruleMatcher(node.rule)
+	case ruleNot:
+		matcher, err := r.buildMatcher(node.children[0])
+		if err != nil {
+			return nil, err
+		}
+		return func(req *http.Request, match *mux.RouteMatch) bool {
+			return !matcher(req, &mux.RouteMatch{})
+		}, nil
+	case ruleAnd, ruleOr:
+		var matchers []mux.MatcherFunc
+		for _, child := range node.children {
+			matcher, err := r.buildMatcher(child)
+			if err != nil {
+				return nil, err
+			}
+			matchers = append(matchers, matcher)
+		}
+
+		if node.operator == ruleAnd {
+			return func(req *http.Request, match *mux.RouteMatch) bool {
+				andMatch := &mux.RouteMatch{}
+				for _, matcher := range matchers {
+					if !matcher(req, andMatch) {
+						return false
+					}
+				}
+				mergeRouteVars(match, andMatch)
+				return true
+			}, nil
 		}
+		return func(req *http.Request, match *mux.RouteMatch) bool {
+			for _, matcher := range matchers {
+				if matcher(req, match) {
+					return true
+				}
+			}
+			return false
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown operator in rule expression: %d", node.operator)
+	}
+}
+
+func mergeRouteVars(match *mux.RouteMatch, from *mux.RouteMatch) {
+	if len(from.Vars) == 0 {
+		return
+	}
+	if match.Vars == nil {
+		match.Vars = make(map[string]string)
+	}
+	for key, value := range from.Vars {
+		match.Vars[key] = value
 	}
-	return nil
 }
 
 // Parse parses rules expressions
 func (r *Rules) Parse(expression string) (*mux.Route, error) {
-	var resultRoute *mux.Route
-	err := r.parseRules(expression, func(functionName string, function interface{}, arguments []string) error {
-		inputs := make([]reflect.Value, len(arguments))
-		for i := range arguments {
-			inputs[i] = reflect.ValueOf(arguments[i])
-		}
-		method := reflect.ValueOf(function)
-		if method.IsValid() {
-			resultRoute = method.Call(inputs)[0].Interface().(*mux.Route)
-			if r.err != nil {
-				return r.err
-			}
-			if resultRoute.GetError() != nil {
-				return resultRoute.GetError()
+	tree, err := parseRuleExpression(expression)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing rule: %v", err)
+	}
+
+	// Rules combined at the top level with && or ; are applied to the route itself,
+	// only sub-expressions using ||, ! or parentheses need a dedicated matcher.
+	for _, node := range tree.conjuncts() {
+		if node.operator == ruleLeaf {
+			if _, err := r.applyRule(node.rule); err != nil {
+				return nil, fmt.Errorf("error parsing rule: %v", err)
 			}
+			continue
+		}
 
+		matcher, err := r.buildMatcher(node)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing rule: %v", err)
+		}
+		r.route.route.MatcherFunc(matcher)
+	}
+	return r.route.route, nil
+}
+
+// Explain matches every rule of the expression against the request on its own.
+func (r *Rules) Explain(expression string, req *http.Request) ([]api.RuleEvaluation, error) {
+	tree, err := parseRuleExpression(expression)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing rule: %v", err)
+	}
+
+	var evaluations []api.RuleEvaluation
+	err = tree.walk(false, func(leaf *ruleNode, negated bool) error {
+		evaluation := api.RuleEvaluation{Rule: leaf.rule, Negated: negated}
+
+		functionName, _, _, err := r.parseRule(leaf.rule)
+		if err != nil {
+			return err
+		}
+
+		if modifierFunctions[functionName] && !matchingModifierFunctions[functionName] {
+			evaluation.Matched = true
+			evaluation.Modifier = true
 		} else {
-			return fmt.Errorf("Method not found: '%s'", functionName)
+			matcher, err := r.ruleMatcher(leaf.rule)
+			if err != nil {
+				return err
+			}
+			evaluation.Matched = matcher(req, &mux.RouteMatch{})
 		}
+
+		evaluations = append(evaluations, evaluation)
 		return nil
 	})
 	if err != nil {
 		return nil, fmt.Errorf("error parsing rule: %v", err)
 	}
-	return resultRoute, nil
+	return evaluations, nil
 }
 
 // ParseDomains parses rules expressions and returns domains
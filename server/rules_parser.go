@@ -0,0 +1,310 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ruleOperator is the kind of a node in a rule expression tree.
+type ruleOperator int
+
+const (
+	ruleLeaf ruleOperator = iota
+	ruleAnd
+	ruleOr
+	ruleNot
+)
+
+// ruleNode is a node of a parsed rule expression.
+// Leaves hold a single rule (e.g. "Host:foo.bar"), other nodes combine their children.
+type ruleNode struct {
+	operator ruleOperator
+	rule     string
+	children []*ruleNode
+}
+
+// conjuncts returns the operands of the top-level AND of the expression.
+func (n *ruleNode) conjuncts() []*ruleNode {
+	if n.operator != ruleAnd {
+		return []*ruleNode{n}
+	}
+	var nodes []*ruleNode
+	for _, child := range n.children {
+		nodes = append(nodes, child.conjuncts()...)
+	}
+	return nodes
+}
+
+// walk calls fn for every leaf of the tree, telling whether the leaf is negated.
+func (n *ruleNode) walk(negated bool, fn func(leaf *ruleNode, negated bool) error) error {
+	if n.operator == ruleLeaf {
+		return fn(n, negated)
+	}
+	for _, child := range n.children {
+		if err := child.walk(negated != (n.operator == ruleNot), fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type ruleTokenType int
+
+const (
+	tokenRule ruleTokenType = iota
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenOpen
+	tokenClose
+	tokenSeparator
+)
+
+type ruleToken struct {
+	typ   ruleTokenType
+	value string
+}
+
+// tokenizeRuleExpression splits a rule expression into rules and operators.
+// '!' and '(' are only operators where a rule is expected, and parentheses or operators
+// inside a rule (e.g. in a regular expression between curly braces) are kept as part of it.
+func tokenizeRuleExpression(expression string) ([]ruleToken, error) {
+	var tokens []ruleToken
+	expectRule := true
+
+	for i := 0; i < len(expression); {
+		switch {
+		case expression[i] == ' ' || expression[i] == '\t':
+			i++
+		case strings.HasPrefix(expression[i:], "&&"):
+			tokens = append(tokens, ruleToken{typ: tokenAnd})
+			expectRule = true
+			i += 2
+		case strings.HasPrefix(expression[i:], "||"):
+			tokens = append(tokens, ruleToken{typ: tokenOr})
+			expectRule = true
+			i += 2
+		case expression[i] == ';':
+			tokens = append(tokens, ruleToken{typ: tokenSeparator})
+			expectRule = true
+			i++
+		case expression[i] == ')':
+			tokens = append(tokens, ruleToken{typ: tokenClose})
+			expectRule = false
+			i++
+		case expectRule && expression[i] == '!':
+			tokens = append(tokens, ruleToken{typ: tokenNot})
+			i++
+		case expectRule && expression[i] == '(':
+			tokens = append(tokens, ruleToken{typ: tokenOpen})
+			i++
+		default:
+			end, err := scanRule(expression, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, ruleToken{typ: tokenRule, value: strings.TrimSpace(expression[i:end])})
+			expectRule = false
+			i = end
+		}
+	}
+
+	return tokens, nil
+}
+
+// scanRule returns the end position of the rule starting at start.
+func scanRule(expression string, start int) (int, error) {
+	braces, parens := 0, 0
+	for i := start; i < len(expression); i++ {
+		switch expression[i] {
+		case '{':
+			braces++
+		case '}':
+			braces--
+		case '(':
+			if braces == 0 {
+				parens++
+			}
+		case ')':
+			if braces == 0 {
+				if parens == 0 {
+					return i, nil
+				}
+				parens--
+			}
+		case ';':
+			if braces == 0 && parens == 0 {
+				return i, nil
+			}
+		case '&', '|':
+			if braces == 0 && parens == 0 && i+1 < len(expression) && expression[i+1] == expression[i] {
+				return i, nil
+			}
+		}
+	}
+	if braces != 0 {
+		return 0, fmt.Errorf("unbalanced curly braces in rule '%s'", expression[start:])
+	}
+	return len(expression), nil
+}
+
+// ruleParser is a recursive descent parser for rule expressions:
+//
+//	expression = and { "||" and }
+//	and        = unary { ( "&&" | ";" ) unary }
+//	unary      = "!" unary | "(" expression ")" | rule
+type ruleParser struct {
+	tokens []ruleToken
+	pos    int
+}
+
+// parseRuleExpression parses a rule expression into a tree.
+// A plain list of rules separated by ';' results in an AND of leaves.
+func parseRuleExpression(expression string) (*ruleNode, error) {
+	if len(strings.TrimSpace(expression)) == 0 {
+		return nil, errors.New("Empty rule")
+	}
+
+	tokens, err := tokenizeRuleExpression(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &ruleParser{tokens: tokens}
+	p.skipSeparators()
+	if p.done() {
+		return nil, errors.New("Empty rule")
+	}
+
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, fmt.Errorf("unexpected %s in rule expression '%s'", p.peek(), expression)
+	}
+	return node, nil
+}
+
+func (p *ruleParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *ruleParser) peek() ruleToken {
+	return p.tokens[p.pos]
+}
+
+func (p *ruleParser) skipSeparators() {
+	for !p.done() && p.peek().typ == tokenSeparator {
+		p.pos++
+	}
+}
+
+func (p *ruleParser) parseOr() (*ruleNode, error) {
+	node, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	children := []*ruleNode{node}
+	for !p.done() && p.peek().typ == tokenOr {
+		p.pos++
+		node, err = p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, node)
+	}
+
+	if len(children) == 1 {
+		return children[0], nil
+	}
+	return &ruleNode{operator: ruleOr, children: children}, nil
+}
+
+func (p *ruleParser) parseAnd() (*ruleNode, error) {
+	node, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	children := []*ruleNode{node}
+	for !p.done() {
+		typ := p.peek().typ
+		if typ != tokenAnd && typ != tokenSeparator {
+			break
+		}
+		p.pos++
+
+		// Empty rules between ';' have always been ignored.
+		if typ == tokenSeparator {
+			p.skipSeparators()
+			if p.done() || p.peek().typ == tokenClose || p.peek().typ == tokenOr {
+				break
+			}
+		}
+
+		node, err = p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, node)
+	}
+
+	if len(children) == 1 {
+		return children[0], nil
+	}
+	return &ruleNode{operator: ruleAnd, children: children}, nil
+}
+
+func (p *ruleParser) parseUnary() (*ruleNode, error) {
+	if p.done() {
+		return nil, errors.New("unexpected end of rule expression")
+	}
+
+	token := p.peek()
+	p.pos++
+
+	switch token.typ {
+	case tokenNot:
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &ruleNode{operator: ruleNot, children: []*ruleNode{node}}, nil
+	case tokenOpen:
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.done() || p.peek().typ != tokenClose {
+			return nil, errors.New("missing closing parenthesis in rule expression")
+		}
+		p.pos++
+		return node, nil
+	case tokenRule:
+		return &ruleNode{operator: ruleLeaf, rule: token.value}, nil
+	default:
+		return nil, fmt.Errorf("unexpected %s in rule expression", token)
+	}
+}
+
+func (t ruleToken) String() string {
+	switch t.typ {
+	case tokenAnd:
+		return "'&&'"
+	case tokenOr:
+		return "'||'"
+	case tokenNot:
+		return "'!'"
+	case tokenOpen:
+		return "'('"
+	case tokenClose:
+		return "')'"
+	case tokenSeparator:
+		return "';'"
+	default:
+		return fmt.Sprintf("rule '%s'", t.value)
+	}
+}
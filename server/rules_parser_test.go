@@ -0,0 +1,104 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRuleExpression(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		expression string
+		expected   *ruleNode
+	}{
+		{
+			desc:       "single rule",
+			expression: "Host:foo.bar",
+			expected:   &ruleNode{operator: ruleLeaf, rule: "Host:foo.bar"},
+		},
+		{
+			desc:       "legacy semicolon list",
+			expression: "Host: foo.bar ;Path:/test;",
+			expected: &ruleNode{operator: ruleAnd, children: []*ruleNode{
+				{operator: ruleLeaf, rule: "Host: foo.bar"},
+				{operator: ruleLeaf, rule: "Path:/test"},
+			}},
+		},
+		{
+			desc:       "operator precedence",
+			expression: "Host:a && Path:/a || !Host:b",
+			expected: &ruleNode{operator: ruleOr, children: []*ruleNode{
+				{operator: ruleAnd, children: []*ruleNode{
+					{operator: ruleLeaf, rule: "Host:a"},
+					{operator: ruleLeaf, rule: "Path:/a"},
+				}},
+				{operator: ruleNot, children: []*ruleNode{
+					{operator: ruleLeaf, rule: "Host:b"},
+				}},
+			}},
+		},
+		{
+			desc:       "grouping",
+			expression: "(Host:a || Host:b) && PathPrefix:/api",
+			expected: &ruleNode{operator: ruleAnd, children: []*ruleNode{
+				{operator: ruleOr, children: []*ruleNode{
+					{operator: ruleLeaf, rule: "Host:a"},
+					{operator: ruleLeaf, rule: "Host:b"},
+				}},
+				{operator: ruleLeaf, rule: "PathPrefix:/api"},
+			}},
+		},
+		{
+			desc:       "parentheses inside rules",
+			expression: "(HostRegexp:{sub:(a|b)}.com || Path:/foo(bar))",
+			expected: &ruleNode{operator: ruleOr, children: []*ruleNode{
+				{operator: ruleLeaf, rule: "HostRegexp:{sub:(a|b)}.com"},
+				{operator: ruleLeaf, rule: "Path:/foo(bar)"},
+			}},
+		},
+		{
+			desc:       "operators inside curly braces",
+			expression: "PathPrefix:/{id:(a||b)}",
+			expected:   &ruleNode{operator: ruleLeaf, rule: "PathPrefix:/{id:(a||b)}"},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			node, err := parseRuleExpression(test.expression)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, node)
+		})
+	}
+}
+
+func TestParseRuleExpressionSyntaxErrors(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		expression string
+	}{
+		{desc: "empty", expression: "  "},
+		{desc: "only separators", expression: ";;"},
+		{desc: "missing operand", expression: "Host:a ||"},
+		{desc: "consecutive operators", expression: "Host:a || && Host:b"},
+		{desc: "missing closing parenthesis", expression: "(Host:a || Host:b"},
+		{desc: "unexpected closing parenthesis", expression: "Host:a) || Host:b"},
+		{desc: "unbalanced curly braces", expression: "Path:/{id"},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := parseRuleExpression(test.expression)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/containous/mux"
+	"github.com/containous/traefik/configuration"
 	"github.com/containous/traefik/testhelpers"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -72,6 +73,14 @@ func TestParseDomains(t *testing.T) {
 			expression: "Host: Foo.Bar ;Path:/test",
 			domain:     []string{"foo.bar"},
 		},
+		{
+			expression: "(Host:foo.bar || Host:bar.foo) && PathPrefix:/api",
+			domain:     []string{"foo.bar", "bar.foo"},
+		},
+		{
+			expression: "Host:foo.bar && !Host:bar.foo",
+			domain:     []string{"foo.bar"},
+		},
 	}
 
 	for _, test := range tests {
@@ -256,3 +265,433 @@ func TestPathPrefix(t *testing.T) {
 		})
 	}
 }
+
+func TestParseRuleExpressions(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		expression string
+		urls       map[string]bool
+	}{
+		{
+			desc:       "or",
+			expression: "Host:foo.bar || Host:bar.foo",
+			urls: map[string]bool{
+				"http://foo.bar/": true,
+				"http://bar.foo/": true,
+				"http://foo.foo/": false,
+			},
+		},
+		{
+			desc:       "or combined with and",
+			expression: "(Host:a.com || Host:b.com) && PathPrefix:/api",
+			urls: map[string]bool{
+				"http://a.com/api/users": true,
+				"http://b.com/api":       true,
+				"http://a.com/web":       false,
+				"http://c.com/api":       false,
+			},
+		},
+		{
+			desc:       "and binds tighter than or",
+			expression: "Host:a.com && PathPrefix:/api || Host:b.com",
+			urls: map[string]bool{
+				"http://a.com/api": true,
+				"http://a.com/web": false,
+				"http://b.com/web": true,
+			},
+		},
+		{
+			desc:       "not",
+			expression: "Host:a.com && !PathPrefix:/admin",
+			urls: map[string]bool{
+				"http://a.com/api":   true,
+				"http://a.com/admin": false,
+			},
+		},
+		{
+			desc:       "not on group",
+			expression: "!(Host:a.com || Host:b.com)",
+			urls: map[string]bool{
+				"http://a.com/": false,
+				"http://b.com/": false,
+				"http://c.com/": true,
+			},
+		},
+		{
+			desc:       "semicolon mixed with operators",
+			expression: "Path:/foo,/bar; Host:a.com || Host:b.com",
+			urls: map[string]bool{
+				"http://a.com/foo": true,
+				"http://b.com/bar": true,
+				"http://c.com/foo": false,
+				"http://a.com/baz": false,
+			},
+		},
+		{
+			desc:       "regular expressions containing parentheses",
+			expression: "HostRegexp:{subdomain:(foo\\.)?bar\\.com} || Host:baz.com",
+			urls: map[string]bool{
+				"http://foo.bar.com": true,
+				"http://bar.com":     true,
+				"http://baz.com":     true,
+				"http://fooubar.com": false,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			rules := &Rules{route: &serverRoute{route: mux.NewRouter().NewRoute()}}
+			routeResult, err := rules.Parse(test.expression)
+			require.NoError(t, err, "Error while building route for %s", test.expression)
+
+			for testURL, expectedMatch := range test.urls {
+				request := testhelpers.MustNewRequest(http.MethodGet, testURL, nil)
+				match := routeResult.Match(request, &mux.RouteMatch{Route: routeResult})
+				assert.Equal(t, expectedMatch, match, "Unexpected result matching %s with %s", test.expression, testURL)
+			}
+		})
+	}
+}
+
+func TestParseRuleExpressionVars(t *testing.T) {
+	rules := &Rules{route: &serverRoute{route: mux.NewRouter().NewRoute()}}
+	expression := "Path:/users/{id:[0-9]+} || Path:/accounts/{account}"
+	routeResult, err := rules.Parse(expression)
+	require.NoError(t, err, "Error while building route for %s", expression)
+
+	routeMatch := &mux.RouteMatch{Route: routeResult}
+	request := testhelpers.MustNewRequest(http.MethodGet, "http://foo.bar/accounts/foo", nil)
+	require.True(t, routeResult.Match(request, routeMatch))
+	assert.Equal(t, map[string]string{"account": "foo"}, routeMatch.Vars)
+}
+
+func TestParseRuleExpressionErrors(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		expression string
+	}{
+		{
+			desc:       "unknown function",
+			expression: "Host:foo.bar || Hots:bar.foo",
+		},
+		{
+			desc:       "modifier in or",
+			expression: "Host:foo.bar || AddPrefix:/foo",
+		},
+		{
+			desc:       "negated modifier",
+			expression: "!ReplacePath:/foo",
+		},
+		{
+			desc:       "strip in or",
+			expression: "PathPrefixStrip:/a || Host:b",
+		},
+		{
+			desc:       "negated strip",
+			expression: "!PathStrip:/a",
+		},
+		{
+			desc:       "strip regex in parentheses",
+			expression: "(PathPrefixStripRegex:/{id:[0-9]+} && Host:b) || Host:c",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			rules := &Rules{route: &serverRoute{route: mux.NewRouter().NewRoute()}}
+			_, err := rules.Parse(test.expression)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	testCases := []struct {
+		desc             string
+		expression       string
+		forwardedHeaders *configuration.ForwardedHeaders
+		remoteAddr       string
+		xForwardedFor    string
+		expectedMatch    bool
+	}{
+		{
+			desc:          "remote address in range",
+			expression:    "ClientIP:10.0.0.0/8,192.168.1.1",
+			remoteAddr:    "10.1.2.3:1234",
+			expectedMatch: true,
+		},
+		{
+			desc:          "remote address matches IP",
+			expression:    "SourceRange:10.0.0.0/8,192.168.1.1",
+			remoteAddr:    "192.168.1.1:1234",
+			expectedMatch: true,
+		},
+		{
+			desc:          "remote address not in range",
+			expression:    "ClientIP:10.0.0.0/8",
+			remoteAddr:    "192.168.1.1:1234",
+			expectedMatch: false,
+		},
+		{
+			desc:          "forwarded header ignored without trusted IPs",
+			expression:    "ClientIP:10.0.0.0/8",
+			remoteAddr:    "192.168.1.1:1234",
+			xForwardedFor: "10.1.2.3",
+			expectedMatch: false,
+		},
+		{
+			desc:             "forwarded header from trusted IP",
+			expression:       "ClientIP:10.0.0.0/8",
+			forwardedHeaders: &configuration.ForwardedHeaders{TrustedIPs: []string{"192.168.1.0/24"}},
+			remoteAddr:       "192.168.1.1:1234",
+			xForwardedFor:    "10.1.2.3",
+			expectedMatch:    true,
+		},
+		{
+			desc:             "forwarded header from untrusted IP",
+			expression:       "ClientIP:10.0.0.0/8",
+			forwardedHeaders: &configuration.ForwardedHeaders{TrustedIPs: []string{"192.168.2.0/24"}},
+			remoteAddr:       "192.168.1.1:1234",
+			xForwardedFor:    "10.1.2.3",
+			expectedMatch:    false,
+		},
+		{
+			desc:             "spoofed forwarded header behind trusted proxy",
+			expression:       "ClientIP:10.0.0.0/8",
+			forwardedHeaders: &configuration.ForwardedHeaders{TrustedIPs: []string{"192.168.1.0/24"}},
+			remoteAddr:       "192.168.1.1:1234",
+			xForwardedFor:    "10.1.2.3, 172.16.0.1",
+			expectedMatch:    false,
+		},
+		{
+			desc:             "chain of trusted proxies",
+			expression:       "ClientIP:10.0.0.0/8",
+			forwardedHeaders: &configuration.ForwardedHeaders{TrustedIPs: []string{"192.168.1.0/24"}},
+			remoteAddr:       "192.168.1.1:1234",
+			xForwardedFor:    "10.1.2.3, 192.168.1.2",
+			expectedMatch:    true,
+		},
+		{
+			desc:             "insecure forwarded headers",
+			expression:       "ClientIP:10.0.0.0/8",
+			forwardedHeaders: &configuration.ForwardedHeaders{Insecure: true},
+			remoteAddr:       "192.168.1.1:1234",
+			xForwardedFor:    "10.1.2.3, 172.16.0.1",
+			expectedMatch:    true,
+		},
+		{
+			desc:          "combined with host",
+			expression:    "Host:foo.bar && !ClientIP:10.0.0.0/8",
+			remoteAddr:    "10.1.2.3:1234",
+			expectedMatch: false,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			rules := &Rules{
+				route:            &serverRoute{route: mux.NewRouter().NewRoute()},
+				forwardedHeaders: test.forwardedHeaders,
+			}
+			routeResult, err := rules.Parse(test.expression)
+			require.NoError(t, err, "Error while building route for %s", test.expression)
+
+			request := testhelpers.MustNewRequest(http.MethodGet, "http://foo.bar", nil)
+			request.RemoteAddr = test.remoteAddr
+			if test.xForwardedFor != "" {
+				request.Header.Set("X-Forwarded-For", test.xForwardedFor)
+			}
+
+			match := routeResult.Match(request, &mux.RouteMatch{Route: routeResult})
+			assert.Equal(t, test.expectedMatch, match)
+		})
+	}
+}
+
+func TestClientIPInvalidRange(t *testing.T) {
+	rules := &Rules{route: &serverRoute{route: mux.NewRouter().NewRoute()}}
+	_, err := rules.Parse("ClientIP:foo")
+	assert.Error(t, err)
+}
+
+func TestCookie(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		expression    string
+		cookies       map[string]string
+		expectedMatch bool
+	}{
+		{
+			desc:          "matching value",
+			expression:    "Cookie:canary=always",
+			cookies:       map[string]string{"canary": "always"},
+			expectedMatch: true,
+		},
+		{
+			desc:          "different value",
+			expression:    "Cookie:canary=always",
+			cookies:       map[string]string{"canary": "never"},
+			expectedMatch: false,
+		},
+		{
+			desc:          "missing cookie",
+			expression:    "Cookie:canary=always",
+			expectedMatch: false,
+		},
+		{
+			desc:          "cookie presence",
+			expression:    "Cookie:canary",
+			cookies:       map[string]string{"canary": "whatever"},
+			expectedMatch: true,
+		},
+		{
+			desc:          "any of several cookies",
+			expression:    "Cookie:canary=always,beta=1",
+			cookies:       map[string]string{"beta": "1"},
+			expectedMatch: true,
+		},
+		{
+			desc:          "regular expression",
+			expression:    "CookieRegexp:group=^(beta|canary)$",
+			cookies:       map[string]string{"group": "canary"},
+			expectedMatch: true,
+		},
+		{
+			desc:          "regular expression not matching",
+			expression:    "CookieRegexp:group=^(beta|canary)$",
+			cookies:       map[string]string{"group": "stable"},
+			expectedMatch: false,
+		},
+		{
+			desc:          "combined with host",
+			expression:    "Host:foo.bar && Cookie:canary=always",
+			cookies:       map[string]string{"canary": "always"},
+			expectedMatch: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			rules := &Rules{route: &serverRoute{route: mux.NewRouter().NewRoute()}}
+			routeResult, err := rules.Parse(test.expression)
+			require.NoError(t, err, "Error while building route for %s", test.expression)
+
+			request := testhelpers.MustNewRequest(http.MethodGet, "http://foo.bar", nil)
+			for name, value := range test.cookies {
+				request.AddCookie(&http.Cookie{Name: name, Value: value})
+			}
+
+			match := routeResult.Match(request, &mux.RouteMatch{Route: routeResult})
+			assert.Equal(t, test.expectedMatch, match)
+		})
+	}
+}
+
+func TestCookieRegexpInvalid(t *testing.T) {
+	rules := &Rules{route: &serverRoute{route: mux.NewRouter().NewRoute()}}
+	_, err := rules.Parse("CookieRegexp:group=(beta")
+	assert.Error(t, err)
+}
+
+func TestGRPC(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		expression    string
+		path          string
+		contentType   string
+		expectedMatch bool
+	}{
+		{
+			desc:          "service",
+			expression:    "GRPCService:helloworld.Greeter",
+			path:          "/helloworld.Greeter/SayHello",
+			contentType:   "application/grpc",
+			expectedMatch: true,
+		},
+		{
+			desc:          "service with proto content type",
+			expression:    "GRPCService:helloworld.Greeter",
+			path:          "/helloworld.Greeter/SayHello",
+			contentType:   "application/grpc+proto",
+			expectedMatch: true,
+		},
+		{
+			desc:          "other service",
+			expression:    "GRPCService:helloworld.Greeter",
+			path:          "/helloworld.GreeterV2/SayHello",
+			contentType:   "application/grpc",
+			expectedMatch: false,
+		},
+		{
+			desc:          "any of several services",
+			expression:    "GRPCService:helloworld.Greeter,routeguide.RouteGuide",
+			path:          "/routeguide.RouteGuide/GetFeature",
+			contentType:   "application/grpc",
+			expectedMatch: true,
+		},
+		{
+			desc:          "not a gRPC request",
+			expression:    "GRPCService:helloworld.Greeter",
+			path:          "/helloworld.Greeter/SayHello",
+			contentType:   "application/json",
+			expectedMatch: false,
+		},
+		{
+			desc:          "method",
+			expression:    "GRPCMethod:helloworld.Greeter/SayHello",
+			path:          "/helloworld.Greeter/SayHello",
+			contentType:   "application/grpc",
+			expectedMatch: true,
+		},
+		{
+			desc:          "other method",
+			expression:    "GRPCMethod:helloworld.Greeter/SayHello",
+			path:          "/helloworld.Greeter/SayGoodbye",
+			contentType:   "application/grpc",
+			expectedMatch: false,
+		},
+		{
+			desc:          "combined with host",
+			expression:    "Host:foo.bar && GRPCService:helloworld.Greeter",
+			path:          "/helloworld.Greeter/SayHello",
+			contentType:   "application/grpc",
+			expectedMatch: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			rules := &Rules{route: &serverRoute{route: mux.NewRouter().NewRoute()}}
+			routeResult, err := rules.Parse(test.expression)
+			require.NoError(t, err, "Error while building route for %s", test.expression)
+
+			request := testhelpers.MustNewRequest(http.MethodPost, "http://foo.bar"+test.path, nil)
+			request.Header.Set("Content-Type", test.contentType)
+
+			match := routeResult.Match(request, &mux.RouteMatch{Route: routeResult})
+			assert.Equal(t, test.expectedMatch, match)
+		})
+	}
+}
+
+func TestGRPCMethodInvalid(t *testing.T) {
+	rules := &Rules{route: &serverRoute{route: mux.NewRouter().NewRoute()}}
+	_, err := rules.Parse("GRPCMethod:helloworld.Greeter")
+	assert.Error(t, err)
+}
@@ -2,8 +2,10 @@ package server
 
 import (
 	"context"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -23,21 +25,26 @@ import (
 
 	"github.com/armon/go-proxyproto"
 	"github.com/containous/mux"
+	"github.com/containous/traefik/api"
 	"github.com/containous/traefik/cluster"
 	"github.com/containous/traefik/configuration"
 	"github.com/containous/traefik/healthcheck"
+	"github.com/containous/traefik/loadbalancer"
 	"github.com/containous/traefik/log"
 	"github.com/containous/traefik/metrics"
 	"github.com/containous/traefik/middlewares"
 	"github.com/containous/traefik/middlewares/accesslog"
 	mauth "github.com/containous/traefik/middlewares/auth"
+	mirroring "github.com/containous/traefik/middlewares/mirror"
 	"github.com/containous/traefik/middlewares/redirect"
 	"github.com/containous/traefik/middlewares/tracing"
 	"github.com/containous/traefik/provider"
 	"github.com/containous/traefik/safe"
 	"github.com/containous/traefik/server/cookie"
+	"github.com/containous/traefik/tcp"
 	traefikTls "github.com/containous/traefik/tls"
 	"github.com/containous/traefik/types"
+	"github.com/containous/traefik/udp"
 	"github.com/containous/traefik/whitelist"
 	"github.com/eapache/channels"
 	"github.com/sirupsen/logrus"
@@ -64,6 +71,7 @@ type Server struct {
 	signals                       chan os.Signal
 	stopChan                      chan bool
 	currentConfigurations         safe.Safe
+	frontendConflicts             safe.Safe
 	providerConfigUpdateMap       map[string]chan types.ConfigMessage
 	globalConfiguration           configuration.GlobalConfiguration
 	accessLoggerMiddleware        *accesslog.LogHandler
@@ -71,17 +79,28 @@ type Server struct {
 	routinesPool                  *safe.Pool
 	leadership                    *cluster.Leadership
 	defaultForwardingRoundTripper http.RoundTripper
+	h2cForwardingRoundTripper     http.RoundTripper
 	metricsRegistry               metrics.Registry
 	provider                      provider.Provider
+	warmUps                       *loadbalancer.WarmUps
+	backendTransports             map[string]http.RoundTripper
+	previousBackendTransports     map[string]http.RoundTripper
+	backendTransportsLock         sync.Mutex
 }
 
 type serverEntryPoints map[string]*serverEntryPoint
 
 type serverEntryPoint struct {
-	httpServer *http.Server
-	listener   net.Listener
-	httpRouter *middlewares.HandlerSwitcher
-	certs      safe.Safe
+	httpServer   *http.Server
+	listener     net.Listener
+	httpRouter   *middlewares.HandlerSwitcher
+	certs        safe.Safe
+	routingTable safe.Safe
+	tcpServer    *tcp.Server
+	tcpRouter    *tcp.HandlerSwitcher
+	tcpTLSConfig *tls.Config
+	udpServer    *udp.Server
+	udpRouter    *udp.HandlerSwitcher
 }
 
 type serverRoute struct {
@@ -106,14 +125,20 @@ func NewServer(globalConfiguration configuration.GlobalConfiguration, provider p
 	server.configureSignals()
 	currentConfigurations := make(types.Configurations)
 	server.currentConfigurations.Set(currentConfigurations)
+	server.frontendConflicts.Set([]api.FrontendConflict{})
 	server.providerConfigUpdateMap = make(map[string]chan types.ConfigMessage)
 	server.globalConfiguration = globalConfiguration
+	server.warmUps = loadbalancer.NewWarmUps()
+	server.backendTransports = make(map[string]http.RoundTripper)
 	if server.globalConfiguration.API != nil {
 		server.globalConfiguration.API.CurrentConfigurations = &server.currentConfigurations
+		server.globalConfiguration.API.RouteExplainer = server
+		server.globalConfiguration.API.FrontendConflicts = &server.frontendConflicts
 	}
 
 	server.routinesPool = safe.NewPool(context.Background())
 	server.defaultForwardingRoundTripper = createHTTPTransport(globalConfiguration)
+	server.h2cForwardingRoundTripper = createH2CTransport(globalConfiguration)
 
 	server.tracingMiddleware = globalConfiguration.Tracing
 	if globalConfiguration.Tracing != nil && globalConfiguration.Tracing.Backend != "" {
@@ -121,6 +146,9 @@ func NewServer(globalConfiguration configuration.GlobalConfiguration, provider p
 	}
 
 	server.metricsRegistry = registerMetricClients(globalConfiguration.Metrics)
+	if server.globalConfiguration.API != nil {
+		server.globalConfiguration.API.HealthCheck = healthcheck.GetHealthCheck(server.metricsRegistry)
+	}
 
 	if globalConfiguration.Cluster != nil {
 		// leadership creation if cluster mode
@@ -147,14 +175,15 @@ func NewServer(globalConfiguration configuration.GlobalConfiguration, provider p
 // in Traefik at this point in time. Setting this value to the default of 100 could lead to confusing
 // behaviour and backwards compatibility issues.
 func createHTTPTransport(globalConfiguration configuration.GlobalConfiguration) *http.Transport {
-	dialer := &net.Dialer{
-		Timeout:   configuration.DefaultDialTimeout,
-		KeepAlive: 30 * time.Second,
-		DualStack: true,
-	}
-	if globalConfiguration.ForwardingTimeouts != nil {
-		dialer.Timeout = time.Duration(globalConfiguration.ForwardingTimeouts.DialTimeout)
-	}
+	transport := newHTTPTransport(globalConfiguration)
+	http2.ConfigureTransport(transport)
+
+	return transport
+}
+
+// newHTTPTransport creates an http.Transport configured with the GlobalConfiguration settings, without HTTP/2.
+func newHTTPTransport(globalConfiguration configuration.GlobalConfiguration) *http.Transport {
+	dialer := createDialer(globalConfiguration)
 
 	transport := &http.Transport{
 		Proxy:                 http.ProxyFromEnvironment,
@@ -175,11 +204,141 @@ func createHTTPTransport(globalConfiguration configuration.GlobalConfiguration)
 			RootCAs: createRootCACertPool(globalConfiguration.RootCAs),
 		}
 	}
-	http2.ConfigureTransport(transport)
 
 	return transport
 }
 
+// createDialer creates the dialer of the connections to the backend servers, configured with the GlobalConfiguration dial timeout.
+func createDialer(globalConfiguration configuration.GlobalConfiguration) *net.Dialer {
+	dialer := &net.Dialer{
+		Timeout:   configuration.DefaultDialTimeout,
+		KeepAlive: 30 * time.Second,
+		DualStack: true,
+	}
+	if globalConfiguration.ForwardingTimeouts != nil {
+		dialer.Timeout = time.Duration(globalConfiguration.ForwardingTimeouts.DialTimeout)
+	}
+	return dialer
+}
+
+// createBackendTransport creates an http.Transport configured with the GlobalConfiguration settings,
+// overridden by the transport settings of a backend.
+func createBackendTransport(globalConfiguration configuration.GlobalConfiguration, backendTransport *types.Transport) (*http.Transport, error) {
+	transport := newHTTPTransport(globalConfiguration)
+
+	if len(backendTransport.DialTimeout) > 0 {
+		dialTimeout, err := time.ParseDuration(backendTransport.DialTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid dial timeout: %v", err)
+		}
+		dialer := createDialer(globalConfiguration)
+		dialer.Timeout = dialTimeout
+		transport.DialContext = dialer.DialContext
+	}
+	if len(backendTransport.ResponseHeaderTimeout) > 0 {
+		responseHeaderTimeout, err := time.ParseDuration(backendTransport.ResponseHeaderTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid response header timeout: %v", err)
+		}
+		transport.ResponseHeaderTimeout = responseHeaderTimeout
+	}
+	if len(backendTransport.IdleConnTimeout) > 0 {
+		idleConnTimeout, err := time.ParseDuration(backendTransport.IdleConnTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid idle connection timeout: %v", err)
+		}
+		transport.IdleConnTimeout = idleConnTimeout
+	}
+	if backendTransport.MaxIdleConnsPerHost != 0 {
+		transport.MaxIdleConnsPerHost = backendTransport.MaxIdleConnsPerHost
+	}
+	transport.DisableKeepAlives = backendTransport.DisableKeepAlives
+
+	if backendTransport.TLS != nil {
+		tlsConfig, err := createBackendTLSConfig(globalConfiguration, backendTransport.TLS)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	if !backendTransport.DisableHTTP2 {
+		if err := http2.ConfigureTransport(transport); err != nil {
+			return nil, err
+		}
+	}
+	return transport, nil
+}
+
+// createBackendTLSConfig creates the TLS configuration of the connections to the servers of a backend.
+// The servers are verified with the CA of the backend, or with the global RootCAs.
+func createBackendTLSConfig(globalConfiguration configuration.GlobalConfiguration, backendTLS *types.TransportTLS) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		ServerName:         backendTLS.ServerName,
+		InsecureSkipVerify: backendTLS.InsecureSkipVerify || globalConfiguration.InsecureSkipVerify,
+	}
+
+	if len(backendTLS.CA) > 0 {
+		ca, err := traefikTls.FileOrContent(backendTLS.CA).Read()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA: %v", err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(ca) {
+			return nil, errors.New("invalid CA certificate(s)")
+		}
+	} else if len(globalConfiguration.RootCAs) > 0 {
+		tlsConfig.RootCAs = createRootCACertPool(globalConfiguration.RootCAs)
+	}
+
+	if len(backendTLS.Cert) > 0 || len(backendTLS.Key) > 0 {
+		cert, err := traefikTls.FileOrContent(backendTLS.Cert).Read()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client certificate: %v", err)
+		}
+		key, err := traefikTls.FileOrContent(backendTLS.Key).Read()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client key: %v", err)
+		}
+		certificate, err := tls.X509KeyPair(cert, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	return tlsConfig, nil
+}
+
+// createH2CTransport creates an http2.Transport talking cleartext HTTP/2 (h2c) to the backend servers,
+// configured with the GlobalConfiguration dial timeout.
+// The ResponseHeaderTimeout forwarding timeout isn't supported by this transport.
+func createH2CTransport(globalConfiguration configuration.GlobalConfiguration) *http2.Transport {
+	return newH2CTransport(createDialer(globalConfiguration))
+}
+
+// createBackendH2CTransport creates an http2.Transport talking cleartext HTTP/2 (h2c) to the servers of a backend,
+// configured with the dial timeout of the backend transport settings, the other settings being ignored.
+func createBackendH2CTransport(globalConfiguration configuration.GlobalConfiguration, backendTransport *types.Transport) (*http2.Transport, error) {
+	dialer := createDialer(globalConfiguration)
+	if len(backendTransport.DialTimeout) > 0 {
+		dialTimeout, err := time.ParseDuration(backendTransport.DialTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid dial timeout: %v", err)
+		}
+		dialer.Timeout = dialTimeout
+	}
+	return newH2CTransport(dialer), nil
+}
+
+func newH2CTransport(dialer *net.Dialer) *http2.Transport {
+	return &http2.Transport{
+		AllowHTTP: true,
+		DialTLS: func(network, addr string, _ *tls.Config) (net.Conn, error) {
+			return dialer.Dial(network, addr)
+		},
+	}
+}
+
 func createRootCACertPool(rootCAs traefikTls.RootCAs) *x509.CertPool {
 	roots := x509.NewCertPool()
 
@@ -225,7 +384,14 @@ func (s *Server) Stop() {
 			graceTimeOut := time.Duration(s.globalConfiguration.LifeCycle.GraceTimeOut)
 			ctx, cancel := context.WithTimeout(context.Background(), graceTimeOut)
 			log.Debugf("Waiting %s seconds before killing connections on entrypoint %s...", graceTimeOut, serverEntryPointName)
-			if err := serverEntryPoint.httpServer.Shutdown(ctx); err != nil {
+			if serverEntryPoint.udpServer != nil {
+				serverEntryPoint.udpServer.Close()
+			} else if serverEntryPoint.tcpServer != nil {
+				if err := serverEntryPoint.tcpServer.Shutdown(ctx); err != nil {
+					log.Debugf("Wait is over due to: %s", err)
+					serverEntryPoint.tcpServer.Close()
+				}
+			} else if err := serverEntryPoint.httpServer.Shutdown(ctx); err != nil {
 				log.Debugf("Wait is over due to: %s", err)
 				serverEntryPoint.httpServer.Close()
 			}
@@ -287,6 +453,26 @@ func (s *Server) startHTTPServers() {
 }
 
 func (s *Server) setupServerEntryPoint(newServerEntryPointName string, newServerEntryPoint *serverEntryPoint) *serverEntryPoint {
+	if entryPoint := s.globalConfiguration.EntryPoints[newServerEntryPointName]; entryPoint.IsTCP() {
+		tcpServer, tlsConfig, err := s.prepareTCPServer(newServerEntryPointName, entryPoint, newServerEntryPoint)
+		if err != nil {
+			log.Fatal("Error preparing server: ", err)
+		}
+		serverEntryPoint := s.serverEntryPoints[newServerEntryPointName]
+		serverEntryPoint.tcpServer = tcpServer
+		serverEntryPoint.tcpTLSConfig = tlsConfig
+		return serverEntryPoint
+	}
+	if entryPoint := s.globalConfiguration.EntryPoints[newServerEntryPointName]; entryPoint.IsUDP() {
+		udpServer, err := s.prepareUDPServer(newServerEntryPointName, entryPoint, newServerEntryPoint)
+		if err != nil {
+			log.Fatal("Error preparing server: ", err)
+		}
+		serverEntryPoint := s.serverEntryPoints[newServerEntryPointName]
+		serverEntryPoint.udpServer = udpServer
+		return serverEntryPoint
+	}
+
 	serverMiddlewares := []negroni.Handler{middlewares.NegroniRecoverHandler()}
 	serverInternalMiddlewares := []negroni.Handler{middlewares.NegroniRecoverHandler()}
 
@@ -449,6 +635,13 @@ func (s *Server) loadConfiguration(configMsg types.ConfigMessage) {
 		s.metricsRegistry.LastConfigReloadSuccessGauge().Set(float64(time.Now().Unix()))
 		for newServerEntryPointName, newServerEntryPoint := range newServerEntryPoints {
 			s.serverEntryPoints[newServerEntryPointName].httpRouter.UpdateHandler(newServerEntryPoint.httpRouter.GetHandler())
+			s.serverEntryPoints[newServerEntryPointName].routingTable.Set(newServerEntryPoint.routingTable.Get())
+			if newServerEntryPoint.tcpRouter != nil {
+				s.serverEntryPoints[newServerEntryPointName].tcpRouter.UpdateHandler(newServerEntryPoint.tcpRouter.GetHandler())
+			}
+			if newServerEntryPoint.udpRouter != nil {
+				s.serverEntryPoints[newServerEntryPointName].udpRouter.UpdateHandler(newServerEntryPoint.udpRouter.GetHandler())
+			}
 			if s.globalConfiguration.EntryPoints[newServerEntryPointName].TLS == nil {
 				if newServerEntryPoint.certs.Get() != nil {
 					log.Debugf("Certificates not added to non-TLS entryPoint %s.", newServerEntryPointName)
@@ -456,9 +649,11 @@ func (s *Server) loadConfiguration(configMsg types.ConfigMessage) {
 			} else {
 				s.serverEntryPoints[newServerEntryPointName].certs.Set(newServerEntryPoint.certs.Get())
 			}
-			log.Infof("Server configuration reloaded on %s", s.serverEntryPoints[newServerEntryPointName].httpServer.Addr)
+			log.Infof("Server configuration reloaded on %s", s.globalConfiguration.EntryPoints[newServerEntryPointName].Address)
 		}
 		s.currentConfigurations.Set(newConfigurations)
+		s.warmUps.Retain(configuredServers(newConfigurations))
+		s.frontendConflicts.Set(s.collectConflicts())
 		s.postLoadConfiguration()
 	} else {
 		s.metricsRegistry.ConfigReloadsFailureCounter().Add(1)
@@ -686,6 +881,22 @@ func (s *Server) createTLSConfig(entryPointName string, tlsOption *traefikTls.TL
 }
 
 func (s *Server) startServer(serverEntryPoint *serverEntryPoint, globalConfiguration configuration.GlobalConfiguration) {
+	if serverEntryPoint.udpServer != nil {
+		log.Infof("Starting UDP server on %s", serverEntryPoint.udpServer.Addr)
+		if err := serverEntryPoint.udpServer.Serve(); err != udp.ErrServerClosed {
+			log.Error("Error creating server: ", err)
+		}
+		return
+	}
+
+	if serverEntryPoint.tcpServer != nil {
+		log.Infof("Starting TCP server on %s", serverEntryPoint.tcpServer.Addr)
+		if err := serverEntryPoint.tcpServer.Serve(); err != tcp.ErrServerClosed {
+			log.Error("Error creating server: ", err)
+		}
+		return
+	}
+
 	log.Infof("Starting server on %s", serverEntryPoint.httpServer.Addr)
 	var err error
 	if serverEntryPoint.httpServer.TLSConfig != nil {
@@ -749,16 +960,36 @@ func (s *Server) prepareServer(entryPointName string, entryPoint *configuration.
 		return nil, nil, err
 	}
 
+	listener, err := buildListener(entryPoint)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &http.Server{
+			Addr:         entryPoint.Address,
+			Handler:      internalMuxRouter,
+			TLSConfig:    tlsConfig,
+			ReadTimeout:  readTimeout,
+			WriteTimeout: writeTimeout,
+			IdleTimeout:  idleTimeout,
+			ErrorLog:     httpServerLogger,
+		},
+		listener,
+		nil
+}
+
+// buildListener opens the listener of an entry point, handling the proxy protocol if enabled.
+func buildListener(entryPoint *configuration.EntryPoint) (net.Listener, error) {
 	listener, err := net.Listen("tcp", entryPoint.Address)
 	if err != nil {
 		log.Error("Error opening listener ", err)
-		return nil, nil, err
+		return nil, err
 	}
 
 	if entryPoint.ProxyProtocol != nil {
 		IPs, err := whitelist.NewIP(entryPoint.ProxyProtocol.TrustedIPs, entryPoint.ProxyProtocol.Insecure)
 		if err != nil {
-			return nil, nil, fmt.Errorf("error creating whitelist: %s", err)
+			return nil, fmt.Errorf("error creating whitelist: %s", err)
 		}
 		log.Infof("Enabling ProxyProtocol for trusted IPs %v", entryPoint.ProxyProtocol.TrustedIPs)
 		listener = &proxyproto.Listener{
@@ -773,17 +1004,7 @@ func (s *Server) prepareServer(entryPointName string, entryPoint *configuration.
 		}
 	}
 
-	return &http.Server{
-			Addr:         entryPoint.Address,
-			Handler:      internalMuxRouter,
-			TLSConfig:    tlsConfig,
-			ReadTimeout:  readTimeout,
-			WriteTimeout: writeTimeout,
-			IdleTimeout:  idleTimeout,
-			ErrorLog:     httpServerLogger,
-		},
-		listener,
-		nil
+	return listener, nil
 }
 
 func (s *Server) buildInternalRouter(entryPointName, path string, internalMiddlewares []negroni.Handler) *mux.Router {
@@ -837,11 +1058,18 @@ func buildServerTimeouts(globalConfig configuration.GlobalConfiguration) (readTi
 
 func (s *Server) buildEntryPoints(globalConfiguration configuration.GlobalConfiguration) map[string]*serverEntryPoint {
 	serverEntryPoints := make(map[string]*serverEntryPoint)
-	for entryPointName := range globalConfiguration.EntryPoints {
+	for entryPointName, entryPoint := range globalConfiguration.EntryPoints {
 		router := s.buildDefaultHTTPRouter()
 		serverEntryPoints[entryPointName] = &serverEntryPoint{
 			httpRouter: middlewares.NewHandlerSwitcher(router),
 		}
+		serverEntryPoints[entryPointName].routingTable.Set(newRoutingTable(router, entryPoint))
+		if entryPoint.IsTCP() {
+			serverEntryPoints[entryPointName].tcpRouter = tcp.NewHandlerSwitcher(tcp.NewRouter())
+		}
+		if entryPoint.IsUDP() {
+			serverEntryPoints[entryPointName].udpRouter = udp.NewHandlerSwitcher(nil)
+		}
 	}
 	return serverEntryPoints
 }
@@ -864,12 +1092,124 @@ func (s *Server) getRoundTripper(entryPointName string, globalConfiguration conf
 	return s.defaultForwardingRoundTripper, nil
 }
 
+// getBackendRoundTripper returns the RoundTripper forwarding the requests to the servers of the backend,
+// which is the given one unless the backend speaks cleartext HTTP/2 or has its own transport settings.
+func (s *Server) getBackendRoundTripper(roundTripper http.RoundTripper, backend *types.Backend) (http.RoundTripper, error) {
+	if backend == nil {
+		return roundTripper, nil
+	}
+
+	switch backend.Protocol {
+	case "", types.BackendProtocolHTTP:
+		if backend.Transport == nil {
+			return roundTripper, nil
+		}
+	case types.BackendProtocolH2C:
+		if backend.Transport == nil {
+			return s.h2cForwardingRoundTripper, nil
+		}
+	default:
+		return nil, fmt.Errorf("unknown protocol %q", backend.Protocol)
+	}
+
+	return s.getBackendTransport(backend.Protocol, backend.Transport)
+}
+
+// getBackendTransport returns the RoundTripper for the transport settings of a backend.
+// The RoundTrippers are shared by the backends with the same settings, and kept across the configuration reloads,
+// so that their idle connections are reused.
+func (s *Server) getBackendTransport(protocol string, backendTransport *types.Transport) (http.RoundTripper, error) {
+	key, err := backendTransportKey(protocol, backendTransport)
+	if err != nil {
+		return nil, err
+	}
+
+	s.backendTransportsLock.Lock()
+	defer s.backendTransportsLock.Unlock()
+
+	if roundTripper, ok := s.backendTransports[key]; ok {
+		return roundTripper, nil
+	}
+	if roundTripper, ok := s.previousBackendTransports[key]; ok {
+		s.backendTransports[key] = roundTripper
+		return roundTripper, nil
+	}
+
+	var roundTripper http.RoundTripper
+	if protocol == types.BackendProtocolH2C {
+		roundTripper, err = createBackendH2CTransport(s.globalConfiguration, backendTransport)
+	} else {
+		roundTripper, err = createBackendTransport(s.globalConfiguration, backendTransport)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid transport settings: %v", err)
+	}
+	s.backendTransports[key] = roundTripper
+	return roundTripper, nil
+}
+
+// backendTransportKey returns the key of the transport settings of a backend,
+// which includes the content of its TLS files, so that a rotated certificate is read again.
+func backendTransportKey(protocol string, backendTransport *types.Transport) (string, error) {
+	settings, err := json.Marshal(backendTransport)
+	if err != nil {
+		return "", err
+	}
+	key := protocol + string(settings)
+
+	if backendTransport != nil && backendTransport.TLS != nil {
+		hash := sha256.New()
+		for _, file := range []string{backendTransport.TLS.CA, backendTransport.TLS.Cert, backendTransport.TLS.Key} {
+			if len(file) == 0 {
+				continue
+			}
+			content, err := traefikTls.FileOrContent(file).Read()
+			if err != nil {
+				return "", err
+			}
+			hash.Write(content)
+		}
+		key += hex.EncodeToString(hash.Sum(nil))
+	}
+	return key, nil
+}
+
+// renewBackendTransports starts the transports of the backends of a new configuration:
+// the transports of the previous configuration are only carried over if their settings are still in use.
+func (s *Server) renewBackendTransports() {
+	s.backendTransportsLock.Lock()
+	defer s.backendTransportsLock.Unlock()
+
+	s.previousBackendTransports = s.backendTransports
+	s.backendTransports = make(map[string]http.RoundTripper)
+}
+
+// releaseBackendTransports closes the idle connections of the transports of the previous configuration no longer in use.
+func (s *Server) releaseBackendTransports() {
+	s.backendTransportsLock.Lock()
+	defer s.backendTransportsLock.Unlock()
+
+	for key, roundTripper := range s.previousBackendTransports {
+		if _, ok := s.backendTransports[key]; ok {
+			continue
+		}
+		if closer, ok := roundTripper.(interface{ CloseIdleConnections() }); ok {
+			closer.CloseIdleConnections()
+		}
+	}
+	s.previousBackendTransports = nil
+}
+
 // loadConfig returns a new gorilla.mux Route from the specified global configuration and the dynamic
 // provider configurations.
 func (s *Server) loadConfig(configurations types.Configurations, globalConfiguration configuration.GlobalConfiguration) (map[string]*serverEntryPoint, error) {
+	s.renewBackendTransports()
+	defer s.releaseBackendTransports()
+
 	serverEntryPoints := s.buildEntryPoints(globalConfiguration)
 	redirectHandlers := make(map[string]negroni.Handler)
 	backends := map[string]http.Handler{}
+	backendsMiddlewares := map[string][]string{}
 	backendsHealthCheck := map[string]*healthcheck.BackendHealthCheck{}
 	errorHandler := NewRecordingErrorHandler(middlewares.DefaultNetErrorRecorder{})
 
@@ -896,290 +1236,450 @@ func (s *Server) loadConfig(configurations types.Configurations, globalConfigura
 				log.Errorf("Skipping frontend %s...", frontendName)
 				continue frontend
 			}
+			if err := checkTrafficSplit(frontend.Split); err != nil {
+				log.Errorf("Invalid traffic split for frontend %s: %v", frontendName, err)
+				log.Errorf("Skipping frontend %s...", frontendName)
+				continue frontend
+			}
+			// an invalid mirror must not impede the frontend
+			mirror := frontend.Mirror
+			if err := checkMirror(mirror, config); err != nil {
+				log.Errorf("Invalid mirror for frontend %s, not mirroring its requests: %v", frontendName, err)
+				mirror = nil
+			}
+			// nor an invalid fallback backend
+			fallbackBackend := frontend.FallbackBackend
+			if err := checkFallbackBackend(frontend, config); err != nil {
+				log.Errorf("Invalid fallback backend for frontend %s, not falling back: %v", frontendName, err)
+				fallbackBackend = ""
+			}
 			for _, entryPointName := range frontend.EntryPoints {
 				log.Debugf("Wiring frontend %s to entryPoint %s", frontendName, entryPointName)
 
-				newServerRoute := &serverRoute{route: serverEntryPoints[entryPointName].httpRouter.GetHandler().NewRoute().Name(frontendName)}
-				for routeName, route := range frontend.Routes {
-					err := getRoute(newServerRoute, &route)
-					if err != nil {
-						log.Errorf("Error creating route for frontend %s: %v", frontendName, err)
-						log.Errorf("Skipping frontend %s...", frontendName)
-						continue frontend
-					}
-					log.Debugf("Creating route %s %s", routeName, route.Rule)
-				}
-
 				entryPoint := globalConfiguration.EntryPoints[entryPointName]
-				n := negroni.New()
-				if entryPoint.Redirect != nil {
-					if redirectHandlers[entryPointName] != nil {
-						n.Use(redirectHandlers[entryPointName])
-					} else if handler, err := s.buildRedirectHandler(entryPointName, entryPoint.Redirect); err != nil {
-						log.Errorf("Error loading entrypoint configuration for frontend %s: %v", frontendName, err)
-						log.Errorf("Skipping frontend %s...", frontendName)
-						continue frontend
-					} else {
-						handlerToUse := s.wrapNegroniHandlerWithAccessLog(handler, fmt.Sprintf("entrypoint redirect for %s", frontendName))
-						n.Use(handlerToUse)
-						redirectHandlers[entryPointName] = handlerToUse
-					}
+				if frontend.Split != nil && (entryPoint.IsTCP() || entryPoint.IsUDP()) {
+					log.Errorf("Traffic split is not supported on the %s entry point %s, for frontend %s", entryPoint.Network, entryPointName, frontendName)
+					log.Errorf("Skipping frontend %s...", frontendName)
+					continue frontend
 				}
-				if backends[entryPointName+frontend.Backend] == nil {
-					log.Debugf("Creating backend %s", frontend.Backend)
-
-					roundTripper, err := s.getRoundTripper(entryPointName, globalConfiguration, frontend.PassTLSCert, entryPoint.TLS)
-					if err != nil {
-						log.Errorf("Failed to create RoundTripper for frontend %s: %v", frontendName, err)
-						log.Errorf("Skipping frontend %s...", frontendName)
-						continue frontend
+				if mirror != nil && (entryPoint.IsTCP() || entryPoint.IsUDP()) {
+					log.Warnf("Mirroring is not supported on the %s entry point %s, for frontend %s", entryPoint.Network, entryPointName, frontendName)
+				}
+				if len(fallbackBackend) > 0 && (entryPoint.IsTCP() || entryPoint.IsUDP()) {
+					log.Warnf("Fallback backends are not supported on the %s entry point %s, for frontend %s", entryPoint.Network, entryPointName, frontendName)
+				}
+				if entryPoint.IsTCP() {
+					var tlsConfig *tls.Config
+					if current, ok := s.serverEntryPoints[entryPointName]; ok {
+						tlsConfig = current.tcpTLSConfig
 					}
-
-					rewriter, err := NewHeaderRewriter(entryPoint.ForwardedHeaders.TrustedIPs, entryPoint.ForwardedHeaders.Insecure)
+					err := s.wireTCPFrontend(serverEntryPoints[entryPointName].tcpRouter.GetHandler(), tlsConfig, frontendName, frontend, config, globalConfiguration)
 					if err != nil {
-						log.Errorf("Error creating rewriter for frontend %s: %v", frontendName, err)
+						log.Errorf("Error creating TCP route for frontend %s: %v", frontendName, err)
 						log.Errorf("Skipping frontend %s...", frontendName)
 						continue frontend
 					}
-
-					headerMiddleware := middlewares.NewHeaderFromStruct(frontend.Headers)
-					var responseModifier func(res *http.Response) error
-					if headerMiddleware != nil {
-						responseModifier = headerMiddleware.ModifyResponseHeaders
-					}
-
-					var fwd http.Handler
-
-					fwd, err = forward.New(
-						forward.Stream(true),
-						forward.PassHostHeader(frontend.PassHostHeader),
-						forward.RoundTripper(roundTripper),
-						forward.ErrorHandler(errorHandler),
-						forward.Rewriter(rewriter),
-						forward.ResponseModifier(responseModifier),
-					)
-
+					continue
+				}
+				if entryPoint.IsUDP() {
+					hc, err := s.wireUDPFrontend(serverEntryPoints[entryPointName].udpRouter, frontendName, frontend, config, globalConfiguration)
 					if err != nil {
-						log.Errorf("Error creating forwarder for frontend %s: %v", frontendName, err)
+						log.Errorf("Error wiring frontend %s to UDP entry point %s: %v", frontendName, entryPointName, err)
 						log.Errorf("Skipping frontend %s...", frontendName)
 						continue frontend
 					}
-
-					if s.tracingMiddleware.IsEnabled() {
-						tm := s.tracingMiddleware.NewForwarderMiddleware(frontendName, frontend.Backend)
-
-						next := fwd
-						fwd = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-							tm.ServeHTTP(w, r, next.ServeHTTP)
-						})
-					}
-
-					var rr *roundrobin.RoundRobin
-					var saveFrontend http.Handler
-					if s.accessLoggerMiddleware != nil {
-						saveBackend := accesslog.NewSaveBackend(fwd, frontend.Backend)
-						saveFrontend = accesslog.NewSaveFrontend(saveBackend, frontendName)
-						rr, _ = roundrobin.New(saveFrontend)
-					} else {
-						rr, _ = roundrobin.New(fwd)
-					}
-
-					if config.Backends[frontend.Backend] == nil {
-						log.Errorf("Undefined backend '%s' for frontend %s", frontend.Backend, frontendName)
-						log.Errorf("Skipping frontend %s...", frontendName)
-						continue frontend
+					if hc != nil {
+						addBackendHealthCheck(backendsHealthCheck, frontend.Backend, hc)
 					}
+					continue
+				}
 
-					lbMethod, err := types.NewLoadBalancerMethod(config.Backends[frontend.Backend].LoadBalancer)
+				newServerRoute := &serverRoute{route: serverEntryPoints[entryPointName].httpRouter.GetHandler().NewRoute().Name(frontendName)}
+				for routeName, route := range frontend.Routes {
+					err := getRoute(newServerRoute, &route, entryPoint.ForwardedHeaders)
 					if err != nil {
-						log.Errorf("Error loading load balancer method '%+v' for frontend %s: %v", config.Backends[frontend.Backend].LoadBalancer, frontendName, err)
+						log.Errorf("Error creating route for frontend %s: %v", frontendName, err)
 						log.Errorf("Skipping frontend %s...", frontendName)
 						continue frontend
 					}
+					log.Debugf("Creating route %s %s", routeName, route.Rule)
+				}
 
-					var sticky *roundrobin.StickySession
-					var cookieName string
-					if stickiness := config.Backends[frontend.Backend].LoadBalancer.Stickiness; stickiness != nil {
-						cookieName = cookie.GetName(stickiness.CookieName, frontend.Backend)
-						sticky = roundrobin.NewStickySession(cookieName)
+				backendNames := frontendBackendNames(frontend)
+				if mirror != nil {
+					backendNames = append(backendNames, mirror.Backend)
+				}
+				if len(fallbackBackend) > 0 {
+					backendNames = append(backendNames, fallbackBackend)
+				}
+				for _, backendName := range backendNames {
+					// the backends of a split or mirrored frontend are built as if each was the backend of the frontend
+					frontend := frontendWithBackend(frontend, backendName)
+
+					n := negroni.New()
+					// names of the negroni middlewares, and of the handlers wrapping the load-balancer from the innermost
+					var middlewareNames, handlerNames []string
+					if entryPoint.Redirect != nil {
+						middlewareNames = append(middlewareNames, "entrypoint redirect")
+						if redirectHandlers[entryPointName] != nil {
+							n.Use(redirectHandlers[entryPointName])
+						} else if handler, err := s.buildRedirectHandler(entryPointName, entryPoint.Redirect); err != nil {
+							log.Errorf("Error loading entrypoint configuration for frontend %s: %v", frontendName, err)
+							log.Errorf("Skipping frontend %s...", frontendName)
+							continue frontend
+						} else {
+							handlerToUse := s.wrapNegroniHandlerWithAccessLog(handler, fmt.Sprintf("entrypoint redirect for %s", frontendName))
+							n.Use(handlerToUse)
+							redirectHandlers[entryPointName] = handlerToUse
+						}
 					}
+					if backends[entryPointName+frontend.Backend] == nil {
+						log.Debugf("Creating backend %s", frontend.Backend)
 
-					var lb http.Handler
-					switch lbMethod {
-					case types.Drr:
-						log.Debugf("Creating load-balancer drr")
-						rebalancer, _ := roundrobin.NewRebalancer(rr)
-						if sticky != nil {
-							log.Debugf("Sticky session with cookie %v", cookieName)
-							rebalancer, _ = roundrobin.NewRebalancer(rr, roundrobin.RebalancerStickySession(sticky))
-						}
-						lb = rebalancer
-						if err := s.configureLBServers(rebalancer, config, frontend); err != nil {
+						roundTripper, err := s.getRoundTripper(entryPointName, globalConfiguration, frontend.PassTLSCert, entryPoint.TLS)
+						if err != nil {
+							log.Errorf("Failed to create RoundTripper for frontend %s: %v", frontendName, err)
 							log.Errorf("Skipping frontend %s...", frontendName)
 							continue frontend
 						}
-						hcOpts := parseHealthCheckOptions(rebalancer, frontend.Backend, config.Backends[frontend.Backend].HealthCheck, globalConfiguration.HealthCheck)
-						if hcOpts != nil {
-							log.Debugf("Setting up backend health check %s", *hcOpts)
-							hcOpts.Transport = s.defaultForwardingRoundTripper
-							backendsHealthCheck[entryPointName+frontend.Backend] = healthcheck.NewBackendHealthCheck(*hcOpts, frontend.Backend)
+						roundTripper, err = s.getBackendRoundTripper(roundTripper, config.Backends[frontend.Backend])
+						if err != nil {
+							log.Errorf("Failed to create RoundTripper for backend %s: %v", frontend.Backend, err)
+							log.Errorf("Skipping frontend %s...", frontendName)
+							continue frontend
 						}
-						lb = middlewares.NewEmptyBackendHandler(rebalancer, lb)
-					case types.Wrr:
-						log.Debugf("Creating load-balancer wrr")
-						if sticky != nil {
-							log.Debugf("Sticky session with cookie %v", cookieName)
-							if s.accessLoggerMiddleware != nil {
-								rr, _ = roundrobin.New(saveFrontend, roundrobin.EnableStickySession(sticky))
-							} else {
-								rr, _ = roundrobin.New(fwd, roundrobin.EnableStickySession(sticky))
-							}
+						healthCheckRoundTripper, err := s.getBackendRoundTripper(s.defaultForwardingRoundTripper, config.Backends[frontend.Backend])
+						if err != nil {
+							log.Errorf("Failed to create health check RoundTripper for backend %s: %v", frontend.Backend, err)
+							log.Errorf("Skipping health check of backend %s...", frontend.Backend)
 						}
-						lb = rr
-						if err := s.configureLBServers(rr, config, frontend); err != nil {
+
+						rewriter, err := NewHeaderRewriter(entryPoint.ForwardedHeaders.TrustedIPs, entryPoint.ForwardedHeaders.Insecure)
+						if err != nil {
+							log.Errorf("Error creating rewriter for frontend %s: %v", frontendName, err)
 							log.Errorf("Skipping frontend %s...", frontendName)
 							continue frontend
 						}
-						hcOpts := parseHealthCheckOptions(rr, frontend.Backend, config.Backends[frontend.Backend].HealthCheck, globalConfiguration.HealthCheck)
-						if hcOpts != nil {
-							log.Debugf("Setting up backend health check %s", *hcOpts)
-							hcOpts.Transport = s.defaultForwardingRoundTripper
-							backendsHealthCheck[entryPointName+frontend.Backend] = healthcheck.NewBackendHealthCheck(*hcOpts, frontend.Backend)
-						}
-						lb = middlewares.NewEmptyBackendHandler(rr, lb)
-					}
 
-					if len(frontend.Errors) > 0 {
-						for _, errorPage := range frontend.Errors {
-							if config.Backends[errorPage.Backend] != nil && config.Backends[errorPage.Backend].Servers["error"].URL != "" {
-								errorPageHandler, err := middlewares.NewErrorPagesHandler(errorPage, config.Backends[errorPage.Backend].Servers["error"].URL)
-								if err != nil {
-									log.Errorf("Error creating custom error page middleware, %v", err)
-								} else {
-									n.Use(errorPageHandler)
+						headerMiddleware := middlewares.NewHeaderFromStruct(frontend.Headers)
+						// the latencies of the responses feed the p2c load-balancer
+						responseModifier := loadbalancer.ObserveResponse
+						if headerMiddleware != nil {
+							responseModifier = func(res *http.Response) error {
+								if err := headerMiddleware.ModifyResponseHeaders(res); err != nil {
+									return err
 								}
-							} else {
-								log.Errorf("Error Page is configured for Frontend %s, but either Backend %s is not set or Backend URL is missing", frontendName, errorPage.Backend)
+								return loadbalancer.ObserveResponse(res)
 							}
 						}
-					}
 
-					if frontend.RateLimit != nil && len(frontend.RateLimit.RateSet) > 0 {
-						lb, err = s.buildRateLimiter(lb, frontend.RateLimit)
-						lb = s.wrapHTTPHandlerWithAccessLog(lb, fmt.Sprintf("rate limit for %s", frontendName))
-						if err != nil {
-							log.Errorf("Error creating rate limiter: %v", err)
-							log.Errorf("Skipping frontend %s...", frontendName)
-							continue frontend
+						// the responses and the network errors of the servers feed the outlier detection
+						var outlierDetector *healthcheck.OutlierDetector
+						backendErrorHandler := errorHandler
+						if outlierOpts := parseOutlierOptions(frontend.Backend, config.Backends[frontend.Backend]); outlierOpts != nil {
+							log.Debugf("Setting up backend outlier detection %s", *outlierOpts)
+							outlierDetector = healthcheck.NewOutlierDetector(*outlierOpts, frontend.Backend)
+							backendErrorHandler = errorHandler.WithOutlierDetector(outlierDetector)
+							observeResponse := responseModifier
+							responseModifier = func(res *http.Response) error {
+								outlierDetector.ObserveResponse(res)
+								return observeResponse(res)
+							}
 						}
-					}
 
-					maxConns := config.Backends[frontend.Backend].MaxConn
-					if maxConns != nil && maxConns.Amount != 0 {
-						extractFunc, err := utils.NewExtractor(maxConns.ExtractorFunc)
-						if err != nil {
-							log.Errorf("Error creating connlimit: %v", err)
-							log.Errorf("Skipping frontend %s...", frontendName)
-							continue frontend
+						// the forwarder only reads the websocket TLS configuration from an http.Transport
+						var websocketTLSConfig *tls.Config
+						if _, ok := roundTripper.(*http.Transport); !ok {
+							websocketTLSConfig = &tls.Config{}
 						}
-						log.Debugf("Creating load-balancer connlimit")
-						lb, err = connlimit.New(lb, extractFunc, maxConns.Amount)
-						lb = s.wrapHTTPHandlerWithAccessLog(lb, fmt.Sprintf("connection limit for %s", frontendName))
+
+						var fwd http.Handler
+
+						fwd, err = forward.New(
+							forward.Stream(true),
+							forward.PassHostHeader(frontend.PassHostHeader),
+							forward.RoundTripper(roundTripper),
+							forward.WebsocketTLSClientConfig(websocketTLSConfig),
+							forward.ErrorHandler(backendErrorHandler),
+							forward.Rewriter(rewriter),
+							forward.ResponseModifier(responseModifier),
+						)
+
 						if err != nil {
-							log.Errorf("Error creating connlimit: %v", err)
+							log.Errorf("Error creating forwarder for frontend %s: %v", frontendName, err)
 							log.Errorf("Skipping frontend %s...", frontendName)
 							continue frontend
 						}
-					}
 
-					if globalConfiguration.Retry != nil {
-						countServers := len(config.Backends[frontend.Backend].Servers)
-						lb = s.buildRetryMiddleware(lb, globalConfiguration, countServers, frontend.Backend)
-					}
+						if s.tracingMiddleware.IsEnabled() {
+							tm := s.tracingMiddleware.NewForwarderMiddleware(frontendName, frontend.Backend)
 
-					if s.metricsRegistry.IsEnabled() {
-						n.Use(middlewares.NewBackendMetricsMiddleware(s.metricsRegistry, frontend.Backend))
-					}
+							next := fwd
+							fwd = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+								tm.ServeHTTP(w, r, next.ServeHTTP)
+							})
+						}
 
-					ipWhitelistMiddleware, err := configureIPWhitelistMiddleware(frontend.WhitelistSourceRange)
-					if err != nil {
-						log.Errorf("Error creating IP Whitelister: %s", err)
-					} else if ipWhitelistMiddleware != nil {
-						ipWhitelistMiddleware = s.wrapNegroniHandlerWithAccessLog(ipWhitelistMiddleware, fmt.Sprintf("ipwhitelister for %s", frontendName))
-						n.Use(s.tracingMiddleware.NewNegroniHandlerWrapper("IP whitelist", ipWhitelistMiddleware, false))
-						log.Infof("Configured IP Whitelists: %s", frontend.WhitelistSourceRange)
-					}
+						// the hedged requests are sent to another server than the one picked by the load-balancer
+						var hedging *middlewares.Hedging
+						if hedgingPolicy := parseHedgingPolicy(frontend.Backend, config.Backends[frontend.Backend]); hedgingPolicy != nil {
+							hedging = s.buildHedgingMiddleware(fwd, *hedgingPolicy, frontend.Backend)
+							fwd = hedging
+						}
 
-					if frontend.Redirect != nil {
-						rewrite, err := s.buildRedirectHandler(entryPointName, frontend.Redirect)
+						var next http.Handler = fwd
+						if s.accessLoggerMiddleware != nil {
+							saveBackend := accesslog.NewSaveBackend(fwd, frontend.Backend)
+							next = accesslog.NewSaveFrontend(saveBackend, frontendName)
+						}
+
+						if config.Backends[frontend.Backend] == nil {
+							log.Errorf("Undefined backend '%s' for frontend %s", frontend.Backend, frontendName)
+							log.Errorf("Skipping frontend %s...", frontendName)
+							continue frontend
+						}
+
+						lbMethod, err := types.NewLoadBalancerMethod(config.Backends[frontend.Backend].LoadBalancer)
 						if err != nil {
-							log.Errorf("Error creating Frontend Redirect: %v", err)
-						} else {
-							n.Use(s.wrapNegroniHandlerWithAccessLog(rewrite, fmt.Sprintf("frontend redirect for %s", frontendName)))
-							log.Debugf("Frontend %s redirect created", frontendName)
+							log.Errorf("Error loading load balancer method '%+v' for frontend %s: %v", config.Backends[frontend.Backend].LoadBalancer, frontendName, err)
+							log.Errorf("Skipping frontend %s...", frontendName)
+							continue frontend
 						}
-					}
 
-					if len(frontend.BasicAuth) > 0 {
-						users := types.Users{}
-						for _, user := range frontend.BasicAuth {
-							users = append(users, user)
+						var sticky *roundrobin.StickySession
+						var cookieName string
+						var cookieOptions cookie.Options
+						if stickiness := config.Backends[frontend.Backend].LoadBalancer.Stickiness; stickiness != nil {
+							cookieName = cookie.GetName(stickiness.CookieName, frontend.Backend)
+							cookieOptions = stickyCookieOptions(stickiness, frontendName)
+							sticky = roundrobin.NewStickySession(cookieName)
 						}
 
-						auth := &types.Auth{}
-						auth.Basic = &types.Basic{
-							Users: users,
+						log.Debugf("Creating load-balancer %s", strings.ToLower(config.Backends[frontend.Backend].LoadBalancer.Method))
+						if sticky != nil {
+							log.Debugf("Sticky session with cookie %v", cookieName)
 						}
-						authMiddleware, err := mauth.NewAuthenticator(auth, s.tracingMiddleware)
+						newBalancer, err := newLoadBalancer(lbMethod, next, sticky, config.Backends[frontend.Backend].LoadBalancer.Hash, entryPoint.ForwardedHeaders)
 						if err != nil {
-							log.Errorf("Error creating Auth: %s", err)
-						} else {
-							n.Use(s.wrapNegroniHandlerWithAccessLog(authMiddleware, fmt.Sprintf("Auth for %s", frontendName)))
+							log.Errorf("Error creating load-balancer for frontend %s: %v", frontendName, err)
+							log.Errorf("Skipping frontend %s...", frontendName)
+							continue frontend
 						}
-					}
 
-					if headerMiddleware != nil {
-						log.Debugf("Adding header middleware for frontend %s", frontendName)
-						n.Use(s.tracingMiddleware.NewNegroniHandlerWrapper("Header", headerMiddleware, false))
-					}
+						// the servers are added through the sticky cookie, which keeps the opaque values of their cookies
+						var stickyBalancer loadBalancer = newBalancer
+						if sticky != nil {
+							stickyBalancer = loadbalancer.NewStickyCookie(newBalancer, cookieName, cookieOptions)
+						}
+						var lb http.Handler = stickyBalancer
+						balancer := s.buildBalancer(stickyBalancer, frontend.Backend, config.Backends[frontend.Backend], lbMethod, globalConfiguration.Zone)
+						handlerNames = append(handlerNames, fmt.Sprintf("load-balancer %s", strings.ToLower(config.Backends[frontend.Backend].LoadBalancer.Method)), "empty backend handler")
+						if err := s.configureLBServers(balancer, config, frontend); err != nil {
+							log.Errorf("Skipping frontend %s...", frontendName)
+							continue frontend
+						}
+						hcOpts := parseHealthCheckOptions(balancer, frontend.Backend, config.Backends[frontend.Backend].HealthCheck, globalConfiguration.HealthCheck)
+						if hcOpts != nil && healthCheckRoundTripper != nil {
+							log.Debugf("Setting up backend health check %s", *hcOpts)
+							hcOpts.Transport = healthCheckRoundTripper
+							addBackendHealthCheck(backendsHealthCheck, frontend.Backend, healthcheck.NewBackendHealthCheck(*hcOpts, frontend.Backend))
+						}
+						lb = middlewares.NewEmptyBackendHandler(newBalancer, lb)
+						if outlierDetector != nil {
+							outlierDetector.LB = balancer
+							outlierDetector.HealthCheck = backendsHealthCheck[frontend.Backend]
+						}
+						if hedging != nil {
+							hedging.LB = balancer
+						}
 
-					secureMiddleware := middlewares.NewSecure(frontend.Headers)
-					if secureMiddleware != nil {
-						log.Debugf("Adding secure middleware for frontend %s", frontendName)
-						n.UseFunc(secureMiddleware.HandlerFuncWithNext)
-					}
+						if len(frontend.Errors) > 0 {
+							for _, errorPage := range frontend.Errors {
+								if config.Backends[errorPage.Backend] != nil && config.Backends[errorPage.Backend].Servers["error"].URL != "" {
+									errorPageHandler, err := middlewares.NewErrorPagesHandler(errorPage, config.Backends[errorPage.Backend].Servers["error"].URL)
+									if err != nil {
+										log.Errorf("Error creating custom error page middleware, %v", err)
+									} else {
+										n.Use(errorPageHandler)
+										middlewareNames = append(middlewareNames, fmt.Sprintf("error pages %s", errorPage.Backend))
+									}
+								} else {
+									log.Errorf("Error Page is configured for Frontend %s, but either Backend %s is not set or Backend URL is missing", frontendName, errorPage.Backend)
+								}
+							}
+						}
 
-					if config.Backends[frontend.Backend].Buffering != nil {
-						bufferedLb, err := s.buildBufferingMiddleware(lb, config.Backends[frontend.Backend].Buffering)
+						if frontend.RateLimit != nil && len(frontend.RateLimit.RateSet) > 0 {
+							lb, err = s.buildRateLimiter(lb, frontend.RateLimit)
+							handlerNames = append(handlerNames, "rate limit")
+							lb = s.wrapHTTPHandlerWithAccessLog(lb, fmt.Sprintf("rate limit for %s", frontendName))
+							if err != nil {
+								log.Errorf("Error creating rate limiter: %v", err)
+								log.Errorf("Skipping frontend %s...", frontendName)
+								continue frontend
+							}
+						}
 
+						maxConns := config.Backends[frontend.Backend].MaxConn
+						if maxConns != nil && maxConns.Amount != 0 {
+							extractFunc, err := utils.NewExtractor(maxConns.ExtractorFunc)
+							if err != nil {
+								log.Errorf("Error creating connlimit: %v", err)
+								log.Errorf("Skipping frontend %s...", frontendName)
+								continue frontend
+							}
+							log.Debugf("Creating load-balancer connlimit")
+							lb, err = connlimit.New(lb, extractFunc, maxConns.Amount)
+							handlerNames = append(handlerNames, "connection limit")
+							lb = s.wrapHTTPHandlerWithAccessLog(lb, fmt.Sprintf("connection limit for %s", frontendName))
+							if err != nil {
+								log.Errorf("Error creating connlimit: %v", err)
+								log.Errorf("Skipping frontend %s...", frontendName)
+								continue frontend
+							}
+						}
+
+						if globalConfiguration.Retry != nil || config.Backends[frontend.Backend].Retry != nil {
+							retryPolicy := parseRetryPolicy(frontend.Backend, config.Backends[frontend.Backend], globalConfiguration.Retry)
+							lb = s.buildRetryMiddleware(lb, retryPolicy, frontend.Backend)
+							handlerNames = append(handlerNames, "retry")
+						}
+
+						if s.metricsRegistry.IsEnabled() {
+							n.Use(middlewares.NewBackendMetricsMiddleware(s.metricsRegistry, frontend.Backend))
+							middlewareNames = append(middlewareNames, "metrics")
+						}
+
+						ipWhitelistMiddleware, err := configureIPWhitelistMiddleware(frontend.WhitelistSourceRange)
 						if err != nil {
-							log.Errorf("Error setting up buffering middleware: %s", err)
+							log.Errorf("Error creating IP Whitelister: %s", err)
+						} else if ipWhitelistMiddleware != nil {
+							ipWhitelistMiddleware = s.wrapNegroniHandlerWithAccessLog(ipWhitelistMiddleware, fmt.Sprintf("ipwhitelister for %s", frontendName))
+							n.Use(s.tracingMiddleware.NewNegroniHandlerWrapper("IP whitelist", ipWhitelistMiddleware, false))
+							middlewareNames = append(middlewareNames, "IP whitelist")
+							log.Infof("Configured IP Whitelists: %s", frontend.WhitelistSourceRange)
+						}
+
+						if frontend.Redirect != nil {
+							rewrite, err := s.buildRedirectHandler(entryPointName, frontend.Redirect)
+							if err != nil {
+								log.Errorf("Error creating Frontend Redirect: %v", err)
+							} else {
+								n.Use(s.wrapNegroniHandlerWithAccessLog(rewrite, fmt.Sprintf("frontend redirect for %s", frontendName)))
+								middlewareNames = append(middlewareNames, "frontend redirect")
+								log.Debugf("Frontend %s redirect created", frontendName)
+							}
+						}
+
+						if len(frontend.BasicAuth) > 0 {
+							users := types.Users{}
+							for _, user := range frontend.BasicAuth {
+								users = append(users, user)
+							}
+
+							auth := &types.Auth{}
+							auth.Basic = &types.Basic{
+								Users: users,
+							}
+							authMiddleware, err := mauth.NewAuthenticator(auth, s.tracingMiddleware)
+							if err != nil {
+								log.Errorf("Error creating Auth: %s", err)
+							} else {
+								n.Use(s.wrapNegroniHandlerWithAccessLog(authMiddleware, fmt.Sprintf("Auth for %s", frontendName)))
+								middlewareNames = append(middlewareNames, "basic auth")
+							}
+						}
+
+						if headerMiddleware != nil {
+							log.Debugf("Adding header middleware for frontend %s", frontendName)
+							n.Use(s.tracingMiddleware.NewNegroniHandlerWrapper("Header", headerMiddleware, false))
+							middlewareNames = append(middlewareNames, "headers")
+						}
+
+						secureMiddleware := middlewares.NewSecure(frontend.Headers)
+						if secureMiddleware != nil {
+							log.Debugf("Adding secure middleware for frontend %s", frontendName)
+							n.UseFunc(secureMiddleware.HandlerFuncWithNext)
+							middlewareNames = append(middlewareNames, "secure headers")
+						}
+
+						if config.Backends[frontend.Backend].Buffering != nil {
+							bufferedLb, err := s.buildBufferingMiddleware(lb, config.Backends[frontend.Backend].Buffering)
+
+							if err != nil {
+								log.Errorf("Error setting up buffering middleware: %s", err)
+							} else {
+								lb = bufferedLb
+								handlerNames = append(handlerNames, "buffering")
+							}
+						}
+
+						if config.Backends[frontend.Backend].CircuitBreaker != nil {
+							log.Debugf("Creating circuit breaker %s", config.Backends[frontend.Backend].CircuitBreaker.Expression)
+							expression := config.Backends[frontend.Backend].CircuitBreaker.Expression
+							circuitBreaker, err := middlewares.NewCircuitBreaker(lb, expression, middlewares.NewCircuitBreakerOptions(expression))
+							if err != nil {
+								log.Errorf("Error creating circuit breaker: %v", err)
+								log.Errorf("Skipping frontend %s...", frontendName)
+								continue frontend
+							}
+							n.Use(s.tracingMiddleware.NewNegroniHandlerWrapper("Circuit breaker", circuitBreaker, false))
+							middlewareNames = append(middlewareNames, "circuit breaker")
 						} else {
-							lb = bufferedLb
+							n.UseHandler(lb)
 						}
-					}
+						backends[entryPointName+frontend.Backend] = n
 
-					if config.Backends[frontend.Backend].CircuitBreaker != nil {
-						log.Debugf("Creating circuit breaker %s", config.Backends[frontend.Backend].CircuitBreaker.Expression)
-						expression := config.Backends[frontend.Backend].CircuitBreaker.Expression
-						circuitBreaker, err := middlewares.NewCircuitBreaker(lb, expression, middlewares.NewCircuitBreakerOptions(expression))
-						if err != nil {
-							log.Errorf("Error creating circuit breaker: %v", err)
-							log.Errorf("Skipping frontend %s...", frontendName)
-							continue frontend
+						for i := len(handlerNames) - 1; i >= 0; i-- {
+							middlewareNames = append(middlewareNames, handlerNames[i])
 						}
-						n.Use(s.tracingMiddleware.NewNegroniHandlerWrapper("Circuit breaker", circuitBreaker, false))
+						backendsMiddlewares[entryPointName+frontend.Backend] = middlewareNames
 					} else {
-						n.UseHandler(lb)
+						log.Debugf("Reusing backend %s", frontend.Backend)
+					}
+				}
+
+				backendName := frontend.Backend
+				handler := backends[entryPointName+frontend.Backend]
+				backendMiddlewares := backendsMiddlewares[entryPointName+frontend.Backend]
+				if frontend.Split != nil {
+					backendName = strings.Join(frontendBackendNames(frontend), ", ")
+					handler = buildTrafficSplitter(frontendName, frontend.Split, backends, entryPointName)
+					backendMiddlewares = []string{"traffic split"}
+				}
+				if len(fallbackBackend) > 0 {
+					log.Debugf("Falling back to backend %s for frontend %s", fallbackBackend, frontendName)
+					handler = middlewares.NewFallback(handler, backends[entryPointName+fallbackBackend])
+					backendMiddlewares = append([]string{fmt.Sprintf("fallback %s", fallbackBackend)}, backendMiddlewares...)
+				}
+				if mirror != nil {
+					handler = buildMirror(frontendName, handler, mirror, backends[entryPointName+mirror.Backend])
+					backendMiddlewares = append([]string{fmt.Sprintf("mirror %s", mirror.Backend)}, backendMiddlewares...)
+				}
+				if frontend.Timeout != nil {
+					timeout, err := time.ParseDuration(frontend.Timeout.Request)
+					if err != nil || timeout <= 0 {
+						log.Errorf("Illegal request timeout %q for frontend %s, the requests are not limited", frontend.Timeout.Request, frontendName)
+					} else {
+						handler = middlewares.NewTimeout(timeout, frontend.Timeout.DeadlineHeader, handler)
+						backendMiddlewares = append([]string{fmt.Sprintf("timeout %s", timeout)}, backendMiddlewares...)
 					}
-					backends[entryPointName+frontend.Backend] = n
-				} else {
-					log.Debugf("Reusing backend %s", frontend.Backend)
 				}
 				if frontend.Priority > 0 {
 					newServerRoute.route.Priority(frontend.Priority)
 				}
-				s.wireFrontendBackend(newServerRoute, backends[entryPointName+frontend.Backend])
+				s.wireFrontendBackend(newServerRoute, handler)
+
+				rules := make(map[string]string)
+				for routeName, route := range frontend.Routes {
+					rules[routeName] = route.Rule
+				}
+				serverEntryPoints[entryPointName].routingTable.Get().(*routingTable).frontends[frontendName] = &frontendRouting{
+					backend:     backendName,
+					priority:    newServerRoute.route.GetPriority(),
+					rules:       rules,
+					middlewares: append(newServerRoute.modifiers(), backendMiddlewares...),
+				}
 
 				err := newServerRoute.route.GetError()
 				if err != nil {
@@ -1195,6 +1695,14 @@ func (s *Server) loadConfig(configurations types.Configurations, globalConfigura
 	//sort routes and update certificates
 	for serverEntryPointName, serverEntryPoint := range serverEntryPoints {
 		serverEntryPoint.httpRouter.GetHandler().SortRoutes()
+		if serverEntryPoint.tcpRouter != nil {
+			serverEntryPoint.tcpRouter.GetHandler().SortRoutes()
+		}
+		table := serverEntryPoint.routingTable.Get().(*routingTable)
+		table.conflicts = detectConflicts(serverEntryPointName, table)
+		for _, conflict := range table.conflicts {
+			log.Warnf("Conflicting frontends on entry point %s: %s", serverEntryPointName, conflict.Description)
+		}
 		_, exists := entryPointsCertificates[serverEntryPointName]
 		if exists {
 			serverEntryPoint.certs.Set(entryPointsCertificates[serverEntryPointName])
@@ -1309,8 +1817,178 @@ func (s *Server) buildDefaultHTTPRouter() *mux.Router {
 	return router
 }
 
+func newConsistentHash(next http.Handler, config *types.ConsistentHash, forwardedHeaders *configuration.ForwardedHeaders) (*loadbalancer.ConsistentHash, error) {
+	key := loadbalancer.DefaultHashKey
+	var loadFactor float64
+	if config != nil {
+		if config.Key != "" {
+			key = config.Key
+		}
+		loadFactor = config.LoadFactor
+	}
+
+	clientIP := &whitelist.ClientIP{}
+	if forwardedHeaders != nil {
+		var err error
+		clientIP, err = whitelist.NewClientIP(forwardedHeaders.TrustedIPs, forwardedHeaders.Insecure)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	keyFunc, err := loadbalancer.NewHashKeyFunc(key, clientIP)
+	if err != nil {
+		return nil, err
+	}
+	return loadbalancer.NewConsistentHash(next, keyFunc, loadFactor)
+}
+
+// loadBalancer is a load-balancer forwarding the requests to its servers.
+type loadBalancer interface {
+	http.Handler
+	healthcheck.LoadBalancer
+}
+
+// newLoadBalancer creates the load-balancer of the method, forwarding the requests to next.
+// The forwarded headers of the entry point resolve the client IP the hash load-balancer hashes the requests by.
+func newLoadBalancer(lbMethod types.LoadBalancerMethod, next http.Handler, sticky *roundrobin.StickySession, hash *types.ConsistentHash, forwardedHeaders *configuration.ForwardedHeaders) (loadBalancer, error) {
+	switch lbMethod {
+	case types.Drr:
+		rr, err := roundrobin.New(next)
+		if err != nil {
+			return nil, err
+		}
+		if sticky != nil {
+			return roundrobin.NewRebalancer(rr, roundrobin.RebalancerStickySession(sticky))
+		}
+		return roundrobin.NewRebalancer(rr)
+	case types.Wrr:
+		if sticky != nil {
+			return roundrobin.New(next, roundrobin.EnableStickySession(sticky))
+		}
+		return roundrobin.New(next)
+	case types.LeastConn:
+		return loadbalancer.NewLeastConn(next, sticky), nil
+	case types.P2C:
+		return loadbalancer.NewP2C(next, sticky), nil
+	case types.Hash:
+		return newConsistentHash(next, hash, forwardedHeaders)
+	}
+	return nil, fmt.Errorf("unknown load-balancer method %d", lbMethod)
+}
+
+// buildBalancer wraps the load-balancer of a backend with its slow start,
+// and with the failover between the priority groups of its servers if any.
+// The servers of the backend are added, and removed by the health checks, through the returned load-balancer.
+func (s *Server) buildBalancer(lb healthcheck.LoadBalancer, backendName string, backend *types.Backend, lbMethod types.LoadBalancerMethod, zone string) healthcheck.LoadBalancer {
+	slowStart := s.buildSlowStart(lb, backendName, backend.LoadBalancer, lbMethod)
+
+	groups := failoverGroups(backend.Servers, zone)
+	if groups == nil {
+		return slowStart
+	}
+
+	threshold := 70
+	if backend.LoadBalancer != nil && backend.LoadBalancer.FailoverThreshold > 0 {
+		threshold = backend.LoadBalancer.FailoverThreshold
+	}
+	log.Debugf("Failover between the priority groups of the servers of backend %s, with a threshold of %d%%", backendName, threshold)
+	return loadbalancer.NewFailover(slowStart, backendName, groups, threshold)
+}
+
+// failoverGroups returns the priority groups of the servers by URL, or nil if all the servers are in the same group.
+// The servers are grouped by priority, and then by whether they are in the given zone,
+// a server without a zone being considered in the zone.
+func failoverGroups(servers map[string]types.Server, zone string) map[string]int {
+	groups := make(map[string]int)
+	distinct := make(map[int]bool)
+	for _, server := range servers {
+		u, err := url.Parse(server.URL)
+		if err != nil {
+			continue
+		}
+		group := 2 * server.Priority
+		if len(zone) > 0 && len(server.Zone) > 0 && server.Zone != zone {
+			group++
+		}
+		groups[u.String()] = group
+		distinct[group] = true
+	}
+	if len(distinct) < 2 {
+		return nil
+	}
+	return groups
+}
+
+// configuredServers returns the URLs of the servers of the backends of the configurations, by backend name.
+func configuredServers(configurations types.Configurations) map[string][]*url.URL {
+	servers := make(map[string][]*url.URL)
+	for _, config := range configurations {
+		if config == nil {
+			continue
+		}
+		for backendName, backend := range config.Backends {
+			if backend == nil {
+				continue
+			}
+			for _, server := range backend.Servers {
+				u, err := url.Parse(server.URL)
+				if err != nil {
+					continue
+				}
+				servers[backendName] = append(servers[backendName], u)
+			}
+		}
+	}
+	return servers
+}
+
+// buildSlowStart wraps the load-balancer of a backend, to restore the weight of the servers returning from a health check failure,
+// and to ramp up the weight of the new servers over the slow start window of the backend.
+func (s *Server) buildSlowStart(lb healthcheck.LoadBalancer, backendName string, loadBalancer *types.LoadBalancer, lbMethod types.LoadBalancerMethod) *loadbalancer.SlowStart {
+	var window time.Duration
+	if loadBalancer != nil && len(loadBalancer.SlowStart) > 0 {
+		var err error
+		window, err = time.ParseDuration(loadBalancer.SlowStart)
+		switch {
+		case err != nil:
+			log.Errorf("Invalid slow start for backend %s, servers are not slowly started: %v", backendName, err)
+			window = 0
+		case lbMethod == types.Hash:
+			// ramping up the weights would move the keys of the servers several times
+			log.Errorf("Slow start is not supported by the hash load-balancing method of backend %s, servers are not slowly started", backendName)
+			window = 0
+		case window > 0:
+			log.Debugf("Slow start of the servers of backend %s over %s", backendName, window)
+		}
+	}
+	return loadbalancer.NewSlowStart(lb, backendName, window, s.warmUps)
+}
+
+// addBackendHealthCheck adds the health check of a backend to the health checks of the configuration, keyed by backend:
+// a backend used on several entry points has a load-balancer per entry point, but its servers are checked once for all of them.
+func addBackendHealthCheck(backendsHealthCheck map[string]*healthcheck.BackendHealthCheck, backendName string, backendHealthCheck *healthcheck.BackendHealthCheck) {
+	if existing, ok := backendsHealthCheck[backendName]; ok {
+		existing.AddLoadBalancer(backendHealthCheck.LB)
+		return
+	}
+	backendsHealthCheck[backendName] = backendHealthCheck
+}
+
 func parseHealthCheckOptions(lb healthcheck.LoadBalancer, backend string, hc *types.HealthCheck, hcConfig *configuration.HealthCheckConfig) *healthcheck.Options {
-	if hc == nil || hc.Path == "" || hcConfig == nil {
+	if hc == nil || hcConfig == nil {
+		return nil
+	}
+
+	hcType := strings.ToLower(hc.Type)
+	switch hcType {
+	case "", healthcheck.TypeHTTP:
+		if hc.Path == "" {
+			return nil
+		}
+	case healthcheck.TypeTCP, healthcheck.TypeGRPC:
+	default:
+		log.Errorf("Illegal healthcheck type for backend '%s': %q", backend, hc.Type)
 		return nil
 	}
 
@@ -1327,16 +2005,98 @@ func parseHealthCheckOptions(lb healthcheck.LoadBalancer, backend string, hc *ty
 		}
 	}
 
-	return &healthcheck.Options{
-		Path:     hc.Path,
-		Port:     hc.Port,
-		Interval: interval,
-		LB:       lb,
+	options := &healthcheck.Options{
+		Type:        hcType,
+		Path:        hc.Path,
+		Port:        hc.Port,
+		Interval:    interval,
+		LB:          lb,
+		Scheme:      hc.Scheme,
+		Method:      strings.ToUpper(hc.Method),
+		Hostname:    hc.Hostname,
+		Headers:     hc.Headers,
+		Rise:        hc.Rise,
+		Fall:        hc.Fall,
+		GRPCService: hc.GRPCService,
+	}
+
+	if hc.Timeout != "" {
+		timeout, err := time.ParseDuration(hc.Timeout)
+		if err != nil || timeout <= 0 {
+			log.Errorf("Illegal healthcheck timeout for backend '%s': %q", backend, hc.Timeout)
+		} else {
+			options.Timeout = timeout
+		}
+	}
+
+	statusCodeRanges, err := middlewares.ParseHTTPCodeRanges(hc.StatusCodes)
+	if err != nil {
+		log.Errorf("Illegal healthcheck status codes for backend '%s': %s", backend, err)
+	} else {
+		options.StatusCodeRanges = statusCodeRanges
+	}
+
+	if hc.Body != "" {
+		body, err := regexp.Compile(hc.Body)
+		if err != nil {
+			log.Errorf("Illegal healthcheck body for backend '%s': %s", backend, err)
+		} else {
+			options.Body = body
+		}
+	}
+
+	return options
+}
+
+func parseOutlierOptions(backendName string, backend *types.Backend) *healthcheck.OutlierOptions {
+	if backend == nil || backend.OutlierDetection == nil {
+		return nil
+	}
+	od := backend.OutlierDetection
+
+	options := &healthcheck.OutlierOptions{
+		ConsecutiveErrors:  5,
+		BaseEjectionTime:   30 * time.Second,
+		MaxEjectionTime:    300 * time.Second,
+		MaxEjectionPercent: 10,
+	}
+	if od.ConsecutiveErrors > 0 {
+		options.ConsecutiveErrors = od.ConsecutiveErrors
 	}
+	if od.MaxEjectionPercent > 0 {
+		options.MaxEjectionPercent = od.MaxEjectionPercent
+	}
+	if od.BaseEjectionTime != "" {
+		baseEjectionTime, err := time.ParseDuration(od.BaseEjectionTime)
+		switch {
+		case err != nil:
+			log.Errorf("Illegal outlier detection base ejection time for backend '%s': %s", backendName, err)
+		case baseEjectionTime <= 0:
+			log.Errorf("Outlier detection base ejection time smaller than zero for backend '%s'", backendName)
+		default:
+			options.BaseEjectionTime = baseEjectionTime
+		}
+	}
+	if od.MaxEjectionTime != "" {
+		maxEjectionTime, err := time.ParseDuration(od.MaxEjectionTime)
+		switch {
+		case err != nil:
+			log.Errorf("Illegal outlier detection max ejection time for backend '%s': %s", backendName, err)
+		case maxEjectionTime <= 0:
+			log.Errorf("Outlier detection max ejection time smaller than zero for backend '%s'", backendName)
+		default:
+			options.MaxEjectionTime = maxEjectionTime
+		}
+	}
+	if options.MaxEjectionTime < options.BaseEjectionTime {
+		options.MaxEjectionTime = options.BaseEjectionTime
+	}
+
+	return options
 }
 
-func getRoute(serverRoute *serverRoute, route *types.Route) error {
-	rules := Rules{route: serverRoute}
+func getRoute(serverRoute *serverRoute, route *types.Route, forwardedHeaders *configuration.ForwardedHeaders) error {
+	rules := Rules{route: serverRoute, forwardedHeaders: forwardedHeaders}
 	newRoute, err := rules.Parse(route.Rule)
 	if err != nil {
 		return err
@@ -1346,6 +2106,123 @@ func getRoute(serverRoute *serverRoute, route *types.Route) error {
 	return nil
 }
 
+// frontendBackendNames returns the names of the backends of a frontend, the backend of its traffic split if any.
+func frontendBackendNames(frontend *types.Frontend) []string {
+	if frontend.Split == nil {
+		return []string{frontend.Backend}
+	}
+	var names []string
+	for _, backend := range frontend.Split.Backends {
+		names = append(names, backend.Backend)
+	}
+	return names
+}
+
+// frontendWithBackend returns a copy of a frontend with the given backend.
+func frontendWithBackend(frontend *types.Frontend, backendName string) *types.Frontend {
+	copied := *frontend
+	copied.Backend = backendName
+	return &copied
+}
+
+func checkTrafficSplit(split *types.TrafficSplit) error {
+	if split == nil {
+		return nil
+	}
+	if len(split.Backends) == 0 {
+		return errors.New("no backend")
+	}
+	total := 0
+	for _, backend := range split.Backends {
+		if backend.Weight < 0 {
+			return fmt.Errorf("negative weight for backend %s", backend.Backend)
+		}
+		total += backend.Weight
+	}
+	if total <= 0 {
+		return errors.New("null total weight")
+	}
+	return nil
+}
+
+func buildTrafficSplitter(frontendName string, split *types.TrafficSplit, backends map[string]http.Handler, entryPointName string) http.Handler {
+	var cookieName string
+	var cookieOptions cookie.Options
+	if split.Stickiness != nil {
+		cookieName = cookie.GetName(split.Stickiness.CookieName, frontendName)
+		cookieOptions = stickyCookieOptions(split.Stickiness, frontendName)
+		log.Debugf("Sticky traffic split for frontend %s, with cookie %s", frontendName, cookieName)
+	}
+	splitter := middlewares.NewTrafficSplitter(cookieName, cookieOptions)
+	for _, backend := range split.Backends {
+		log.Debugf("Splitting traffic of frontend %s to backend %s with weight %d", frontendName, backend.Backend, backend.Weight)
+		splitter.AddBackend(backend.Backend, backend.Weight, backends[entryPointName+backend.Backend])
+	}
+	return splitter
+}
+
+func stickyCookieOptions(stickiness *types.Stickiness, frontendName string) cookie.Options {
+	if !cookie.ValidSameSite(stickiness.SameSite) {
+		log.Errorf("Invalid SameSite attribute %q for the sticky cookie of frontend %s, ignoring it", stickiness.SameSite, frontendName)
+	} else if strings.EqualFold(stickiness.SameSite, "none") && !stickiness.Secure {
+		log.Warnf("The sticky cookie of frontend %s has the SameSite=None attribute without the Secure one, browsers may reject it", frontendName)
+	}
+	return cookie.Options{
+		Secret:   stickiness.Secret,
+		Secure:   stickiness.Secure,
+		HTTPOnly: stickiness.HTTPOnly,
+		SameSite: stickiness.SameSite,
+		MaxAge:   stickiness.MaxAge,
+		Domain:   stickiness.Domain,
+		Path:     stickiness.Path,
+	}
+}
+
+func checkMirror(mirror *types.Mirror, config *types.Configuration) error {
+	if mirror == nil {
+		return nil
+	}
+	backend := config.Backends[mirror.Backend]
+	if backend == nil {
+		return fmt.Errorf("undefined backend '%s'", mirror.Backend)
+	}
+	if mirror.Percent < 0 || mirror.Percent > 100 {
+		return fmt.Errorf("percent %d is not between 0 and 100", mirror.Percent)
+	}
+	if _, err := types.NewLoadBalancerMethod(backend.LoadBalancer); err != nil {
+		return fmt.Errorf("backend %s: %v", mirror.Backend, err)
+	}
+	return nil
+}
+
+func checkFallbackBackend(frontend *types.Frontend, config *types.Configuration) error {
+	if len(frontend.FallbackBackend) == 0 {
+		return nil
+	}
+	backend := config.Backends[frontend.FallbackBackend]
+	if backend == nil {
+		return fmt.Errorf("undefined backend '%s'", frontend.FallbackBackend)
+	}
+	for _, backendName := range frontendBackendNames(frontend) {
+		if backendName == frontend.FallbackBackend {
+			return fmt.Errorf("backend %s is a backend of the frontend", frontend.FallbackBackend)
+		}
+	}
+	if _, err := types.NewLoadBalancerMethod(backend.LoadBalancer); err != nil {
+		return fmt.Errorf("backend %s: %v", frontend.FallbackBackend, err)
+	}
+	return nil
+}
+
+func buildMirror(frontendName string, handler http.Handler, mirror *types.Mirror, mirrorHandler http.Handler) http.Handler {
+	percent := mirror.Percent
+	if percent == 0 {
+		percent = 100
+	}
+	log.Debugf("Mirroring %d%% of the requests of frontend %s to backend %s", percent, frontendName, mirror.Backend)
+	return mirroring.NewHandler(handler, mirrorHandler, percent, mirror.MaxBodySize)
+}
+
 func sortedFrontendNamesForConfig(configuration *types.Configuration) []string {
 	var keys []string
 	for key := range configuration.Frontends {
@@ -1371,13 +2248,18 @@ func configureBackends(backends map[string]*types.Backend) {
 			log.Warnf("Deprecated configuration found: %s. Please use %s.", "backend.LoadBalancer.Sticky", "backend.LoadBalancer.Stickiness")
 		}
 
-		_, err := types.NewLoadBalancerMethod(backend.LoadBalancer)
+		lbMethod, err := types.NewLoadBalancerMethod(backend.LoadBalancer)
 		if err == nil {
 			if backend.LoadBalancer != nil && backend.LoadBalancer.Stickiness == nil && backend.LoadBalancer.Sticky {
 				backend.LoadBalancer.Stickiness = &types.Stickiness{
 					CookieName: "_TRAEFIK_BACKEND",
 				}
 			}
+			// the hash load-balancer already forwards the requests with the same key to the same server
+			if lbMethod == types.Hash && backend.LoadBalancer.Stickiness != nil {
+				log.Errorf("Stickiness is not supported by the hash load-balancing method of backend %s, ignoring it.", backendName)
+				backend.LoadBalancer.Stickiness = nil
+			}
 		} else {
 			log.Debugf("Validation of load balancer method for backend %s failed: %s. Using default method wrr.", backendName, err)
 
@@ -1450,7 +2332,7 @@ func (s *Server) buildRateLimiter(handler http.Handler, rlConfig *types.RateLimi
 
 }
 
-func (s *Server) buildRetryMiddleware(handler http.Handler, globalConfig configuration.GlobalConfiguration, countServers int, backendName string) http.Handler {
+func (s *Server) buildRetryMiddleware(handler http.Handler, retryPolicy middlewares.RetryPolicy, backendName string) http.Handler {
 	retryListeners := middlewares.RetryListeners{}
 	if s.metricsRegistry.IsEnabled() {
 		retryListeners = append(retryListeners, middlewares.NewMetricsRetryListener(s.metricsRegistry, backendName))
@@ -1459,15 +2341,110 @@ func (s *Server) buildRetryMiddleware(handler http.Handler, globalConfig configu
 		retryListeners = append(retryListeners, &accesslog.SaveRetries{})
 	}
 
-	retryAttempts := countServers
-	if globalConfig.Retry.Attempts > 0 {
-		retryAttempts = globalConfig.Retry.Attempts
+	log.Debugf("Creating retries max attempts %d", retryPolicy.Attempts)
+
+	return s.tracingMiddleware.NewHTTPHandlerWrapper("Retry", middlewares.NewRetryWithPolicy(retryPolicy, handler, retryListeners), false)
+}
+
+func (s *Server) buildHedgingMiddleware(handler http.Handler, hedgingPolicy middlewares.HedgingPolicy, backendName string) *middlewares.Hedging {
+	hedgeListeners := middlewares.HedgeListeners{}
+	if s.metricsRegistry.IsEnabled() {
+		hedgeListeners = append(hedgeListeners, middlewares.NewMetricsHedgeListener(s.metricsRegistry, backendName))
 	}
 
-	log.Debugf("Creating retries max attempts %d", retryAttempts)
+	log.Debugf("Creating request hedging after %s, percentile %d", hedgingPolicy.Delay, hedgingPolicy.Percentile)
 
-	return s.tracingMiddleware.NewHTTPHandlerWrapper("Retry", middlewares.NewRetry(retryAttempts, handler, retryListeners), false)
+	return middlewares.NewHedging(hedgingPolicy, handler, hedgeListeners)
 }
+
+// parseHedgingPolicy returns the request hedging policy of a backend, if any.
+// The delay defaults to 100ms when only the percentile is set.
+func parseHedgingPolicy(backendName string, backend *types.Backend) *middlewares.HedgingPolicy {
+	if backend == nil || backend.Hedging == nil {
+		return nil
+	}
+	hedging := backend.Hedging
+
+	policy := &middlewares.HedgingPolicy{Delay: 100 * time.Millisecond}
+	if hedging.Percentile > 0 && hedging.Percentile < 100 {
+		policy.Percentile = hedging.Percentile
+	} else if hedging.Percentile != 0 {
+		log.Errorf("Illegal hedging percentile for backend '%s': %d", backendName, hedging.Percentile)
+	}
+
+	if hedging.Delay != "" {
+		delay, err := time.ParseDuration(hedging.Delay)
+		if err != nil || delay <= 0 {
+			log.Errorf("Illegal hedging delay for backend '%s': %q", backendName, hedging.Delay)
+		} else {
+			return &middlewares.HedgingPolicy{Delay: delay, Percentile: policy.Percentile}
+		}
+	}
+
+	if policy.Percentile == 0 {
+		log.Errorf("No hedging delay nor percentile for backend '%s', the requests are not hedged", backendName)
+		return nil
+	}
+	return policy
+}
+
+// parseRetryPolicy returns the retry policy of a backend: its own policy, or the global one.
+// By default, the requests are attempted once per server of the backend.
+func parseRetryPolicy(backendName string, backend *types.Backend, globalRetry *configuration.Retry) middlewares.RetryPolicy {
+	policy := middlewares.RetryPolicy{Attempts: len(backend.Servers)}
+	if globalRetry != nil && globalRetry.Attempts > 0 {
+		policy.Attempts = globalRetry.Attempts
+	}
+
+	retry := backend.Retry
+	if retry == nil {
+		return policy
+	}
+
+	if retry.Attempts > 0 {
+		policy.Attempts = retry.Attempts
+	}
+	policy.IdempotentOnly = retry.IdempotentOnly
+
+	statusCodeRanges, err := middlewares.ParseHTTPCodeRanges(retry.StatusCodes)
+	if err != nil {
+		log.Errorf("Illegal retry status codes for backend '%s': %s", backendName, err)
+	} else {
+		policy.StatusCodeRanges = statusCodeRanges
+	}
+
+	if retry.PerTryTimeout != "" {
+		perTryTimeout, err := time.ParseDuration(retry.PerTryTimeout)
+		if err != nil {
+			log.Errorf("Illegal retry per try timeout for backend '%s': %s", backendName, err)
+		} else {
+			policy.PerTryTimeout = perTryTimeout
+		}
+	}
+	if retry.Backoff != "" {
+		backoff, err := time.ParseDuration(retry.Backoff)
+		if err != nil {
+			log.Errorf("Illegal retry backoff for backend '%s': %s", backendName, err)
+		} else {
+			policy.Backoff = backoff
+		}
+	}
+	if retry.MaxBackoff != "" {
+		maxBackoff, err := time.ParseDuration(retry.MaxBackoff)
+		if err != nil {
+			log.Errorf("Illegal retry max backoff for backend '%s': %s", backendName, err)
+		} else {
+			policy.MaxBackoff = maxBackoff
+		}
+	}
+
+	if retry.BudgetPercent > 0 {
+		policy.Budget = middlewares.NewRetryBudget(retry.BudgetPercent)
+	}
+
+	return policy
+}
+
 func (s *Server) wrapNegroniHandlerWithAccessLog(handler negroni.Handler, frontendName string) negroni.Handler {
 	if s.accessLoggerMiddleware != nil {
 		saveBackend := accesslog.NewSaveNegroniBackend(handler, "Træfik")
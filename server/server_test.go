@@ -1,11 +1,18 @@
 package server
 
 import (
+	cryptotls "crypto/tls"
 	"fmt"
+	"io/ioutil"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
 	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -23,6 +30,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/urfave/negroni"
 	"github.com/vulcand/oxy/roundrobin"
+	"golang.org/x/net/http2"
 )
 
 // LocalhostCert is a PEM-encoded TLS cert with SAN IPs
@@ -430,7 +438,7 @@ func TestServerLoadConfigHealthCheckOptions(t *testing.T) {
 		},
 	}
 
-	for _, lbMethod := range []string{"Wrr", "Drr"} {
+	for _, lbMethod := range []string{"Wrr", "Drr", "LeastConn", "Hash", "P2C"} {
 		for _, healthCheck := range healthChecks {
 			t.Run(fmt.Sprintf("%s/hc=%t", lbMethod, healthCheck != nil), func(t *testing.T) {
 				globalConfig := configuration.GlobalConfiguration{
@@ -550,6 +558,72 @@ func TestServerParseHealthCheckOptions(t *testing.T) {
 				LB:       lb,
 			},
 		},
+		{
+			desc: "request and response settings",
+			hc: &types.HealthCheck{
+				Path:        "/path",
+				Timeout:     "3s",
+				Scheme:      "https",
+				Method:      "head",
+				Hostname:    "foo.localhost",
+				Headers:     map[string]string{"X-Health": "1"},
+				StatusCodes: []string{"200-299", "304"},
+				Body:        "^ok$",
+				Rise:        3,
+				Fall:        2,
+			},
+			wantOpts: &healthcheck.Options{
+				Path:             "/path",
+				Interval:         globalInterval,
+				LB:               lb,
+				Timeout:          3 * time.Second,
+				Scheme:           "https",
+				Method:           http.MethodHead,
+				Hostname:         "foo.localhost",
+				Headers:          map[string]string{"X-Health": "1"},
+				StatusCodeRanges: [][2]int{{200, 299}, {304, 304}},
+				Body:             regexp.MustCompile("^ok$"),
+				Rise:             3,
+				Fall:             2,
+			},
+		},
+		{
+			desc: "illegal request and response settings",
+			hc: &types.HealthCheck{
+				Path:        "/path",
+				Timeout:     "foo",
+				StatusCodes: []string{"2xx"},
+				Body:        "(",
+			},
+			wantOpts: &healthcheck.Options{
+				Path:     "/path",
+				Interval: globalInterval,
+				LB:       lb,
+			},
+		},
+		{
+			desc: "grpc health check without path",
+			hc: &types.HealthCheck{
+				Type:        "GRPC",
+				Port:        9090,
+				GRPCService: "foo.Bar",
+			},
+			wantOpts: &healthcheck.Options{
+				Type:        healthcheck.TypeGRPC,
+				Port:        9090,
+				Interval:    globalInterval,
+				LB:          lb,
+				GRPCService: "foo.Bar",
+			},
+		},
+		{
+			desc: "illegal type",
+			hc: &types.HealthCheck{
+				Type: "udp",
+				Path: "/path",
+			},
+			wantOpts: nil,
+		},
 	}
 
 	for _, test := range tests {
@@ -565,6 +639,80 @@ func TestServerParseHealthCheckOptions(t *testing.T) {
 	}
 }
 
+func TestFailoverGroups(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		servers  map[string]types.Server
+		zone     string
+		expected map[string]int
+	}{
+		{
+			desc: "no zone nor priority",
+			servers: map[string]types.Server{
+				"a": {URL: "http://a"},
+				"b": {URL: "http://b"},
+			},
+			zone: "zone-a",
+		},
+		{
+			desc: "no zone of the instance",
+			servers: map[string]types.Server{
+				"a": {URL: "http://a", Zone: "zone-a"},
+				"b": {URL: "http://b", Zone: "zone-b"},
+			},
+		},
+		{
+			desc: "zones",
+			servers: map[string]types.Server{
+				"a": {URL: "http://a", Zone: "zone-a"},
+				"b": {URL: "http://b", Zone: "zone-b"},
+				"c": {URL: "http://c"},
+			},
+			zone:     "zone-a",
+			expected: map[string]int{"http://a": 0, "http://b": 1, "http://c": 0},
+		},
+		{
+			desc: "priorities and zones",
+			servers: map[string]types.Server{
+				"a": {URL: "http://a", Zone: "zone-a", Priority: 1},
+				"b": {URL: "http://b", Zone: "zone-b", Priority: 1},
+				"c": {URL: "http://c", Zone: "zone-b"},
+			},
+			zone:     "zone-a",
+			expected: map[string]int{"http://a": 2, "http://b": 3, "http://c": 1},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, failoverGroups(test.servers, test.zone))
+		})
+	}
+}
+
+func TestConfiguredServers(t *testing.T) {
+	configurations := types.Configurations{
+		"file": buildDynamicConfig(
+			withBackend("backend1", buildBackend(withServer("server1", "http://a"))),
+			withBackend("backend2", buildBackend()),
+		),
+		"docker": buildDynamicConfig(
+			withBackend("backend3", buildBackend(withServer("server1", "http://b"))),
+		),
+		"empty": nil,
+	}
+
+	servers := configuredServers(configurations)
+
+	assert.Equal(t, map[string][]*url.URL{
+		"backend1": {testhelpers.MustParseURL("http://a")},
+		"backend3": {testhelpers.MustParseURL("http://b")},
+	}, servers)
+}
+
 func TestNewServerWithWhitelistSourceRange(t *testing.T) {
 	cases := []struct {
 		desc                 string
@@ -710,6 +858,14 @@ func TestConfigureBackends(t *testing.T) {
 			},
 			wantMethod: validMethod,
 		},
+		{
+			desc: "hash load balancer method with sticky enabled",
+			lb: &types.LoadBalancer{
+				Method:     "Hash",
+				Stickiness: &types.Stickiness{},
+			},
+			wantMethod: "Hash",
+		},
 		{
 			desc: "invalid load balancer method with sticky enabled",
 			lb: &types.LoadBalancer{
@@ -838,6 +994,46 @@ func TestServerResponseEmptyBackend(t *testing.T) {
 			},
 			wantStatusCode: http.StatusOK,
 		},
+		{
+			desc: "Ok LB-LeastConn",
+			dynamicConfig: func(testServerURL string) *types.Configuration {
+				return buildDynamicConfig(
+					withFrontend("frontend", buildFrontend(withRoute(requestPath, routeRule))),
+					withBackend("backend", buildBackend(withServer("testServer", testServerURL), withLoadBalancer("LeastConn", true))),
+				)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			desc: "Ok LB-P2C",
+			dynamicConfig: func(testServerURL string) *types.Configuration {
+				return buildDynamicConfig(
+					withFrontend("frontend", buildFrontend(withRoute(requestPath, routeRule))),
+					withBackend("backend", buildBackend(withServer("testServer", testServerURL), withLoadBalancer("P2C", false))),
+				)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			desc: "Ok LB-Drr Sticky",
+			dynamicConfig: func(testServerURL string) *types.Configuration {
+				return buildDynamicConfig(
+					withFrontend("frontend", buildFrontend(withRoute(requestPath, routeRule))),
+					withBackend("backend", buildBackend(withServer("testServer", testServerURL), withLoadBalancer("Drr", true))),
+				)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			desc: "Ok LB-Hash",
+			dynamicConfig: func(testServerURL string) *types.Configuration {
+				return buildDynamicConfig(
+					withFrontend("frontend", buildFrontend(withRoute(requestPath, routeRule))),
+					withBackend("backend", buildBackend(withServer("testServer", testServerURL), withLoadBalancer("Hash", false))),
+				)
+			},
+			wantStatusCode: http.StatusOK,
+		},
 		{
 			desc: "No Frontend",
 			dynamicConfig: func(testServerURL string) *types.Configuration {
@@ -885,6 +1081,46 @@ func TestServerResponseEmptyBackend(t *testing.T) {
 			},
 			wantStatusCode: http.StatusServiceUnavailable,
 		},
+		{
+			desc: "Empty Backend LB-LeastConn",
+			dynamicConfig: func(testServerURL string) *types.Configuration {
+				return buildDynamicConfig(
+					withFrontend("frontend", buildFrontend(withRoute(requestPath, routeRule))),
+					withBackend("backend", buildBackend(withLoadBalancer("LeastConn", false))),
+				)
+			},
+			wantStatusCode: http.StatusServiceUnavailable,
+		},
+		{
+			desc: "Empty Backend LB-P2C",
+			dynamicConfig: func(testServerURL string) *types.Configuration {
+				return buildDynamicConfig(
+					withFrontend("frontend", buildFrontend(withRoute(requestPath, routeRule))),
+					withBackend("backend", buildBackend(withLoadBalancer("P2C", false))),
+				)
+			},
+			wantStatusCode: http.StatusServiceUnavailable,
+		},
+		{
+			desc: "Empty Backend LB-Hash",
+			dynamicConfig: func(testServerURL string) *types.Configuration {
+				return buildDynamicConfig(
+					withFrontend("frontend", buildFrontend(withRoute(requestPath, routeRule))),
+					withBackend("backend", buildBackend(withLoadBalancer("Hash", false))),
+				)
+			},
+			wantStatusCode: http.StatusServiceUnavailable,
+		},
+		{
+			desc: "Empty Backend LB-LeastConn Sticky",
+			dynamicConfig: func(testServerURL string) *types.Configuration {
+				return buildDynamicConfig(
+					withFrontend("frontend", buildFrontend(withRoute(requestPath, routeRule))),
+					withBackend("backend", buildBackend(withLoadBalancer("LeastConn", true))),
+				)
+			},
+			wantStatusCode: http.StatusServiceUnavailable,
+		},
 	}
 
 	for _, test := range testCases {
@@ -1016,6 +1252,724 @@ func TestBuildRedirectHandler(t *testing.T) {
 	}
 }
 
+func TestServerH2CBackend(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	h2cServer := &http2.Server{}
+	handler := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Trailer", "Grpc-Status")
+		rw.Write([]byte(req.Proto))
+		rw.Header().Set("Grpc-Status", "0")
+	})
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go h2cServer.ServeConn(conn, &http2.ServeConnOpts{Handler: handler})
+		}
+	}()
+
+	testCases := []struct {
+		desc               string
+		protocol           string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			desc:               "h2c",
+			protocol:           types.BackendProtocolH2C,
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "HTTP/2.0",
+		},
+		{
+			desc:               "unknown protocol",
+			protocol:           "spdy",
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			globalConfig := configuration.GlobalConfiguration{
+				EntryPoints: configuration.EntryPoints{
+					"http": &configuration.EntryPoint{ForwardedHeaders: &configuration.ForwardedHeaders{Insecure: true}},
+				},
+			}
+			dynamicConfigs := types.Configurations{
+				"config": buildDynamicConfig(
+					withFrontend("frontend", buildFrontend(withRoute("route", "GRPCService:helloworld.Greeter"))),
+					withBackend("backend", buildBackend(withServer("server", "http://"+listener.Addr().String()), withProtocol(test.protocol))),
+				),
+			}
+
+			srv := NewServer(globalConfig, nil)
+			entryPoints, err := srv.loadConfig(dynamicConfigs, globalConfig)
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodPost, "http://foo.bar/helloworld.Greeter/SayHello", nil)
+			request.Header.Set("Content-Type", "application/grpc")
+
+			entryPoints["http"].httpRouter.ServeHTTP(recorder, request)
+
+			assert.Equal(t, test.expectedStatusCode, recorder.Code)
+			if test.expectedBody != "" {
+				assert.Equal(t, test.expectedBody, recorder.Body.String())
+				assert.Equal(t, "0", recorder.Result().Trailer.Get("Grpc-Status"))
+			}
+		})
+	}
+}
+
+func TestServerTrafficSplit(t *testing.T) {
+	v1 := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte("v1"))
+	}))
+	defer v1.Close()
+	v2 := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte("v2"))
+	}))
+	defer v2.Close()
+
+	testCases := []struct {
+		desc               string
+		split              *types.TrafficSplit
+		expectedStatusCode int
+		expectedCounts     map[string]int
+	}{
+		{
+			desc: "canary",
+			split: &types.TrafficSplit{Backends: []types.WeightedBackend{
+				{Backend: "v1", Weight: 9},
+				{Backend: "v2", Weight: 1},
+			}},
+			expectedStatusCode: http.StatusOK,
+			expectedCounts:     map[string]int{"v1": 9, "v2": 1},
+		},
+		{
+			desc: "sticky",
+			split: &types.TrafficSplit{
+				Backends: []types.WeightedBackend{
+					{Backend: "v1", Weight: 1},
+					{Backend: "v2", Weight: 1},
+				},
+				Stickiness: &types.Stickiness{CookieName: "canary"},
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedCounts:     map[string]int{"v1": 10},
+		},
+		{
+			desc:               "null total weight",
+			split:              &types.TrafficSplit{Backends: []types.WeightedBackend{{Backend: "v1"}}},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			desc:               "no backend",
+			split:              &types.TrafficSplit{},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			globalConfig := configuration.GlobalConfiguration{
+				EntryPoints: configuration.EntryPoints{
+					"http": &configuration.EntryPoint{ForwardedHeaders: &configuration.ForwardedHeaders{Insecure: true}},
+				},
+			}
+			frontend := buildFrontend(withRoute("route", "Host:foo.bar"))
+			frontend.Split = test.split
+			dynamicConfigs := types.Configurations{
+				"config": buildDynamicConfig(
+					withFrontend("frontend", frontend),
+					withBackend("v1", buildBackend(withServer("server", v1.URL))),
+					withBackend("v2", buildBackend(withServer("server", v2.URL))),
+				),
+			}
+
+			srv := NewServer(globalConfig, nil)
+			entryPoints, err := srv.loadConfig(dynamicConfigs, globalConfig)
+			require.NoError(t, err)
+
+			counts := map[string]int{}
+			var cookies []*http.Cookie
+			for i := 0; i < 10; i++ {
+				recorder := httptest.NewRecorder()
+				request := httptest.NewRequest(http.MethodGet, "http://foo.bar/", nil)
+				for _, c := range cookies {
+					request.AddCookie(c)
+				}
+				entryPoints["http"].httpRouter.ServeHTTP(recorder, request)
+
+				require.Equal(t, test.expectedStatusCode, recorder.Code)
+				if recorder.Code == http.StatusOK {
+					counts[recorder.Body.String()]++
+				}
+				if i == 0 {
+					cookies = recorder.Result().Cookies()
+				}
+			}
+			if test.expectedCounts == nil {
+				return
+			}
+			assert.Equal(t, test.expectedCounts, counts)
+
+			frontendRouting := entryPoints["http"].routingTable.Get().(*routingTable).frontends["frontend"]
+			assert.Equal(t, "v1, v2", frontendRouting.backend)
+		})
+	}
+}
+
+func TestServerStickyCookie(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}))
+	defer server.Close()
+
+	for _, method := range []string{"wrr", "drr", "leastconn", "p2c"} {
+		method := method
+		t.Run(method, func(t *testing.T) {
+			globalConfig := configuration.GlobalConfiguration{
+				EntryPoints: configuration.EntryPoints{
+					"http": &configuration.EntryPoint{ForwardedHeaders: &configuration.ForwardedHeaders{Insecure: true}},
+				},
+			}
+			backend := buildBackend(withServer("server", server.URL), withLoadBalancer(method, true))
+			backend.LoadBalancer.Stickiness.Secret = "secret"
+			backend.LoadBalancer.Stickiness.HTTPOnly = true
+			backend.LoadBalancer.Stickiness.SameSite = "strict"
+			dynamicConfigs := types.Configurations{
+				"config": buildDynamicConfig(
+					withFrontend("frontend", buildFrontend(withRoute("route", "Host:foo.bar"))),
+					withBackend("backend", backend),
+				),
+			}
+
+			srv := NewServer(globalConfig, nil)
+			entryPoints, err := srv.loadConfig(dynamicConfigs, globalConfig)
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			entryPoints["http"].httpRouter.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "http://foo.bar/", nil))
+			require.Equal(t, http.StatusOK, recorder.Code)
+
+			setCookie := recorder.Header().Get("Set-Cookie")
+			assert.NotContains(t, setCookie, server.URL)
+			assert.Contains(t, setCookie, "HttpOnly")
+			assert.Contains(t, setCookie, "SameSite=Strict")
+			cookies := recorder.Result().Cookies()
+			require.Len(t, cookies, 1)
+
+			// the cookie is accepted back
+			recorder = httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, "http://foo.bar/", nil)
+			request.AddCookie(cookies[0])
+			entryPoints["http"].httpRouter.ServeHTTP(recorder, request)
+			require.Equal(t, http.StatusOK, recorder.Code)
+			assert.Empty(t, recorder.Header().Get("Set-Cookie"))
+		})
+	}
+}
+
+func TestServerMirror(t *testing.T) {
+	primary := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte("primary"))
+	}))
+	defer primary.Close()
+	mirrored := make(chan string, 10)
+	shadow := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		mirrored <- string(body)
+		rw.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer shadow.Close()
+
+	testCases := []struct {
+		desc           string
+		mirror         *types.Mirror
+		expectedMirror bool
+	}{
+		{
+			desc:           "mirror",
+			mirror:         &types.Mirror{Backend: "shadow"},
+			expectedMirror: true,
+		},
+		{
+			desc:   "undefined mirror backend",
+			mirror: &types.Mirror{Backend: "undefined"},
+		},
+		{
+			desc:   "invalid percent",
+			mirror: &types.Mirror{Backend: "shadow", Percent: 110},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			globalConfig := configuration.GlobalConfiguration{
+				EntryPoints: configuration.EntryPoints{
+					"http": &configuration.EntryPoint{ForwardedHeaders: &configuration.ForwardedHeaders{Insecure: true}},
+				},
+			}
+			frontend := buildFrontend(withRoute("route", "Host:foo.bar"))
+			frontend.Mirror = test.mirror
+			dynamicConfigs := types.Configurations{
+				"config": buildDynamicConfig(
+					withFrontend("frontend", frontend),
+					withBackend("backend", buildBackend(withServer("server", primary.URL))),
+					withBackend("shadow", buildBackend(withServer("server", shadow.URL))),
+				),
+			}
+
+			srv := NewServer(globalConfig, nil)
+			entryPoints, err := srv.loadConfig(dynamicConfigs, globalConfig)
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodPost, "http://foo.bar/", strings.NewReader("payload"))
+			entryPoints["http"].httpRouter.ServeHTTP(recorder, request)
+
+			// the response of the mirror is ignored
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			assert.Equal(t, "primary", recorder.Body.String())
+
+			if !test.expectedMirror {
+				select {
+				case <-mirrored:
+					t.Fatal("unexpected mirrored request")
+				case <-time.After(50 * time.Millisecond):
+				}
+				return
+			}
+			select {
+			case body := <-mirrored:
+				assert.Equal(t, "payload", body)
+			case <-time.After(time.Second):
+				t.Fatal("request not mirrored")
+			}
+		})
+	}
+}
+
+func TestServerFallbackBackend(t *testing.T) {
+	primary := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte("primary"))
+	}))
+	defer primary.Close()
+	maintenance := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte("maintenance"))
+	}))
+	defer maintenance.Close()
+
+	testCases := []struct {
+		desc            string
+		primaryServers  []func(*types.Backend)
+		fallbackBackend string
+		expectedCode    int
+		expectedBody    string
+	}{
+		{
+			desc:            "primary backend up",
+			primaryServers:  []func(*types.Backend){withServer("server", primary.URL)},
+			fallbackBackend: "maintenance",
+			expectedCode:    http.StatusOK,
+			expectedBody:    "primary",
+		},
+		{
+			desc:            "primary backend down",
+			fallbackBackend: "maintenance",
+			expectedCode:    http.StatusOK,
+			expectedBody:    "maintenance",
+		},
+		{
+			desc:         "primary backend down without fallback",
+			expectedCode: http.StatusServiceUnavailable,
+			expectedBody: http.StatusText(http.StatusServiceUnavailable),
+		},
+		{
+			desc:            "undefined fallback backend",
+			fallbackBackend: "undefined",
+			expectedCode:    http.StatusServiceUnavailable,
+			expectedBody:    http.StatusText(http.StatusServiceUnavailable),
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			globalConfig := configuration.GlobalConfiguration{
+				EntryPoints: configuration.EntryPoints{
+					"http": &configuration.EntryPoint{ForwardedHeaders: &configuration.ForwardedHeaders{Insecure: true}},
+				},
+			}
+			frontend := buildFrontend(withRoute("route", "Host:foo.bar"))
+			frontend.FallbackBackend = test.fallbackBackend
+			dynamicConfigs := types.Configurations{
+				"config": buildDynamicConfig(
+					withFrontend("frontend", frontend),
+					withBackend("backend", buildBackend(test.primaryServers...)),
+					withBackend("maintenance", buildBackend(withServer("server", maintenance.URL))),
+				),
+			}
+
+			srv := NewServer(globalConfig, nil)
+			entryPoints, err := srv.loadConfig(dynamicConfigs, globalConfig)
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, "http://foo.bar/", nil)
+			entryPoints["http"].httpRouter.ServeHTTP(recorder, request)
+
+			assert.Equal(t, test.expectedCode, recorder.Code)
+			assert.Equal(t, test.expectedBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestServerFrontendTimeout(t *testing.T) {
+	deadlines := make(chan string, 10)
+	backend := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		deadlines <- req.Header.Get("X-Request-Timeout")
+		if req.URL.Path == "/slow" {
+			select {
+			case <-req.Context().Done():
+			case <-time.After(time.Second):
+			}
+		}
+		rw.Write([]byte("backend"))
+	}))
+	defer backend.Close()
+
+	testCases := []struct {
+		desc         string
+		path         string
+		retry        *configuration.Retry
+		expectedCode int
+	}{
+		{
+			desc:         "fast response",
+			path:         "/fast",
+			expectedCode: http.StatusOK,
+		},
+		{
+			desc:         "slow response",
+			path:         "/slow",
+			expectedCode: http.StatusGatewayTimeout,
+		},
+		{
+			desc:         "slow response with retries",
+			path:         "/slow",
+			retry:        &configuration.Retry{Attempts: 3},
+			expectedCode: http.StatusGatewayTimeout,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			globalConfig := configuration.GlobalConfiguration{
+				EntryPoints: configuration.EntryPoints{
+					"http": &configuration.EntryPoint{ForwardedHeaders: &configuration.ForwardedHeaders{Insecure: true}},
+				},
+				Retry: test.retry,
+			}
+			frontend := buildFrontend(withRoute("route", "Host:foo.bar"))
+			frontend.Timeout = &types.FrontendTimeout{Request: "100ms", DeadlineHeader: "X-Request-Timeout"}
+			dynamicConfigs := types.Configurations{
+				"config": buildDynamicConfig(
+					withFrontend("frontend", frontend),
+					withBackend("backend", buildBackend(withServer("server", backend.URL))),
+				),
+			}
+
+			srv := NewServer(globalConfig, nil)
+			entryPoints, err := srv.loadConfig(dynamicConfigs, globalConfig)
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, "http://foo.bar"+test.path, nil)
+			entryPoints["http"].httpRouter.ServeHTTP(recorder, request)
+
+			assert.Equal(t, test.expectedCode, recorder.Code)
+
+			deadline, err := strconv.Atoi(<-deadlines)
+			require.NoError(t, err)
+			assert.True(t, deadline > 0 && deadline <= 100, "got deadline header %d, expected up to 100", deadline)
+			// the timed out request isn't retried
+			assert.Len(t, deadlines, 0)
+		})
+	}
+}
+
+func TestServerBackendTransport(t *testing.T) {
+	certificate, err := cryptotls.X509KeyPair([]byte(localhostCert), []byte(localhostKey))
+	require.NoError(t, err)
+
+	backendServer := httptest.NewUnstartedServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte(req.TLS.ServerName))
+	}))
+	backendServer.TLS = &cryptotls.Config{
+		Certificates: []cryptotls.Certificate{certificate},
+		ClientAuth:   cryptotls.RequireAnyClientCert,
+	}
+	backendServer.StartTLS()
+	defer backendServer.Close()
+
+	testCases := []struct {
+		desc         string
+		transport    *types.Transport
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			desc:         "default transport",
+			expectedCode: http.StatusInternalServerError,
+		},
+		{
+			desc: "without client certificate",
+			transport: &types.Transport{
+				TLS: &types.TransportTLS{CA: string(localhostCert)},
+			},
+			expectedCode: http.StatusBadGateway,
+		},
+		{
+			desc: "with client certificate",
+			transport: &types.Transport{
+				DialTimeout: "5s",
+				TLS: &types.TransportTLS{
+					CA:         string(localhostCert),
+					ServerName: "example.com",
+					Cert:       string(localhostCert),
+					Key:        string(localhostKey),
+				},
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: "example.com",
+		},
+		{
+			desc: "client certificate without key",
+			transport: &types.Transport{
+				TLS: &types.TransportTLS{CA: string(localhostCert), Cert: string(localhostCert)},
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			desc:         "invalid timeout",
+			transport:    &types.Transport{ResponseHeaderTimeout: "forever"},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			globalConfig := configuration.GlobalConfiguration{
+				EntryPoints: configuration.EntryPoints{
+					"http": &configuration.EntryPoint{ForwardedHeaders: &configuration.ForwardedHeaders{Insecure: true}},
+				},
+			}
+			backend := buildBackend(withServer("server", backendServer.URL))
+			backend.Transport = test.transport
+			dynamicConfigs := types.Configurations{
+				"config": buildDynamicConfig(
+					withFrontend("frontend", buildFrontend(withRoute("route", "Host:foo.bar"))),
+					withBackend("backend", backend),
+				),
+			}
+
+			srv := NewServer(globalConfig, nil)
+			entryPoints, err := srv.loadConfig(dynamicConfigs, globalConfig)
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, "http://foo.bar/", nil)
+			entryPoints["http"].httpRouter.ServeHTTP(recorder, request)
+
+			assert.Equal(t, test.expectedCode, recorder.Code)
+			if test.expectedBody != "" {
+				assert.Equal(t, test.expectedBody, recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestServerBackendTransportIsShared(t *testing.T) {
+	srv := NewServer(configuration.GlobalConfiguration{}, nil)
+
+	transport := &types.Transport{MaxIdleConnsPerHost: 10, DisableHTTP2: true}
+	first, err := srv.getBackendRoundTripper(srv.defaultForwardingRoundTripper, &types.Backend{Transport: transport})
+	require.NoError(t, err)
+	assert.Equal(t, 10, first.(*http.Transport).MaxIdleConnsPerHost)
+	assert.Nil(t, first.(*http.Transport).TLSNextProto)
+
+	// another backend, or the same backend after a reload
+	second, err := srv.getBackendRoundTripper(srv.defaultForwardingRoundTripper, &types.Backend{Transport: &types.Transport{MaxIdleConnsPerHost: 10, DisableHTTP2: true}})
+	require.NoError(t, err)
+	assert.True(t, first == second)
+
+	h2c, err := srv.getBackendRoundTripper(srv.defaultForwardingRoundTripper, &types.Backend{Protocol: types.BackendProtocolH2C, Transport: transport})
+	require.NoError(t, err)
+	assert.IsType(t, &http2.Transport{}, h2c)
+}
+
+func TestServerBackendTransportAcrossReloads(t *testing.T) {
+	srv := NewServer(configuration.GlobalConfiguration{}, nil)
+
+	caFile, err := ioutil.TempFile("", "ca")
+	require.NoError(t, err)
+	defer os.Remove(caFile.Name())
+	_, err = caFile.Write([]byte(localhostCert))
+	require.NoError(t, err)
+	require.NoError(t, caFile.Close())
+
+	withCA := &types.Backend{Transport: &types.Transport{TLS: &types.TransportTLS{CA: caFile.Name()}}}
+	other := &types.Backend{Transport: &types.Transport{MaxIdleConnsPerHost: 10}}
+
+	srv.renewBackendTransports()
+	first, err := srv.getBackendRoundTripper(srv.defaultForwardingRoundTripper, withCA)
+	require.NoError(t, err)
+	_, err = srv.getBackendRoundTripper(srv.defaultForwardingRoundTripper, other)
+	require.NoError(t, err)
+	srv.releaseBackendTransports()
+
+	// the transport still in use is carried over, the other one is dropped
+	srv.renewBackendTransports()
+	second, err := srv.getBackendRoundTripper(srv.defaultForwardingRoundTripper, withCA)
+	require.NoError(t, err)
+	srv.releaseBackendTransports()
+	assert.True(t, first == second)
+	assert.Len(t, srv.backendTransports, 1)
+
+	// a rotated CA file is read again
+	require.NoError(t, ioutil.WriteFile(caFile.Name(), []byte(localhostCert+"\n"), 0600))
+	srv.renewBackendTransports()
+	rotated, err := srv.getBackendRoundTripper(srv.defaultForwardingRoundTripper, withCA)
+	require.NoError(t, err)
+	srv.releaseBackendTransports()
+	assert.False(t, first == rotated)
+	assert.Len(t, srv.backendTransports, 1)
+}
+
+func TestParseRetryPolicy(t *testing.T) {
+	servers := map[string]types.Server{"a": {URL: "http://a"}, "b": {URL: "http://b"}}
+
+	testCases := []struct {
+		desc           string
+		retry          *types.Retry
+		globalRetry    *configuration.Retry
+		expected       middlewares.RetryPolicy
+		expectedBudget bool
+	}{
+		{
+			desc:     "global retry without attempts",
+			expected: middlewares.RetryPolicy{Attempts: 2},
+		},
+		{
+			desc:        "global retry",
+			globalRetry: &configuration.Retry{Attempts: 5},
+			expected:    middlewares.RetryPolicy{Attempts: 5},
+		},
+		{
+			desc:        "backend retry",
+			globalRetry: &configuration.Retry{Attempts: 5},
+			retry: &types.Retry{
+				Attempts:       3,
+				StatusCodes:    []string{"502-504", "429"},
+				IdempotentOnly: true,
+				PerTryTimeout:  "2s",
+				Backoff:        "100ms",
+				MaxBackoff:     "1s",
+				BudgetPercent:  20,
+			},
+			expected: middlewares.RetryPolicy{
+				Attempts:         3,
+				StatusCodeRanges: [][2]int{{502, 504}, {429, 429}},
+				IdempotentOnly:   true,
+				PerTryTimeout:    2 * time.Second,
+				Backoff:          100 * time.Millisecond,
+				MaxBackoff:       time.Second,
+			},
+			expectedBudget: true,
+		},
+		{
+			desc: "illegal values are ignored",
+			retry: &types.Retry{
+				StatusCodes:   []string{"5xx"},
+				PerTryTimeout: "foo",
+				Backoff:       "bar",
+			},
+			expected: middlewares.RetryPolicy{Attempts: 2},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			policy := parseRetryPolicy("backend", &types.Backend{Servers: servers, Retry: test.retry}, test.globalRetry)
+
+			assert.Equal(t, test.expectedBudget, policy.Budget != nil)
+			policy.Budget = nil
+			assert.Equal(t, test.expected, policy)
+		})
+	}
+}
+
+func TestParseHedgingPolicy(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		hedging  *types.Hedging
+		expected *middlewares.HedgingPolicy
+	}{
+		{
+			desc: "no hedging",
+		},
+		{
+			desc:     "delay",
+			hedging:  &types.Hedging{Delay: "50ms"},
+			expected: &middlewares.HedgingPolicy{Delay: 50 * time.Millisecond},
+		},
+		{
+			desc:     "percentile",
+			hedging:  &types.Hedging{Percentile: 95},
+			expected: &middlewares.HedgingPolicy{Delay: 100 * time.Millisecond, Percentile: 95},
+		},
+		{
+			desc:     "delay and percentile",
+			hedging:  &types.Hedging{Delay: "1s", Percentile: 99},
+			expected: &middlewares.HedgingPolicy{Delay: time.Second, Percentile: 99},
+		},
+		{
+			desc:     "illegal delay",
+			hedging:  &types.Hedging{Delay: "foo", Percentile: 90},
+			expected: &middlewares.HedgingPolicy{Delay: 100 * time.Millisecond, Percentile: 90},
+		},
+		{
+			desc:    "illegal percentile",
+			hedging: &types.Hedging{Percentile: 100},
+		},
+		{
+			desc:    "empty hedging",
+			hedging: &types.Hedging{},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			policy := parseHedgingPolicy("backend", &types.Backend{Hedging: test.hedging})
+			assert.Equal(t, test.expected, policy)
+		})
+	}
+}
+
 func buildDynamicConfig(dynamicConfigBuilders ...func(*types.Configuration)) *types.Configuration {
 	config := &types.Configuration{
 		Frontends: make(map[string]*types.Frontend),
@@ -1083,3 +2037,9 @@ func withLoadBalancer(method string, sticky bool) func(*types.Backend) {
 		}
 	}
 }
+
+func withProtocol(protocol string) func(*types.Backend) {
+	return func(be *types.Backend) {
+		be.Protocol = protocol
+	}
+}
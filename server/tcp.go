@@ -0,0 +1,124 @@
+package server
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/containous/traefik/configuration"
+	"github.com/containous/traefik/log"
+	"github.com/containous/traefik/tcp"
+	"github.com/containous/traefik/types"
+)
+
+// prepareTCPServer creates the server of a TCP entry point. The TLS configuration of the entry point,
+// if any, is used to terminate TLS for the frontends not configured for TLS passthrough.
+func (s *Server) prepareTCPServer(entryPointName string, entryPoint *configuration.EntryPoint, serverEntryPoint *serverEntryPoint) (*tcp.Server, *tls.Config, error) {
+	log.Infof("Preparing TCP server %s %+v", entryPointName, entryPoint)
+
+	tlsConfig, err := s.createTLSConfig(entryPointName, entryPoint.TLS, serverEntryPoint.httpRouter)
+	if err != nil {
+		log.Errorf("Error creating TLS config: %s", err)
+		return nil, nil, err
+	}
+
+	if tlsConfig != nil {
+		// Terminated connections are forwarded as is, HTTP/2 can't be negotiated.
+		tlsConfig.NextProtos = nil
+	}
+
+	listener, err := buildListener(entryPoint)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return tcp.NewServer(entryPoint.Address, listener, serverEntryPoint.tcpRouter), tlsConfig, nil
+}
+
+// wireTCPFrontend adds a route for the frontend to the router of a TCP entry point,
+// forwarding connections to the servers of its backend.
+func (s *Server) wireTCPFrontend(router *tcp.Router, tlsConfig *tls.Config, frontendName string, frontend *types.Frontend,
+	config *types.Configuration, globalConfiguration configuration.GlobalConfiguration) error {
+	serverNames, rule, err := parseHostSNIRules(frontend.Routes)
+	if err != nil {
+		return err
+	}
+
+	backend := config.Backends[frontend.Backend]
+	if backend == nil {
+		return fmt.Errorf("undefined backend '%s'", frontend.Backend)
+	}
+
+	dialTimeout := configuration.DefaultDialTimeout
+	if globalConfiguration.ForwardingTimeouts != nil {
+		dialTimeout = time.Duration(globalConfiguration.ForwardingTimeouts.DialTimeout)
+	}
+
+	lb := tcp.NewWRRLoadBalancer()
+	for name, srv := range backend.Servers {
+		address, err := tcpServerAddress(srv.URL)
+		if err != nil {
+			return err
+		}
+		log.Debugf("Creating TCP server %s at %s with weight %d", name, address, srv.Weight)
+		lb.AddServer(tcp.NewProxy(address, dialTimeout), srv.Weight)
+	}
+
+	var handler tcp.Handler = lb
+	if !frontend.TLSPassthrough && tlsConfig != nil {
+		handler = &tcp.TLSHandler{Next: lb, Config: tlsConfig}
+	}
+
+	priority := frontend.Priority
+	if priority <= 0 {
+		priority = len(rule)
+	}
+	router.AddRoute(frontendName, priority, serverNames, handler)
+	return nil
+}
+
+// parseHostSNIRules returns the server names of the HostSNI rules of a TCP frontend, which is the only rule supported on TCP entry points.
+func parseHostSNIRules(routes map[string]types.Route) ([]string, string, error) {
+	if len(routes) != 1 {
+		return nil, "", errors.New("TCP frontends must have exactly one route")
+	}
+
+	for _, route := range routes {
+		rule := strings.TrimSpace(route.Rule)
+		parts := strings.SplitN(rule, ":", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) != "HostSNI" {
+			return nil, "", fmt.Errorf("unsupported rule '%s' for a TCP frontend: only HostSNI is supported", route.Rule)
+		}
+
+		var serverNames []string
+		for _, serverName := range strings.Split(parts[1], ",") {
+			if serverName = strings.TrimSpace(serverName); serverName != "" {
+				serverNames = append(serverNames, types.CanonicalDomain(serverName))
+			}
+		}
+		if len(serverNames) == 0 {
+			return nil, "", fmt.Errorf("no server name in rule '%s'", route.Rule)
+		}
+		return serverNames, rule, nil
+	}
+	return nil, "", nil
+}
+
+// tcpServerAddress returns the address of a TCP server, whose URL must use the tcp scheme.
+func tcpServerAddress(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("error parsing server URL %s: %v", rawURL, err)
+	}
+	if u.Scheme != configuration.NetworkTCP {
+		return "", fmt.Errorf("invalid scheme for TCP server URL %s: expected %s", rawURL, configuration.NetworkTCP)
+	}
+	if _, _, err := net.SplitHostPort(u.Host); err != nil {
+		return "", fmt.Errorf("invalid address for TCP server URL %s: %v", rawURL, err)
+	}
+	return u.Host, nil
+}
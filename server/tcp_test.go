@@ -0,0 +1,160 @@
+package server
+
+import (
+	"io/ioutil"
+	"net"
+	"testing"
+
+	"github.com/containous/traefik/configuration"
+	"github.com/containous/traefik/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHostSNIRules(t *testing.T) {
+	testCases := []struct {
+		desc                string
+		routes              map[string]types.Route
+		expectedServerNames []string
+		expectedError       bool
+	}{
+		{
+			desc:                "single server name",
+			routes:              map[string]types.Route{"route": {Rule: "HostSNI:foo.bar"}},
+			expectedServerNames: []string{"foo.bar"},
+		},
+		{
+			desc:                "several server names",
+			routes:              map[string]types.Route{"route": {Rule: "HostSNI: Foo.bar , bar.foo"}},
+			expectedServerNames: []string{"foo.bar", "bar.foo"},
+		},
+		{
+			desc:                "catch-all",
+			routes:              map[string]types.Route{"route": {Rule: "HostSNI:*"}},
+			expectedServerNames: []string{"*"},
+		},
+		{
+			desc:          "HTTP rule",
+			routes:        map[string]types.Route{"route": {Rule: "Host:foo.bar"}},
+			expectedError: true,
+		},
+		{
+			desc:          "no server name",
+			routes:        map[string]types.Route{"route": {Rule: "HostSNI:,"}},
+			expectedError: true,
+		},
+		{
+			desc: "several routes",
+			routes: map[string]types.Route{
+				"route1": {Rule: "HostSNI:foo.bar"},
+				"route2": {Rule: "HostSNI:bar.foo"},
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			serverNames, _, err := parseHostSNIRules(test.routes)
+			if test.expectedError {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.expectedServerNames, serverNames)
+			}
+		})
+	}
+}
+
+func TestTCPServerAddress(t *testing.T) {
+	testCases := []struct {
+		url             string
+		expectedAddress string
+		expectedError   bool
+	}{
+		{url: "tcp://10.0.0.1:5432", expectedAddress: "10.0.0.1:5432"},
+		{url: "tcp://db.local:3306", expectedAddress: "db.local:3306"},
+		{url: "http://10.0.0.1:80", expectedError: true},
+		{url: "tcp://10.0.0.1", expectedError: true},
+		{url: "tcp://%zz", expectedError: true},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.url, func(t *testing.T) {
+			t.Parallel()
+
+			address, err := tcpServerAddress(test.url)
+			if test.expectedError {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.expectedAddress, address)
+			}
+		})
+	}
+}
+
+func TestServerLoadConfigTCPEntryPoint(t *testing.T) {
+	backendListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer backendListener.Close()
+
+	go func() {
+		conn, err := backendListener.Accept()
+		if err != nil {
+			return
+		}
+		conn.Write([]byte("hello"))
+		conn.Close()
+	}()
+
+	globalConfig := configuration.GlobalConfiguration{
+		EntryPoints: configuration.EntryPoints{
+			"db": &configuration.EntryPoint{Network: "tcp", ForwardedHeaders: &configuration.ForwardedHeaders{Insecure: true}},
+		},
+	}
+
+	dynamicConfigs := types.Configurations{
+		"config": &types.Configuration{
+			Frontends: map[string]*types.Frontend{
+				"frontend-db": {
+					EntryPoints: []string{"db"},
+					Backend:     "backend-db",
+					Routes: map[string]types.Route{
+						"route": {Rule: "HostSNI:*"},
+					},
+				},
+				"frontend-http": {
+					EntryPoints: []string{"db"},
+					Backend:     "backend-db",
+					Routes: map[string]types.Route{
+						"route": {Rule: "Host:foo.bar"},
+					},
+				},
+			},
+			Backends: map[string]*types.Backend{
+				"backend-db": {
+					Servers: map[string]types.Server{
+						"server": {URL: "tcp://" + backendListener.Addr().String()},
+					},
+				},
+			},
+		},
+	}
+
+	srv := NewServer(globalConfig, nil)
+	entryPoints, err := srv.loadConfig(dynamicConfigs, globalConfig)
+	require.NoError(t, err)
+	require.NotNil(t, entryPoints["db"].tcpRouter)
+
+	client, server := net.Pipe()
+	go entryPoints["db"].tcpRouter.ServeTCP(server)
+
+	response, err := ioutil.ReadAll(client)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(response))
+}
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+
+	"github.com/containous/traefik/configuration"
+	"github.com/containous/traefik/healthcheck"
+	"github.com/containous/traefik/log"
+	"github.com/containous/traefik/types"
+	"github.com/containous/traefik/udp"
+)
+
+// prepareUDPServer creates the server of a UDP entry point.
+func (s *Server) prepareUDPServer(entryPointName string, entryPoint *configuration.EntryPoint, serverEntryPoint *serverEntryPoint) (*udp.Server, error) {
+	idleTimeout := entryPoint.GetUDPIdleTimeout()
+	log.Infof("Preparing UDP server %s %+v with idleTimeout=%s", entryPointName, entryPoint, idleTimeout)
+
+	conn, err := net.ListenPacket("udp", entryPoint.Address)
+	if err != nil {
+		log.Error("Error opening listener ", err)
+		return nil, err
+	}
+
+	return udp.NewServer(entryPoint.Address, conn, serverEntryPoint.udpRouter, idleTimeout), nil
+}
+
+// wireUDPFrontend sets the frontend as the one of a UDP entry point, forwarding datagrams to the servers of its backend.
+// It returns the health check of the backend, if any.
+func (s *Server) wireUDPFrontend(router *udp.HandlerSwitcher, frontendName string, frontend *types.Frontend,
+	config *types.Configuration, globalConfiguration configuration.GlobalConfiguration) (*healthcheck.BackendHealthCheck, error) {
+	if router.GetHandler() != nil {
+		return nil, fmt.Errorf("UDP entry points have a single frontend")
+	}
+
+	backend := config.Backends[frontend.Backend]
+	if backend == nil {
+		return nil, fmt.Errorf("undefined backend '%s'", frontend.Backend)
+	}
+
+	lb := udp.NewWRRLoadBalancer()
+	for name, srv := range backend.Servers {
+		u, err := url.Parse(srv.URL)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing server URL %s: %v", srv.URL, err)
+		}
+		if u.Scheme != configuration.NetworkUDP {
+			return nil, fmt.Errorf("invalid scheme for UDP server URL %s: expected %s", srv.URL, configuration.NetworkUDP)
+		}
+		if _, _, err := net.SplitHostPort(u.Host); err != nil {
+			return nil, fmt.Errorf("invalid address for UDP server URL %s: %v", srv.URL, err)
+		}
+
+		log.Debugf("Creating UDP server %s at %s with weight %d", name, u, srv.Weight)
+		lb.AddServer(u, srv.Weight)
+		s.metricsRegistry.BackendServerUpGauge().With("backend", frontend.Backend, "url", srv.URL).Set(1)
+	}
+	router.UpdateHandler(lb)
+
+	hcOpts := parseHealthCheckOptions(lb, frontend.Backend, backend.HealthCheck, globalConfiguration.HealthCheck)
+	if hcOpts == nil {
+		return nil, nil
+	}
+	// the UDP servers don't answer the HTTP requests of the default health check
+	if hcOpts.Type != healthcheck.TypeTCP && hcOpts.Type != healthcheck.TypeGRPC {
+		log.Errorf("Health check of UDP backend %s must be of type %s or %s, skipping it...", frontend.Backend, healthcheck.TypeTCP, healthcheck.TypeGRPC)
+		return nil, nil
+	}
+	log.Debugf("Setting up backend health check %s", *hcOpts)
+	hcOpts.Transport = s.defaultForwardingRoundTripper
+	return healthcheck.NewBackendHealthCheck(*hcOpts, frontend.Backend), nil
+}
@@ -0,0 +1,85 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/containous/traefik/configuration"
+	"github.com/containous/traefik/types"
+	"github.com/containous/traefik/udp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWireUDPFrontend(t *testing.T) {
+	testCases := []struct {
+		desc                string
+		serverURL           string
+		healthCheck         *types.HealthCheck
+		expectedError       bool
+		expectedHealthCheck bool
+	}{
+		{
+			desc:      "UDP server",
+			serverURL: "udp://10.0.0.1:53",
+		},
+		{
+			desc:                "UDP server with TCP health check",
+			serverURL:           "udp://10.0.0.1:53",
+			healthCheck:         &types.HealthCheck{Type: "tcp"},
+			expectedHealthCheck: true,
+		},
+		{
+			desc:        "UDP server with HTTP health check",
+			serverURL:   "udp://10.0.0.1:53",
+			healthCheck: &types.HealthCheck{Path: "/health", Port: 8080},
+		},
+		{
+			desc:          "HTTP server",
+			serverURL:     "http://10.0.0.1:80",
+			expectedError: true,
+		},
+		{
+			desc:          "missing port",
+			serverURL:     "udp://10.0.0.1",
+			expectedError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			globalConfig := configuration.GlobalConfiguration{
+				HealthCheck: &configuration.HealthCheckConfig{Interval: 1},
+			}
+			config := &types.Configuration{
+				Backends: map[string]*types.Backend{
+					"backend": {
+						Servers: map[string]types.Server{
+							"server": {URL: test.serverURL, Weight: 1},
+						},
+						HealthCheck: test.healthCheck,
+					},
+				},
+			}
+			frontend := &types.Frontend{Backend: "backend"}
+
+			srv := NewServer(globalConfig, nil)
+			router := udp.NewHandlerSwitcher(nil)
+			hc, err := srv.wireUDPFrontend(router, "frontend", frontend, config, globalConfig)
+			if test.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, router.GetHandler())
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, router.GetHandler())
+			assert.Len(t, router.GetHandler().Servers(), 1)
+			assert.Equal(t, test.expectedHealthCheck, hc != nil)
+
+			_, err = srv.wireUDPFrontend(router, "other", frontend, config, globalConfig)
+			assert.Error(t, err, "UDP entry points have a single frontend")
+		})
+	}
+}
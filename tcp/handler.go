@@ -0,0 +1,42 @@
+package tcp
+
+import (
+	"crypto/tls"
+	"net"
+)
+
+// Handler handles a TCP connection, and is responsible for closing it.
+type Handler interface {
+	ServeTCP(conn net.Conn)
+}
+
+// HandlerFunc is an adapter to allow the use of ordinary functions as TCP handlers.
+type HandlerFunc func(conn net.Conn)
+
+// ServeTCP calls f(conn).
+func (f HandlerFunc) ServeTCP(conn net.Conn) {
+	f(conn)
+}
+
+// TLSHandler terminates TLS before handing the decrypted connection to the next handler.
+type TLSHandler struct {
+	Next   Handler
+	Config *tls.Config
+}
+
+// ServeTCP wraps the connection in a TLS server connection.
+func (h *TLSHandler) ServeTCP(conn net.Conn) {
+	h.Next.ServeTCP(tls.Server(conn, h.Config))
+}
+
+type closeWriter interface {
+	CloseWrite() error
+}
+
+// closeWrite shuts down the writing side of the connection, or closes it if it can't be half-closed.
+func closeWrite(conn net.Conn) error {
+	if cw, ok := conn.(closeWriter); ok {
+		return cw.CloseWrite()
+	}
+	return conn.Close()
+}
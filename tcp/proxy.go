@@ -0,0 +1,48 @@
+package tcp
+
+import (
+	"io"
+	"net"
+	"time"
+
+	"github.com/containous/traefik/log"
+)
+
+// Proxy forwards connections to a server.
+type Proxy struct {
+	address     string
+	dialTimeout time.Duration
+}
+
+// NewProxy creates a proxy to the server at address.
+func NewProxy(address string, dialTimeout time.Duration) *Proxy {
+	return &Proxy{address: address, dialTimeout: dialTimeout}
+}
+
+// ServeTCP copies data between the connection and a new connection to the server, until both sides are done.
+func (p *Proxy) ServeTCP(conn net.Conn) {
+	defer conn.Close()
+
+	backendConn, err := net.DialTimeout("tcp", p.address, p.dialTimeout)
+	if err != nil {
+		log.Errorf("Error while connecting to %s: %v", p.address, err)
+		return
+	}
+	defer backendConn.Close()
+
+	errChan := make(chan error, 2)
+	go copyConn(backendConn, conn, errChan)
+	go copyConn(conn, backendConn, errChan)
+
+	for i := 0; i < 2; i++ {
+		if err := <-errChan; err != nil {
+			log.Debugf("Error while forwarding connection to %s: %v", p.address, err)
+		}
+	}
+}
+
+func copyConn(dst, src net.Conn, errChan chan<- error) {
+	_, err := io.Copy(dst, src)
+	closeWrite(dst)
+	errChan <- err
+}
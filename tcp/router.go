@@ -0,0 +1,92 @@
+package tcp
+
+import (
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/containous/traefik/log"
+)
+
+// CatchAll is the server name matching every connection, including non-TLS ones.
+const CatchAll = "*"
+
+// helloTimeout is the maximum time to wait for a TLS ClientHello when routing by server name.
+const helloTimeout = 5 * time.Second
+
+// Router routes connections to handlers according to the TLS server name (SNI) they request.
+type Router struct {
+	routes []*route
+}
+
+type route struct {
+	name       string
+	priority   int
+	serverName map[string]bool
+	handler    Handler
+}
+
+// NewRouter builds a new, empty, router.
+func NewRouter() *Router {
+	return &Router{}
+}
+
+// AddRoute adds a route handling connections requesting one of the server names.
+func (r *Router) AddRoute(name string, priority int, serverNames []string, handler Handler) {
+	newRoute := &route{
+		name:       name,
+		priority:   priority,
+		serverName: make(map[string]bool),
+		handler:    handler,
+	}
+	for _, serverName := range serverNames {
+		newRoute.serverName[strings.ToLower(serverName)] = true
+	}
+	r.routes = append(r.routes, newRoute)
+}
+
+// SortRoutes sorts routes by priority, then by name.
+func (r *Router) SortRoutes() {
+	sort.SliceStable(r.routes, func(i, j int) bool {
+		if r.routes[i].priority != r.routes[j].priority {
+			return r.routes[i].priority > r.routes[j].priority
+		}
+		return r.routes[i].name < r.routes[j].name
+	})
+}
+
+// ServeTCP hands the connection to the handler of the first route matching its server name.
+// The ClientHello is only read if a route matches specific server names,
+// so that routers with catch-all routes only also serve server-first protocols.
+func (r *Router) ServeTCP(conn net.Conn) {
+	var serverName string
+	var isTLS bool
+
+	if r.routesByServerName() {
+		peeked := newPeekedConn(conn)
+		serverName, isTLS = clientHelloServerName(peeked, helloTimeout)
+		serverName = strings.ToLower(serverName)
+		conn = peeked
+	}
+
+	for _, rt := range r.routes {
+		if rt.serverName[CatchAll] || (isTLS && rt.serverName[serverName]) {
+			log.Debugf("Routing connection from %s with server name %q to %s", conn.RemoteAddr(), serverName, rt.name)
+			rt.handler.ServeTCP(conn)
+			return
+		}
+	}
+
+	log.Debugf("No route for connection from %s with server name %q", conn.RemoteAddr(), serverName)
+	conn.Close()
+}
+
+func (r *Router) routesByServerName() bool {
+	for _, rt := range r.routes {
+		if !rt.serverName[CatchAll] {
+			return true
+		}
+	}
+	return false
+}
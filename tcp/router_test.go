@@ -0,0 +1,169 @@
+package tcp
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouterServerName(t *testing.T) {
+	type testRoute struct {
+		name        string
+		priority    int
+		serverNames []string
+	}
+
+	testCases := []struct {
+		desc       string
+		routes     []testRoute
+		serverName string
+		plain      bool
+		expected   string
+	}{
+		{
+			desc: "matching server name",
+			routes: []testRoute{
+				{name: "foo", priority: 10, serverNames: []string{"foo.bar"}},
+				{name: "bar", priority: 10, serverNames: []string{"bar.foo"}},
+			},
+			serverName: "foo.bar",
+			expected:   "foo",
+		},
+		{
+			desc: "server name is case insensitive",
+			routes: []testRoute{
+				{name: "foo", priority: 10, serverNames: []string{"Foo.Bar"}},
+			},
+			serverName: "foo.bar",
+			expected:   "foo",
+		},
+		{
+			desc: "no matching server name",
+			routes: []testRoute{
+				{name: "foo", priority: 10, serverNames: []string{"foo.bar"}},
+			},
+			serverName: "bar.foo",
+		},
+		{
+			desc: "catch-all with a lower priority",
+			routes: []testRoute{
+				{name: "all", priority: 1, serverNames: []string{CatchAll}},
+				{name: "foo", priority: 10, serverNames: []string{"foo.bar"}},
+			},
+			serverName: "foo.bar",
+			expected:   "foo",
+		},
+		{
+			desc: "catch-all with a higher priority",
+			routes: []testRoute{
+				{name: "all", priority: 100, serverNames: []string{CatchAll}},
+				{name: "foo", priority: 10, serverNames: []string{"foo.bar"}},
+			},
+			serverName: "foo.bar",
+			expected:   "all",
+		},
+		{
+			desc: "plain connection to catch-all",
+			routes: []testRoute{
+				{name: "all", priority: 1, serverNames: []string{CatchAll}},
+				{name: "foo", priority: 10, serverNames: []string{"foo.bar"}},
+			},
+			plain:    true,
+			expected: "all",
+		},
+		{
+			desc: "plain connection without catch-all",
+			routes: []testRoute{
+				{name: "foo", priority: 10, serverNames: []string{"foo.bar"}},
+			},
+			plain: true,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			routed := make(chan string, 1)
+			router := NewRouter()
+			for _, r := range test.routes {
+				name := r.name
+				router.AddRoute(name, r.priority, r.serverNames, HandlerFunc(func(conn net.Conn) {
+					routed <- name
+					conn.Close()
+				}))
+			}
+			router.SortRoutes()
+
+			client, server := net.Pipe()
+			go func() {
+				if test.plain {
+					client.Write([]byte("PING\r\n"))
+				} else {
+					tls.Client(client, &tls.Config{ServerName: test.serverName, InsecureSkipVerify: true}).Handshake()
+				}
+				client.Close()
+			}()
+
+			router.ServeTCP(server)
+			close(routed)
+			assert.Equal(t, test.expected, <-routed)
+		})
+	}
+}
+
+func TestRouterTLSTermination(t *testing.T) {
+	router := NewRouter()
+	router.AddRoute("echo", 10, []string{"foo.bar"}, &TLSHandler{
+		Config: &tls.Config{Certificates: []tls.Certificate{generateCertificate(t, "foo.bar")}},
+		Next: HandlerFunc(func(conn net.Conn) {
+			defer conn.Close()
+			buf := make([]byte, 4)
+			if _, err := conn.Read(buf); err == nil {
+				conn.Write(buf)
+			}
+		}),
+	})
+
+	client, server := net.Pipe()
+	go router.ServeTCP(server)
+
+	tlsClient := tls.Client(client, &tls.Config{ServerName: "foo.bar", InsecureSkipVerify: true})
+	defer tlsClient.Close()
+
+	_, err := tlsClient.Write([]byte("ping"))
+	require.NoError(t, err)
+
+	response, err := ioutil.ReadAll(tlsClient)
+	require.NoError(t, err)
+	assert.Equal(t, "ping", string(response))
+}
+
+func generateCertificate(t *testing.T, domain string) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: domain},
+		DNSNames:     []string{domain},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
@@ -0,0 +1,137 @@
+package tcp
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/containous/traefik/log"
+)
+
+// ErrServerClosed is returned by Serve after a call to Shutdown or Close.
+var ErrServerClosed = errors.New("tcp: Server closed")
+
+// Server accepts connections on a listener and hands each of them to the handler in its own goroutine.
+type Server struct {
+	Addr     string
+	Handler  Handler
+	listener net.Listener
+
+	lock   sync.Mutex
+	conns  map[net.Conn]struct{}
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// NewServer creates a server for the listener.
+func NewServer(addr string, listener net.Listener, handler Handler) *Server {
+	return &Server{
+		Addr:     addr,
+		Handler:  handler,
+		listener: listener,
+		conns:    make(map[net.Conn]struct{}),
+	}
+}
+
+// Serve accepts connections until the server is shut down.
+func (s *Server) Serve() error {
+	var tempDelay time.Duration
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			if s.isClosed() {
+				return ErrServerClosed
+			}
+			if netErr, ok := err.(net.Error); ok && netErr.Temporary() {
+				if tempDelay == 0 {
+					tempDelay = 5 * time.Millisecond
+				} else {
+					tempDelay *= 2
+				}
+				if tempDelay > time.Second {
+					tempDelay = time.Second
+				}
+				log.Errorf("Error accepting connection on %s: %v; retrying in %v", s.Addr, err, tempDelay)
+				time.Sleep(tempDelay)
+				continue
+			}
+			return err
+		}
+		tempDelay = 0
+
+		if !s.trackConn(conn) {
+			conn.Close()
+			return ErrServerClosed
+		}
+		go func() {
+			defer s.untrackConn(conn)
+			s.Handler.ServeTCP(conn)
+		}()
+	}
+}
+
+// Shutdown stops accepting connections, and waits for the active ones to be closed until the context is done.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.closeListener()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops accepting connections and closes the active ones.
+func (s *Server) Close() error {
+	err := s.closeListener()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for conn := range s.conns {
+		conn.Close()
+	}
+	return err
+}
+
+func (s *Server) closeListener() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	return s.listener.Close()
+}
+
+func (s *Server) isClosed() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.closed
+}
+
+func (s *Server) trackConn(conn net.Conn) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.closed {
+		return false
+	}
+	s.conns[conn] = struct{}{}
+	s.wg.Add(1)
+	return true
+}
+
+func (s *Server) untrackConn(conn net.Conn) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.conns, conn)
+	s.wg.Done()
+}
@@ -0,0 +1,81 @@
+package tcp
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerProxy(t *testing.T) {
+	backendListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer backendListener.Close()
+
+	go func() {
+		conn, err := backendListener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		io.Copy(conn, conn)
+		conn.(*net.TCPConn).CloseWrite()
+	}()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := NewServer(listener.Addr().String(), listener, NewProxy(backendListener.Addr().String(), time.Second))
+	served := make(chan error, 1)
+	go func() {
+		served <- server.Serve()
+	}()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.Write([]byte("ping"))
+	require.NoError(t, err)
+	require.NoError(t, conn.(*net.TCPConn).CloseWrite())
+
+	response, err := ioutil.ReadAll(conn)
+	require.NoError(t, err)
+	assert.Equal(t, "ping", string(response))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, server.Shutdown(ctx))
+	assert.Equal(t, ErrServerClosed, <-served)
+}
+
+func TestServerShutdownTimeout(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	accepted := make(chan struct{})
+	server := NewServer(listener.Addr().String(), listener, HandlerFunc(func(conn net.Conn) {
+		close(accepted)
+		ioutil.ReadAll(conn)
+		conn.Close()
+	}))
+	go server.Serve()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+	<-accepted
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, server.Shutdown(ctx))
+
+	require.NoError(t, server.Close())
+	_, err = ioutil.ReadAll(conn)
+	assert.NoError(t, err)
+}
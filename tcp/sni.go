@@ -0,0 +1,116 @@
+package tcp
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net"
+	"time"
+)
+
+const (
+	recordTypeHandshake = 0x16
+	recordHeaderLen     = 5
+	maxRecordLen        = 16384 + 2048
+)
+
+var errServerNameRead = errors.New("server name read")
+
+// peekedConn replays the bytes peeked from a connection before reading from it.
+type peekedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func newPeekedConn(conn net.Conn) *peekedConn {
+	return &peekedConn{Conn: conn, reader: bufio.NewReaderSize(conn, recordHeaderLen+maxRecordLen)}
+}
+
+func (c *peekedConn) Read(p []byte) (int, error) {
+	return c.reader.Read(p)
+}
+
+// CloseWrite shuts down the writing side of the underlying connection.
+func (c *peekedConn) CloseWrite() error {
+	return closeWrite(c.Conn)
+}
+
+// clientHelloServerName peeks the TLS ClientHello of the connection, without consuming it,
+// and returns the requested server name (SNI). It also tells whether the connection is a TLS one.
+// Peeking gives up after timeout, as server-first protocols never send anything.
+func clientHelloServerName(conn *peekedConn, timeout time.Duration) (string, bool) {
+	if timeout > 0 {
+		conn.Conn.SetReadDeadline(time.Now().Add(timeout))
+		defer conn.Conn.SetReadDeadline(time.Time{})
+	}
+
+	header, err := conn.reader.Peek(1)
+	if err != nil || header[0] != recordTypeHandshake {
+		return "", false
+	}
+
+	header, err = conn.reader.Peek(recordHeaderLen)
+	if err != nil {
+		return "", false
+	}
+
+	recordLen := int(header[3])<<8 | int(header[4])
+	if recordLen > maxRecordLen {
+		return "", true
+	}
+
+	hello, err := conn.reader.Peek(recordHeaderLen + recordLen)
+	if err != nil {
+		return "", true
+	}
+
+	var serverName string
+	tls.Server(&helloConn{reader: bytes.NewReader(hello)}, &tls.Config{
+		GetConfigForClient: func(info *tls.ClientHelloInfo) (*tls.Config, error) {
+			serverName = info.ServerName
+			return nil, errServerNameRead
+		},
+	}).Handshake()
+
+	return serverName, true
+}
+
+// helloConn is a read-only connection on a copy of the ClientHello, used to parse it with crypto/tls.
+type helloConn struct {
+	net.Conn
+	reader io.Reader
+}
+
+func (c *helloConn) Read(p []byte) (int, error) {
+	return c.reader.Read(p)
+}
+
+func (c *helloConn) Write(p []byte) (int, error) {
+	return 0, io.EOF
+}
+
+func (c *helloConn) Close() error {
+	return nil
+}
+
+func (c *helloConn) SetDeadline(t time.Time) error {
+	return nil
+}
+
+func (c *helloConn) SetReadDeadline(t time.Time) error {
+	return nil
+}
+
+func (c *helloConn) SetWriteDeadline(t time.Time) error {
+	return nil
+}
+
+func (c *helloConn) LocalAddr() net.Addr {
+	return nil
+}
+
+func (c *helloConn) RemoteAddr() net.Addr {
+	return nil
+}
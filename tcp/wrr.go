@@ -0,0 +1,67 @@
+package tcp
+
+import (
+	"net"
+	"sync"
+
+	"github.com/containous/traefik/log"
+)
+
+// WRRLoadBalancer spreads connections over handlers with a smooth weighted round robin.
+type WRRLoadBalancer struct {
+	servers []*weightedServer
+	lock    sync.Mutex
+}
+
+type weightedServer struct {
+	handler       Handler
+	weight        int
+	currentWeight int
+}
+
+// NewWRRLoadBalancer creates a new, empty, load-balancer.
+func NewWRRLoadBalancer() *WRRLoadBalancer {
+	return &WRRLoadBalancer{}
+}
+
+// AddServer adds a handler with the given weight. Weights lower than 1 are set to 1.
+func (b *WRRLoadBalancer) AddServer(handler Handler, weight int) {
+	if weight < 1 {
+		weight = 1
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.servers = append(b.servers, &weightedServer{handler: handler, weight: weight})
+}
+
+// ServeTCP hands the connection to the next server.
+func (b *WRRLoadBalancer) ServeTCP(conn net.Conn) {
+	next := b.next()
+	if next == nil {
+		log.Errorf("No server available for connection from %s", conn.RemoteAddr())
+		conn.Close()
+		return
+	}
+	next.ServeTCP(conn)
+}
+
+func (b *WRRLoadBalancer) next() Handler {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	var selected *weightedServer
+	total := 0
+	for _, server := range b.servers {
+		server.currentWeight += server.weight
+		total += server.weight
+		if selected == nil || server.currentWeight > selected.currentWeight {
+			selected = server
+		}
+	}
+	if selected == nil {
+		return nil
+	}
+	selected.currentWeight -= total
+	return selected.handler
+}
@@ -0,0 +1,64 @@
+package tcp
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWRRLoadBalancer(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		weights  map[string]int
+		expected []string
+	}{
+		{
+			desc:     "no server",
+			expected: []string{""},
+		},
+		{
+			desc:     "same weights",
+			weights:  map[string]int{"a": 1, "b": 1},
+			expected: []string{"a", "b", "a", "b"},
+		},
+		{
+			desc:     "different weights",
+			weights:  map[string]int{"a": 3, "b": 1},
+			expected: []string{"a", "a", "b", "a", "a", "a", "b", "a"},
+		},
+		{
+			desc:     "zero weight",
+			weights:  map[string]int{"a": 0, "b": 1},
+			expected: []string{"a", "b", "a", "b"},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var served string
+			lb := NewWRRLoadBalancer()
+			for _, name := range []string{"a", "b"} {
+				if weight, ok := test.weights[name]; ok {
+					name := name
+					lb.AddServer(HandlerFunc(func(conn net.Conn) {
+						served = name
+						conn.Close()
+					}), weight)
+				}
+			}
+
+			var servers []string
+			for range test.expected {
+				served = ""
+				_, conn := net.Pipe()
+				lb.ServeTCP(conn)
+				servers = append(servers, served)
+			}
+			assert.Equal(t, test.expected, servers)
+		})
+	}
+}
@@ -20,12 +20,44 @@ import (
 
 // Backend holds backend configuration.
 type Backend struct {
-	Servers        map[string]Server `json:"servers,omitempty"`
-	CircuitBreaker *CircuitBreaker   `json:"circuitBreaker,omitempty"`
-	LoadBalancer   *LoadBalancer     `json:"loadBalancer,omitempty"`
-	MaxConn        *MaxConn          `json:"maxConn,omitempty"`
-	HealthCheck    *HealthCheck      `json:"healthCheck,omitempty"`
-	Buffering      *Buffering        `json:"buffering,omitempty"`
+	Servers          map[string]Server `json:"servers,omitempty"`
+	CircuitBreaker   *CircuitBreaker   `json:"circuitBreaker,omitempty"`
+	LoadBalancer     *LoadBalancer     `json:"loadBalancer,omitempty"`
+	MaxConn          *MaxConn          `json:"maxConn,omitempty"`
+	HealthCheck      *HealthCheck      `json:"healthCheck,omitempty"`
+	OutlierDetection *OutlierDetection `json:"outlierDetection,omitempty"`
+	Buffering        *Buffering        `json:"buffering,omitempty"`
+	Protocol         string            `json:"protocol,omitempty"`
+	Transport        *Transport        `json:"transport,omitempty"`
+	Retry            *Retry            `json:"retry,omitempty"`
+	Hedging          *Hedging          `json:"hedging,omitempty"`
+}
+
+const (
+	// BackendProtocolHTTP forwards requests over HTTP/1.1, or over HTTP/2 with TLS when the server supports it.
+	BackendProtocolHTTP = "http"
+	// BackendProtocolH2C forwards requests over cleartext HTTP/2 (h2c).
+	BackendProtocolH2C = "h2c"
+)
+
+// Transport holds the connection settings of the servers of a backend.
+type Transport struct {
+	DialTimeout           string        `json:"dialTimeout,omitempty"`
+	ResponseHeaderTimeout string        `json:"responseHeaderTimeout,omitempty"`
+	IdleConnTimeout       string        `json:"idleConnTimeout,omitempty"`
+	MaxIdleConnsPerHost   int           `json:"maxIdleConnsPerHost,omitempty"`
+	DisableKeepAlives     bool          `json:"disableKeepAlives,omitempty"`
+	DisableHTTP2          bool          `json:"disableHTTP2,omitempty"`
+	TLS                   *TransportTLS `json:"tls,omitempty"`
+}
+
+// TransportTLS holds the TLS settings of the connections to the servers of a backend.
+type TransportTLS struct {
+	CA                 string `json:"ca,omitempty"`
+	ServerName         string `json:"serverName,omitempty"`
+	Cert               string `json:"cert,omitempty"`
+	Key                string `json:"key,omitempty"`
+	InsecureSkipVerify bool   `json:"insecureSkipVerify,omitempty"`
 }
 
 // MaxConn holds maximum connection configuration
@@ -36,14 +68,30 @@ type MaxConn struct {
 
 // LoadBalancer holds load balancing configuration.
 type LoadBalancer struct {
-	Method     string      `json:"method,omitempty"`
-	Sticky     bool        `json:"sticky,omitempty"` // Deprecated: use Stickiness instead
-	Stickiness *Stickiness `json:"stickiness,omitempty"`
+	Method            string          `json:"method,omitempty"`
+	Sticky            bool            `json:"sticky,omitempty"` // Deprecated: use Stickiness instead
+	Stickiness        *Stickiness     `json:"stickiness,omitempty"`
+	Hash              *ConsistentHash `json:"hash,omitempty"`
+	SlowStart         string          `json:"slowStart,omitempty"`
+	FailoverThreshold int             `json:"failoverThreshold,omitempty"`
 }
 
 // Stickiness holds sticky session configuration.
 type Stickiness struct {
 	CookieName string `json:"cookieName,omitempty"`
+	Secret     string `json:"secret,omitempty"`
+	Secure     bool   `json:"secure,omitempty"`
+	HTTPOnly   bool   `json:"httpOnly,omitempty"`
+	SameSite   string `json:"sameSite,omitempty"`
+	MaxAge     int    `json:"maxAge,omitempty"` // in seconds
+	Domain     string `json:"domain,omitempty"`
+	Path       string `json:"path,omitempty"`
+}
+
+// ConsistentHash holds consistent hashing configuration.
+type ConsistentHash struct {
+	Key        string  `json:"key,omitempty"`
+	LoadFactor float64 `json:"loadFactor,omitempty"`
 }
 
 // CircuitBreaker holds circuit breaker configuration.
@@ -60,17 +108,55 @@ type Buffering struct {
 	RetryExpression      string `json:"retryExpression,omitempty"`
 }
 
-// HealthCheck holds HealthCheck configuration
+// HealthCheck holds HealthCheck configuration.
 type HealthCheck struct {
-	Path     string `json:"path,omitempty"`
-	Port     int    `json:"port,omitempty"`
-	Interval string `json:"interval,omitempty"`
+	Type        string            `json:"type,omitempty"`
+	Path        string            `json:"path,omitempty"`
+	Port        int               `json:"port,omitempty"`
+	Interval    string            `json:"interval,omitempty"`
+	Timeout     string            `json:"timeout,omitempty"`
+	Scheme      string            `json:"scheme,omitempty"`
+	Method      string            `json:"method,omitempty"`
+	Hostname    string            `json:"hostname,omitempty"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	StatusCodes []string          `json:"statusCodes,omitempty"`
+	Body        string            `json:"body,omitempty"`
+	Rise        int               `json:"rise,omitempty"`
+	Fall        int               `json:"fall,omitempty"`
+	GRPCService string            `json:"grpcService,omitempty"`
+}
+
+// OutlierDetection holds passive health check configuration.
+type OutlierDetection struct {
+	ConsecutiveErrors  int    `json:"consecutiveErrors,omitempty"`
+	BaseEjectionTime   string `json:"baseEjectionTime,omitempty"`
+	MaxEjectionTime    string `json:"maxEjectionTime,omitempty"`
+	MaxEjectionPercent int    `json:"maxEjectionPercent,omitempty"`
+}
+
+// Retry holds the retry policy of a backend.
+type Retry struct {
+	Attempts       int      `json:"attempts,omitempty"`
+	StatusCodes    []string `json:"statusCodes,omitempty"`
+	IdempotentOnly bool     `json:"idempotentOnly,omitempty"`
+	PerTryTimeout  string   `json:"perTryTimeout,omitempty"`
+	Backoff        string   `json:"backoff,omitempty"`
+	MaxBackoff     string   `json:"maxBackoff,omitempty"`
+	BudgetPercent  int      `json:"budgetPercent,omitempty"`
+}
+
+// Hedging holds request hedging configuration.
+type Hedging struct {
+	Delay      string `json:"delay,omitempty"`
+	Percentile int    `json:"percentile,omitempty"`
 }
 
 // Server holds server configuration.
 type Server struct {
-	URL    string `json:"url,omitempty"`
-	Weight int    `json:"weight"`
+	URL      string `json:"url,omitempty"`
+	Weight   int    `json:"weight"`
+	Zone     string `json:"zone,omitempty"`
+	Priority int    `json:"priority,omitempty"`
 }
 
 // Route holds route configuration.
@@ -164,6 +250,36 @@ type Frontend struct {
 	Errors               map[string]*ErrorPage `json:"errors,omitempty"`
 	RateLimit            *RateLimit            `json:"ratelimit,omitempty"`
 	Redirect             *Redirect             `json:"redirect,omitempty"`
+	TLSPassthrough       bool                  `json:"tlsPassthrough,omitempty"`
+	Split                *TrafficSplit         `json:"split,omitempty"`
+	Mirror               *Mirror               `json:"mirror,omitempty"`
+	FallbackBackend      string                `json:"fallbackBackend,omitempty"`
+	Timeout              *FrontendTimeout      `json:"timeout,omitempty"`
+}
+
+// FrontendTimeout holds frontend request timeout configuration.
+type FrontendTimeout struct {
+	Request        string `json:"request,omitempty"`
+	DeadlineHeader string `json:"deadlineHeader,omitempty"`
+}
+
+// TrafficSplit holds traffic splitting configuration.
+type TrafficSplit struct {
+	Backends   []WeightedBackend `json:"backends,omitempty"`
+	Stickiness *Stickiness       `json:"stickiness,omitempty"`
+}
+
+// Mirror holds request mirroring configuration.
+type Mirror struct {
+	Backend     string `json:"backend,omitempty"`
+	Percent     int    `json:"percent,omitempty"`
+	MaxBodySize int64  `json:"maxBodySize,omitempty"` // in bytes
+}
+
+// WeightedBackend holds a backend receiving a share of the traffic of a frontend.
+type WeightedBackend struct {
+	Backend string `json:"backend,omitempty"`
+	Weight  int    `json:"weight"`
 }
 
 // Redirect configures a redirection of an entry point to another, or to an URL
@@ -182,11 +298,20 @@ const (
 	Wrr LoadBalancerMethod = iota
 	// Drr = Dynamic Round Robin
 	Drr
+	// LeastConn = Least outstanding requests
+	LeastConn
+	// Hash = Consistent hashing with bounded load
+	Hash
+	// P2C = Power of two choices, using the moving average of the latency
+	P2C
 )
 
 var loadBalancerMethodNames = []string{
 	"Wrr",
 	"Drr",
+	"LeastConn",
+	"Hash",
+	"P2C",
 }
 
 // NewLoadBalancerMethod create a new LoadBalancerMethod from a given LoadBalancer.
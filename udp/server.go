@@ -0,0 +1,208 @@
+package udp
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/containous/traefik/log"
+)
+
+// ErrServerClosed is returned by Serve after a call to Close.
+var ErrServerClosed = errors.New("udp: Server closed")
+
+// maxDatagramSize is the maximum size of a UDP payload.
+const maxDatagramSize = 65535
+
+// Server forwards the datagrams received on a packet connection to the servers of a load-balancer.
+// The datagrams of a client are sent to the same server until its session has been idle for the idle timeout,
+// or its server has been removed from the load-balancer (e.g. by a health check).
+type Server struct {
+	Addr        string
+	conn        net.PacketConn
+	handler     *HandlerSwitcher
+	idleTimeout time.Duration
+
+	lock     sync.Mutex
+	sessions map[string]*session
+	closed   bool
+	done     chan struct{}
+}
+
+type session struct {
+	client     net.Addr
+	server     *url.URL
+	conn       net.Conn
+	lastActive int64
+}
+
+func (s *session) touch() {
+	atomic.StoreInt64(&s.lastActive, time.Now().UnixNano())
+}
+
+func (s *session) idleSince(now time.Time) time.Duration {
+	return now.Sub(time.Unix(0, atomic.LoadInt64(&s.lastActive)))
+}
+
+// NewServer creates a server for the packet connection.
+func NewServer(addr string, conn net.PacketConn, handler *HandlerSwitcher, idleTimeout time.Duration) *Server {
+	return &Server{
+		Addr:        addr,
+		conn:        conn,
+		handler:     handler,
+		idleTimeout: idleTimeout,
+		sessions:    make(map[string]*session),
+		done:        make(chan struct{}),
+	}
+}
+
+// Serve forwards datagrams until the server is closed.
+func (s *Server) Serve() error {
+	go s.expireSessions()
+
+	buf := make([]byte, maxDatagramSize)
+	for {
+		n, addr, err := s.conn.ReadFrom(buf)
+		if err != nil {
+			if s.isClosed() {
+				return ErrServerClosed
+			}
+			if netErr, ok := err.(net.Error); ok && netErr.Temporary() {
+				continue
+			}
+			return err
+		}
+
+		sess, err := s.getSession(addr)
+		if err != nil {
+			log.Errorf("Error forwarding datagram from %s on %s: %v", addr, s.Addr, err)
+			continue
+		}
+
+		sess.touch()
+		if _, err := sess.conn.Write(buf[:n]); err != nil {
+			log.Debugf("Error forwarding datagram from %s to %s: %v", addr, sess.server, err)
+		}
+	}
+}
+
+// Close stops the server and closes all the sessions.
+func (s *Server) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	close(s.done)
+
+	for key, sess := range s.sessions {
+		sess.conn.Close()
+		delete(s.sessions, key)
+	}
+	return s.conn.Close()
+}
+
+func (s *Server) isClosed() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.closed
+}
+
+// getSession returns the session of the client, creating it if needed.
+func (s *Server) getSession(addr net.Addr) (*session, error) {
+	lb := s.handler.GetHandler()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	key := addr.String()
+	if sess, ok := s.sessions[key]; ok {
+		if lb != nil && lb.hasServer(sess.server) {
+			return sess, nil
+		}
+		log.Debugf("Server %s of session from %s is no longer available", sess.server, key)
+		sess.conn.Close()
+		delete(s.sessions, key)
+	}
+
+	if lb == nil {
+		return nil, errors.New("no frontend")
+	}
+
+	server, err := lb.nextServer()
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := net.Dial("udp", server.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	sess := &session{client: addr, server: server, conn: conn}
+	sess.touch()
+	s.sessions[key] = sess
+	log.Debugf("New UDP session from %s to %s", key, server)
+
+	go s.forwardReplies(sess)
+	return sess, nil
+}
+
+// forwardReplies sends the datagrams of the server back to the client, until the session is closed.
+func (s *Server) forwardReplies(sess *session) {
+	buf := make([]byte, maxDatagramSize)
+	for {
+		n, err := sess.conn.Read(buf)
+		if err != nil {
+			// The session is closed, or the server is unreachable: the next datagram of the client opens a new session.
+			s.closeSession(sess)
+			return
+		}
+
+		sess.touch()
+		if _, err := s.conn.WriteTo(buf[:n], sess.client); err != nil {
+			log.Debugf("Error sending datagram from %s back to %s: %v", sess.server, sess.client, err)
+		}
+	}
+}
+
+func (s *Server) closeSession(sess *session) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	sess.conn.Close()
+	if s.sessions[sess.client.String()] == sess {
+		delete(s.sessions, sess.client.String())
+	}
+}
+
+// expireSessions closes the sessions idle for longer than the idle timeout.
+func (s *Server) expireSessions() {
+	if s.idleTimeout <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.idleTimeout / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case now := <-ticker.C:
+			s.lock.Lock()
+			for key, sess := range s.sessions {
+				if sess.idleSince(now) > s.idleTimeout {
+					log.Debugf("Closing idle UDP session from %s to %s", key, sess.server)
+					sess.conn.Close()
+					delete(s.sessions, key)
+				}
+			}
+			s.lock.Unlock()
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package udp
+
+import (
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startEchoServer starts a UDP server replying to datagrams with its name.
+func startEchoServer(t *testing.T, name string) *url.URL {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go func() {
+		buf := make([]byte, 1024)
+		for {
+			_, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			conn.WriteTo([]byte(name), addr)
+		}
+	}()
+
+	return &url.URL{Scheme: "udp", Host: conn.LocalAddr().String()}
+}
+
+func startServer(t *testing.T, lb *WRRLoadBalancer, idleTimeout time.Duration) *Server {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := NewServer(conn.LocalAddr().String(), conn, NewHandlerSwitcher(lb), idleTimeout)
+	go server.Serve()
+	return server
+}
+
+func exchange(t *testing.T, client net.Conn) string {
+	_, err := client.Write([]byte("ping"))
+	require.NoError(t, err)
+
+	require.NoError(t, client.SetReadDeadline(time.Now().Add(time.Second)))
+	buf := make([]byte, 1024)
+	n, err := client.Read(buf)
+	require.NoError(t, err)
+	return string(buf[:n])
+}
+
+func TestServerSessionAffinity(t *testing.T) {
+	lb := NewWRRLoadBalancer()
+	lb.AddServer(startEchoServer(t, "a"), 1)
+	lb.AddServer(startEchoServer(t, "b"), 1)
+
+	server := startServer(t, lb, time.Minute)
+	defer server.Close()
+
+	client1, err := net.Dial("udp", server.Addr)
+	require.NoError(t, err)
+	defer client1.Close()
+
+	client2, err := net.Dial("udp", server.Addr)
+	require.NoError(t, err)
+	defer client2.Close()
+
+	first := exchange(t, client1)
+	second := exchange(t, client2)
+	assert.NotEqual(t, first, second)
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, first, exchange(t, client1))
+		assert.Equal(t, second, exchange(t, client2))
+	}
+}
+
+func TestServerRemovedServer(t *testing.T) {
+	a := startEchoServer(t, "a")
+	lb := NewWRRLoadBalancer()
+	lb.AddServer(a, 1)
+	lb.AddServer(startEchoServer(t, "b"), 1)
+
+	server := startServer(t, lb, time.Minute)
+	defer server.Close()
+
+	client, err := net.Dial("udp", server.Addr)
+	require.NoError(t, err)
+	defer client.Close()
+
+	assert.Equal(t, "a", exchange(t, client))
+
+	require.NoError(t, lb.RemoveServer(a))
+	assert.Equal(t, "b", exchange(t, client))
+}
+
+func TestServerIdleTimeout(t *testing.T) {
+	lb := NewWRRLoadBalancer()
+	lb.AddServer(startEchoServer(t, "a"), 1)
+
+	server := startServer(t, lb, 50*time.Millisecond)
+	defer server.Close()
+
+	client, err := net.Dial("udp", server.Addr)
+	require.NoError(t, err)
+	defer client.Close()
+
+	assert.Equal(t, "a", exchange(t, client))
+	assert.Len(t, sessions(server), 1)
+
+	time.Sleep(200 * time.Millisecond)
+	assert.Empty(t, sessions(server))
+}
+
+func sessions(server *Server) map[string]*session {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+
+	sessions := make(map[string]*session)
+	for key, sess := range server.sessions {
+		sessions[key] = sess
+	}
+	return sessions
+}
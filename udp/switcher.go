@@ -0,0 +1,28 @@
+package udp
+
+import (
+	"github.com/containous/traefik/safe"
+)
+
+// HandlerSwitcher allows hot switching of the load-balancer of a UDP entry point.
+type HandlerSwitcher struct {
+	lb *safe.Safe
+}
+
+// NewHandlerSwitcher builds a new instance of HandlerSwitcher.
+// The load-balancer is nil as long as no frontend is wired to the entry point.
+func NewHandlerSwitcher(lb *WRRLoadBalancer) *HandlerSwitcher {
+	return &HandlerSwitcher{
+		lb: safe.New(lb),
+	}
+}
+
+// GetHandler returns the current load-balancer.
+func (hs *HandlerSwitcher) GetHandler() *WRRLoadBalancer {
+	return hs.lb.Get().(*WRRLoadBalancer)
+}
+
+// UpdateHandler safely updates the current load-balancer with a new one.
+func (hs *HandlerSwitcher) UpdateHandler(lb *WRRLoadBalancer) {
+	hs.lb.Set(lb)
+}
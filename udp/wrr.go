@@ -0,0 +1,133 @@
+package udp
+
+import (
+	"errors"
+	"net/url"
+	"sync"
+
+	"github.com/vulcand/oxy/roundrobin"
+)
+
+// WRRLoadBalancer picks the servers of new sessions with a smooth weighted round robin.
+// It implements healthcheck.LoadBalancer, so that unhealthy servers are given no sessions.
+type WRRLoadBalancer struct {
+	servers []*weightedServer
+	weights map[string]int
+	lock    sync.Mutex
+}
+
+type weightedServer struct {
+	url           *url.URL
+	weight        int
+	currentWeight int
+}
+
+// NewWRRLoadBalancer creates a new, empty, load-balancer.
+func NewWRRLoadBalancer() *WRRLoadBalancer {
+	return &WRRLoadBalancer{weights: make(map[string]int)}
+}
+
+// AddServer adds a server with the given weight. Weights lower than 1 are set to 1.
+// The weight is kept when the server is removed then upserted again by health checks.
+func (b *WRRLoadBalancer) AddServer(u *url.URL, weight int) {
+	if weight < 1 {
+		weight = 1
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.weights[u.String()] = weight
+	b.upsert(u)
+}
+
+// UpsertServer adds back a server removed from the load-balancer, with the weight it was added with.
+func (b *WRRLoadBalancer) UpsertServer(u *url.URL, options ...roundrobin.ServerOption) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.upsert(u)
+	return nil
+}
+
+func (b *WRRLoadBalancer) upsert(u *url.URL) {
+	weight, ok := b.weights[u.String()]
+	if !ok {
+		weight = 1
+	}
+
+	for _, server := range b.servers {
+		if server.url.String() == u.String() {
+			server.weight = weight
+			return
+		}
+	}
+	b.servers = append(b.servers, &weightedServer{url: u, weight: weight})
+	b.resetCycle()
+}
+
+// resetCycle restarts the round robin, after the servers have changed.
+func (b *WRRLoadBalancer) resetCycle() {
+	for _, server := range b.servers {
+		server.currentWeight = 0
+	}
+}
+
+// RemoveServer removes a server from the load-balancer.
+func (b *WRRLoadBalancer) RemoveServer(u *url.URL) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	for i, server := range b.servers {
+		if server.url.String() == u.String() {
+			b.servers = append(b.servers[:i], b.servers[i+1:]...)
+			b.resetCycle()
+			return nil
+		}
+	}
+	return errors.New("server not found")
+}
+
+// Servers returns the servers of the load-balancer.
+func (b *WRRLoadBalancer) Servers() []*url.URL {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	var urls []*url.URL
+	for _, server := range b.servers {
+		urls = append(urls, server.url)
+	}
+	return urls
+}
+
+// hasServer tells whether the server is in the load-balancer.
+func (b *WRRLoadBalancer) hasServer(u *url.URL) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	for _, server := range b.servers {
+		if server.url.String() == u.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// nextServer returns the server of a new session.
+func (b *WRRLoadBalancer) nextServer() (*url.URL, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	var selected *weightedServer
+	total := 0
+	for _, server := range b.servers {
+		server.currentWeight += server.weight
+		total += server.weight
+		if selected == nil || server.currentWeight > selected.currentWeight {
+			selected = server
+		}
+	}
+	if selected == nil {
+		return nil, errors.New("no server available")
+	}
+	selected.currentWeight -= total
+	return selected.url, nil
+}
@@ -0,0 +1,44 @@
+package udp
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vulcand/oxy/roundrobin"
+)
+
+func TestWRRLoadBalancer(t *testing.T) {
+	a := &url.URL{Scheme: "udp", Host: "10.0.0.1:53"}
+	b := &url.URL{Scheme: "udp", Host: "10.0.0.2:53"}
+
+	lb := NewWRRLoadBalancer()
+	_, err := lb.nextServer()
+	assert.Error(t, err)
+
+	lb.AddServer(a, 3)
+	lb.AddServer(b, 1)
+	assert.Equal(t, []*url.URL{a, b}, lb.Servers())
+	assert.Equal(t, []string{"10.0.0.1:53", "10.0.0.1:53", "10.0.0.2:53", "10.0.0.1:53"}, nextHosts(t, lb, 4))
+
+	require.NoError(t, lb.RemoveServer(a))
+	assert.False(t, lb.hasServer(a))
+	assert.Error(t, lb.RemoveServer(a))
+	assert.Equal(t, []string{"10.0.0.2:53", "10.0.0.2:53"}, nextHosts(t, lb, 2))
+
+	// The weight the server was added with is restored.
+	require.NoError(t, lb.UpsertServer(a, roundrobin.Weight(1)))
+	assert.True(t, lb.hasServer(a))
+	assert.Equal(t, []string{"10.0.0.1:53", "10.0.0.2:53", "10.0.0.1:53", "10.0.0.1:53"}, nextHosts(t, lb, 4))
+}
+
+func nextHosts(t *testing.T, lb *WRRLoadBalancer, count int) []string {
+	var hosts []string
+	for i := 0; i < count; i++ {
+		u, err := lb.nextServer()
+		require.NoError(t, err)
+		hosts = append(hosts, u.Host)
+	}
+	return hosts
+}
@@ -0,0 +1,73 @@
+package whitelist
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/vulcand/oxy/forward"
+)
+
+// ClientIP resolves the address of the client of a request.
+// The X-Forwarded-For header is only taken into account when the request comes from a trusted IP.
+type ClientIP struct {
+	trustedIPs *IP
+	insecure   bool
+}
+
+// NewClientIP builds a new ClientIP trusting the X-Forwarded-For header of the given IPs and CIDRs,
+// or of all the addresses when insecure.
+func NewClientIP(trustedIPs []string, insecure bool) (*ClientIP, error) {
+	clientIP := &ClientIP{insecure: insecure}
+	if len(trustedIPs) > 0 {
+		var err error
+		clientIP.trustedIPs, err = NewIP(trustedIPs, false)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return clientIP, nil
+}
+
+// IP returns the IP of the client of the request, nil when it can't be parsed.
+func (c *ClientIP) IP(req *http.Request) net.IP {
+	remoteAddr, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		remoteAddr = req.RemoteAddr
+	}
+	ip := net.ParseIP(remoteAddr)
+	if ip == nil || !c.isTrusted(ip) {
+		return ip
+	}
+
+	var forwardedIPs []string
+	for _, values := range req.Header[forward.XForwardedFor] {
+		for _, value := range strings.Split(values, ",") {
+			forwardedIPs = append(forwardedIPs, strings.TrimSpace(value))
+		}
+	}
+
+	// Walk the proxies chain backwards, the client is the first hop which is not trusted.
+	for i := len(forwardedIPs) - 1; i >= 0; i-- {
+		forwardedIP := net.ParseIP(forwardedIPs[i])
+		if forwardedIP == nil {
+			return nil
+		}
+		ip = forwardedIP
+		if !c.isTrusted(ip) {
+			break
+		}
+	}
+	return ip
+}
+
+func (c *ClientIP) isTrusted(ip net.IP) bool {
+	if c.insecure {
+		return true
+	}
+	if c.trustedIPs == nil {
+		return false
+	}
+	trusted, err := c.trustedIPs.ContainsIP(ip)
+	return err == nil && trusted
+}